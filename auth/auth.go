@@ -0,0 +1,101 @@
+// Package auth authenticates gRPC callers with API keys or JWTs and authorizes
+// each RPC by role.
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Role is granted to a caller and required by an RPC. Every role includes the
+// permissions of the roles below it: admin, then scorekeeper, then reader.
+type Role string
+
+const (
+	// Public RPCs need no credentials at all.
+	Public      Role = "public"
+	Reader      Role = "reader"
+	Scorekeeper Role = "scorekeeper"
+	Admin       Role = "admin"
+)
+
+var roleRanks = map[Role]int{Public: 0, Reader: 1, Scorekeeper: 2, Admin: 3}
+
+// ParseRole returns the role named name. Public is not a role that can be granted.
+func ParseRole(name string) (Role, error) {
+	role := Role(name)
+	if _, ok := roleRanks[role]; !ok || role == Public {
+		return "", fmt.Errorf("unknown role %q, want reader, scorekeeper or admin", name)
+	}
+	return role, nil
+}
+
+// Includes reports whether a caller with role r may call an RPC requiring required.
+func (r Role) Includes(required Role) bool {
+	rank, ok := roleRanks[r]
+	return ok && rank >= roleRanks[required]
+}
+
+// Principal is an authenticated caller.
+type Principal struct {
+	// Subject names the caller: the JWT subject, or the name of the API key. It is
+	// empty for anonymous callers.
+	Subject string
+	// KeyID is the id of the caller's API key, zero for callers with a JWT.
+	KeyID int
+	Role  Role
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns ctx carrying the caller p.
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the caller of the RPC handled in ctx, if it was authenticated.
+func FromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+// APIKeyPrefix starts every API key, telling them apart from JWTs.
+const APIKeyPrefix = "nba_"
+
+// NewAPIKey returns a new random API key, along with the prefix and hash to store for it.
+func NewAPIKey() (key, prefix, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	key = APIKeyPrefix + hex.EncodeToString(b)
+	return key, KeyPrefix(key), HashAPIKey(key), nil
+}
+
+// ValidateAPIKey reports whether key has the format of an API key and is long enough to be safe.
+func ValidateAPIKey(key string) error {
+	if !strings.HasPrefix(key, APIKeyPrefix) {
+		return fmt.Errorf("api key must start with %q", APIKeyPrefix)
+	}
+	if len(key) < len(APIKeyPrefix)+32 {
+		return errors.New("api key must be at least 32 characters after the prefix")
+	}
+	return nil
+}
+
+// KeyPrefix returns the start of key that is stored in the clear to identify it.
+func KeyPrefix(key string) string {
+	return key[:min(len(key), len(APIKeyPrefix)+8)]
+}
+
+// HashAPIKey returns the hash stored for key. API keys are long and random, so a fast
+// hash is safe and lets every request look its key up by hash.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
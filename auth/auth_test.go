@@ -0,0 +1,280 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"nba/auth"
+	"nba/memory"
+	"nba/model"
+)
+
+func TestRoleIncludes(t *testing.T) {
+	tests := []struct {
+		role, required auth.Role
+		want           bool
+	}{
+		{auth.Admin, auth.Scorekeeper, true},
+		{auth.Scorekeeper, auth.Reader, true},
+		{auth.Reader, auth.Reader, true},
+		{auth.Reader, auth.Scorekeeper, false},
+		{auth.Scorekeeper, auth.Admin, false},
+		{auth.Role("owner"), auth.Reader, false},
+	}
+	for _, tt := range tests {
+		if got := tt.role.Includes(tt.required); got != tt.want {
+			t.Errorf("%s.Includes(%s) = %v, want %v", tt.role, tt.required, got, tt.want)
+		}
+	}
+	if _, err := auth.ParseRole("public"); err == nil {
+		t.Error("ParseRole(public) succeeded, want an error")
+	}
+}
+
+func TestNewAPIKey(t *testing.T) {
+	key, prefix, hash, err := auth.NewAPIKey()
+	if err != nil {
+		t.Fatalf("NewAPIKey: %v", err)
+	}
+	if err := auth.ValidateAPIKey(key); err != nil {
+		t.Fatalf("ValidateAPIKey(%q): %v", key, err)
+	}
+	if prefix != auth.KeyPrefix(key) || hash != auth.HashAPIKey(key) {
+		t.Fatalf("prefix, hash = %q, %q, want the prefix and hash of %q", prefix, hash, key)
+	}
+	if other, _, _, _ := auth.NewAPIKey(); other == key {
+		t.Fatal("NewAPIKey returned the same key twice")
+	}
+}
+
+// storeKey stores a new API key granting role and returns the secret.
+func storeKey(t *testing.T, repo *memory.PlayerRepository, role auth.Role, revoked bool) string {
+	t.Helper()
+	key, prefix, hash, err := auth.NewAPIKey()
+	if err != nil {
+		t.Fatalf("NewAPIKey: %v", err)
+	}
+	stored, err := repo.SaveAPIKey(context.Background(), model.APIKey{Name: string(role) + " key", Prefix: prefix, Hash: hash, Role: string(role), CreatedAt: time.Now()})
+	if err != nil {
+		t.Fatalf("SaveAPIKey: %v", err)
+	}
+	if revoked {
+		if _, err := repo.RevokeAPIKey(context.Background(), stored.ID, time.Now()); err != nil {
+			t.Fatalf("RevokeAPIKey: %v", err)
+		}
+	}
+	return key
+}
+
+// withMetadata returns a context for an incoming request carrying the key/value pairs.
+func withMetadata(kv ...string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(kv...))
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	repo := memory.NewPlayerRepository()
+	scorekeeper := storeKey(t, repo, auth.Scorekeeper, false)
+	revoked := storeKey(t, repo, auth.Admin, true)
+	a, err := auth.NewAuthenticator(repo, auth.Options{})
+	if err != nil {
+		t.Fatalf("NewAuthenticator: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		wantRole auth.Role
+		wantErr  error
+	}{
+		{"bearer", withMetadata("authorization", "Bearer "+scorekeeper), auth.Scorekeeper, nil},
+		{"api key header", withMetadata(auth.APIKeyHeader, scorekeeper), auth.Scorekeeper, nil},
+		{"unknown", withMetadata(auth.APIKeyHeader, auth.APIKeyPrefix+"unknown"), "", auth.ErrInvalidCredentials},
+		{"revoked", withMetadata(auth.APIKeyHeader, revoked), "", auth.ErrInvalidCredentials},
+		{"no credentials", withMetadata(), "", auth.ErrNoCredentials},
+		{"JWT without a key set", withMetadata("authorization", "Bearer a.b.c"), "", auth.ErrInvalidCredentials},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := a.Authenticate(tt.ctx)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Authenticate error = %v, want %v", err, tt.wantErr)
+			}
+			if p.Role != tt.wantRole {
+				t.Fatalf("role = %q, want %q", p.Role, tt.wantRole)
+			}
+		})
+	}
+}
+
+func TestAuthenticateAnonymous(t *testing.T) {
+	a, err := auth.NewAuthenticator(memory.NewPlayerRepository(), auth.Options{Anonymous: auth.Reader})
+	if err != nil {
+		t.Fatalf("NewAuthenticator: %v", err)
+	}
+	p, err := a.Authenticate(withMetadata())
+	if err != nil || p.Role != auth.Reader {
+		t.Fatalf("Authenticate = %+v, %v, want the reader role", p, err)
+	}
+	// Invalid credentials are not downgraded to the anonymous role.
+	if _, err := a.Authenticate(withMetadata(auth.APIKeyHeader, auth.APIKeyPrefix+"unknown")); !errors.Is(err, auth.ErrInvalidCredentials) {
+		t.Fatalf("Authenticate error = %v, want %v", err, auth.ErrInvalidCredentials)
+	}
+}
+
+// newSigner generates an ECDSA key, writes its public half to a JWKS file and returns
+// the signer and the path of the file.
+func newSigner(t *testing.T) (jose.Signer, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: jose.JSONWebKey{Key: key, KeyID: "test"}}, nil)
+	if err != nil {
+		t.Fatalf("NewSigner: %v", err)
+	}
+	jwks := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: &key.PublicKey, KeyID: "test", Algorithm: string(jose.ES256), Use: "sig"}}}
+	b, err := json.Marshal(jwks)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, b, 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return signer, path
+}
+
+// sign returns a JWT for the claims, with the role claim if role is set.
+func sign(t *testing.T, signer jose.Signer, c jwt.Claims, role string) string {
+	t.Helper()
+	token, err := jwt.Signed(signer).Claims(struct {
+		jwt.Claims
+		Role string `json:"role,omitempty"`
+	}{c, role}).Serialize()
+	if err != nil {
+		t.Fatalf("Serialize: %v", err)
+	}
+	return token
+}
+
+func TestAuthenticateJWT(t *testing.T) {
+	signer, jwksFile := newSigner(t)
+	otherSigner, _ := newSigner(t)
+	a, err := auth.NewAuthenticator(memory.NewPlayerRepository(), auth.Options{JWKSFile: jwksFile, Issuer: "https://issuer.test", Audience: "nba"})
+	if err != nil {
+		t.Fatalf("NewAuthenticator: %v", err)
+	}
+
+	now := time.Now()
+	valid := jwt.Claims{Subject: "alice", Issuer: "https://issuer.test", Audience: jwt.Audience{"nba"}, Expiry: jwt.NewNumericDate(now.Add(time.Hour))}
+	expired := valid
+	expired.Expiry = jwt.NewNumericDate(now.Add(-time.Hour))
+	wrongAudience := valid
+	wrongAudience.Audience = jwt.Audience{"other"}
+	noExpiry := valid
+	noExpiry.Expiry = nil
+
+	tests := []struct {
+		name     string
+		token    string
+		wantRole auth.Role
+	}{
+		{"valid", sign(t, signer, valid, "scorekeeper"), auth.Scorekeeper},
+		{"expired", sign(t, signer, expired, "scorekeeper"), ""},
+		{"wrong audience", sign(t, signer, wrongAudience, "scorekeeper"), ""},
+		{"no expiry", sign(t, signer, noExpiry, "scorekeeper"), ""},
+		{"no role", sign(t, signer, valid, ""), ""},
+		{"unknown role", sign(t, signer, valid, "owner"), ""},
+		{"unknown key", sign(t, otherSigner, valid, "admin"), ""},
+		{"malformed", "not-a-jwt", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := a.Authenticate(withMetadata("authorization", "Bearer "+tt.token))
+			if tt.wantRole == "" {
+				if !errors.Is(err, auth.ErrInvalidCredentials) {
+					t.Fatalf("Authenticate error = %v, want %v", err, auth.ErrInvalidCredentials)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Authenticate: %v", err)
+			}
+			if p.Role != tt.wantRole || p.Subject != "alice" {
+				t.Fatalf("principal = %+v, want alice with role %s", p, tt.wantRole)
+			}
+		})
+	}
+}
+
+func TestNewAuthenticatorRejectsPrivateKeys(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	b, _ := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: key, KeyID: "private"}}})
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, b, 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if _, err := auth.NewAuthenticator(memory.NewPlayerRepository(), auth.Options{JWKSFile: path}); err == nil {
+		t.Fatal("NewAuthenticator succeeded with a private key, want an error")
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	repo := memory.NewPlayerRepository()
+	reader := storeKey(t, repo, auth.Reader, false)
+	a, err := auth.NewAuthenticator(repo, auth.Options{})
+	if err != nil {
+		t.Fatalf("NewAuthenticator: %v", err)
+	}
+	policy := auth.Policy{"/test/Read": auth.Reader, "/test/Write": auth.Scorekeeper, "/test/Check": auth.Public}
+	interceptor := auth.UnaryServerInterceptor(a, policy)
+
+	tests := []struct {
+		name     string
+		method   string
+		ctx      context.Context
+		wantCode codes.Code
+	}{
+		{"allowed", "/test/Read", withMetadata(auth.APIKeyHeader, reader), codes.OK},
+		{"insufficient role", "/test/Write", withMetadata(auth.APIKeyHeader, reader), codes.PermissionDenied},
+		{"no credentials", "/test/Read", withMetadata(), codes.Unauthenticated},
+		{"invalid credentials", "/test/Read", withMetadata(auth.APIKeyHeader, auth.APIKeyPrefix+"unknown"), codes.Unauthenticated},
+		{"public", "/test/Check", withMetadata(), codes.OK},
+		{"not in the policy", "/test/Delete", withMetadata(auth.APIKeyHeader, reader), codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var principal auth.Principal
+			handler := func(ctx context.Context, req any) (any, error) {
+				principal, _ = auth.FromContext(ctx)
+				return "ok", nil
+			}
+			_, err := interceptor(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %s, want %s (%v)", code, tt.wantCode, err)
+			}
+			if tt.name == "allowed" && principal.Role != auth.Reader {
+				t.Fatalf("handler saw principal %+v, want the reader key", principal)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"google.golang.org/grpc/metadata"
+
+	"nba/model"
+)
+
+var (
+	// ErrNoCredentials is returned when the caller sent neither an API key nor a JWT.
+	ErrNoCredentials = errors.New("no credentials")
+	// ErrInvalidCredentials is returned for unknown or revoked API keys and invalid JWTs.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// APIKeyHeader is the metadata key, and HTTP header through the gateway, that may carry
+// an API key instead of the authorization header.
+const APIKeyHeader = "x-api-key"
+
+// jwtAlgorithms are the signature algorithms JWTs may be signed with.
+var jwtAlgorithms = []jose.SignatureAlgorithm{jose.RS256, jose.RS384, jose.RS512, jose.ES256, jose.ES384, jose.ES512, jose.EdDSA}
+
+// clockSkew is how far the clocks of the issuer and the server may disagree on JWT times.
+const clockSkew = time.Minute
+
+// APIKeys looks up stored API keys. It is implemented by postgres.PlayerRepository.
+type APIKeys interface {
+	GetAPIKeyByHash(ctx context.Context, hash string) (model.APIKey, error)
+}
+
+// Options configures an Authenticator.
+type Options struct {
+	// JWKSFile is a JSON Web Key Set file with the public keys JWTs are verified against.
+	// Empty means JWTs are rejected.
+	JWKSFile string
+	// Issuer and Audience, if set, must match the iss and aud claims of JWTs.
+	Issuer   string
+	Audience string
+	// Anonymous is the role of callers without credentials. Empty means they are rejected.
+	Anonymous Role
+}
+
+// Authenticator identifies callers from the credentials in their request metadata:
+// an API key or a JWT, as a bearer token in the authorization header, or an API key
+// in the x-api-key header.
+type Authenticator struct {
+	keys      APIKeys
+	jwks      *jose.JSONWebKeySet
+	issuer    string
+	audience  string
+	anonymous Role
+	now       func() time.Time
+}
+
+// NewAuthenticator returns an Authenticator checking API keys against keys and JWTs
+// against the key set in opts.JWKSFile.
+func NewAuthenticator(keys APIKeys, opts Options) (*Authenticator, error) {
+	a := &Authenticator{
+		keys:      keys,
+		issuer:    opts.Issuer,
+		audience:  opts.Audience,
+		anonymous: opts.Anonymous,
+		now:       time.Now,
+	}
+	if opts.JWKSFile != "" {
+		jwks, err := loadJWKS(opts.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		a.jwks = jwks
+	}
+	return a, nil
+}
+
+func loadJWKS(path string) (*jose.JSONWebKeySet, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+	}
+	var jwks jose.JSONWebKeySet
+	if err := json.Unmarshal(b, &jwks); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS file %s: %w", path, err)
+	}
+	if len(jwks.Keys) == 0 {
+		return nil, fmt.Errorf("JWKS file %s has no keys", path)
+	}
+	for _, key := range jwks.Keys {
+		if !key.IsPublic() {
+			return nil, fmt.Errorf("JWKS file %s holds the private key %q, want public keys only", path, key.KeyID)
+		}
+	}
+	return &jwks, nil
+}
+
+// Authenticate returns the caller of the request handled in ctx. Callers without
+// credentials get the anonymous role, if there is one.
+func (a *Authenticator) Authenticate(ctx context.Context) (Principal, error) {
+	credential := credentialFrom(ctx)
+	switch {
+	case credential == "" && a.anonymous != "":
+		return Principal{Role: a.anonymous}, nil
+	case credential == "":
+		return Principal{}, ErrNoCredentials
+	case strings.HasPrefix(credential, APIKeyPrefix):
+		return a.authenticateAPIKey(ctx, credential)
+	default:
+		return a.authenticateJWT(credential)
+	}
+}
+
+func credentialFrom(ctx context.Context) string {
+	if values := metadata.ValueFromIncomingContext(ctx, "authorization"); len(values) > 0 {
+		scheme, token, ok := strings.Cut(values[0], " ")
+		if ok && strings.EqualFold(scheme, "bearer") {
+			return strings.TrimSpace(token)
+		}
+	}
+	if values := metadata.ValueFromIncomingContext(ctx, APIKeyHeader); len(values) > 0 {
+		return strings.TrimSpace(values[0])
+	}
+	return ""
+}
+
+func (a *Authenticator) authenticateAPIKey(ctx context.Context, credential string) (Principal, error) {
+	key, err := a.keys.GetAPIKeyByHash(ctx, HashAPIKey(credential))
+	if err != nil {
+		return Principal{}, fmt.Errorf("failed to look up api key: %w", err)
+	}
+	if key.ID == 0 {
+		return Principal{}, fmt.Errorf("%w: unknown api key", ErrInvalidCredentials)
+	}
+	if !key.RevokedAt.IsZero() {
+		return Principal{}, fmt.Errorf("%w: api key %s was revoked", ErrInvalidCredentials, key.Prefix)
+	}
+	return Principal{Subject: key.Name, KeyID: key.ID, Role: Role(key.Role)}, nil
+}
+
+// claims are the JWT claims the service reads.
+type claims struct {
+	jwt.Claims
+	Role string `json:"role"`
+}
+
+func (a *Authenticator) authenticateJWT(credential string) (Principal, error) {
+	if a.jwks == nil {
+		return Principal{}, fmt.Errorf("%w: JWTs are not accepted", ErrInvalidCredentials)
+	}
+	token, err := jwt.ParseSigned(credential, jwtAlgorithms)
+	if err != nil {
+		return Principal{}, fmt.Errorf("%w: malformed JWT", ErrInvalidCredentials)
+	}
+	var c claims
+	if err := token.Claims(a.jwks, &c); err != nil {
+		return Principal{}, fmt.Errorf("%w: JWT signature does not match a known key", ErrInvalidCredentials)
+	}
+
+	expected := jwt.Expected{Issuer: a.issuer, Time: a.now()}
+	if a.audience != "" {
+		expected.AnyAudience = jwt.Audience{a.audience}
+	}
+	if err := c.ValidateWithLeeway(expected, clockSkew); err != nil {
+		return Principal{}, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+	}
+	if c.Expiry == nil {
+		return Principal{}, fmt.Errorf("%w: JWT has no expiry", ErrInvalidCredentials)
+	}
+	role, err := ParseRole(c.Role)
+	if err != nil {
+		return Principal{}, fmt.Errorf("%w: JWT role: %v", ErrInvalidCredentials, err)
+	}
+	return Principal{Subject: c.Subject, Role: role}, nil
+}
@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Policy maps full RPC method names, such as "/pb.PlayerGameService/LogPlayerGame",
+// to the role they require. Methods missing from the policy are denied to everyone.
+type Policy map[string]Role
+
+// UnaryServerInterceptor rejects unary calls whose caller does not have the role the
+// policy requires, and passes the caller on to the handler, see FromContext.
+func UnaryServerInterceptor(a *Authenticator, policy Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := a.authorize(ctx, policy, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streaming calls.
+func StreamServerInterceptor(a *Authenticator, policy Policy) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authorize(ss.Context(), policy, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// contextStream is a server stream whose handler sees ctx.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func (a *Authenticator) authorize(ctx context.Context, policy Policy, method string) (context.Context, error) {
+	required, ok := policy[method]
+	if !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "%s is not allowed", method)
+	}
+	if required == Public {
+		return ctx, nil
+	}
+
+	principal, err := a.Authenticate(ctx)
+	switch {
+	case errors.Is(err, ErrNoCredentials):
+		return nil, status.Error(codes.Unauthenticated, "an api key or JWT is required")
+	case errors.Is(err, ErrInvalidCredentials):
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.Unavailable, "authentication is unavailable")
+	}
+	if !principal.Role.Includes(required) {
+		return nil, status.Errorf(codes.PermissionDenied, "%s requires the %s role", method, required)
+	}
+	return WithPrincipal(ctx, principal), nil
+}
@@ -0,0 +1,140 @@
+// Package certs serves TLS certificates from disk, reloading them when the files change
+// so certificates can be rotated without restarting the service.
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Reloader holds the server certificate and the CAs client certificates are verified
+// against, as last loaded from their files.
+type Reloader struct {
+	certFile, keyFile, clientCAFile string
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTimes  []time.Time
+}
+
+// NewReloader loads the certificate and key in certFile and keyFile and, if clientCAFile
+// is set, the PEM encoded CAs in it.
+func NewReloader(certFile, keyFile, clientCAFile string) (*Reloader, error) {
+	r := &Reloader{certFile: certFile, keyFile: keyFile, clientCAFile: clientCAFile}
+	if _, err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload loads the files again if any of them changed since they were last loaded, and
+// reports whether they did. On error the previous certificates stay in use.
+func (r *Reloader) Reload() (bool, error) {
+	modTimes, err := r.statFiles()
+	if err != nil {
+		return false, err
+	}
+	r.mu.RLock()
+	unchanged := r.cert != nil && equalTimes(modTimes, r.modTimes)
+	r.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to load certificate: %w", err)
+	}
+	var clientCAs *x509.CertPool
+	if r.clientCAFile != "" {
+		pem, err := os.ReadFile(r.clientCAFile)
+		if err != nil {
+			return false, fmt.Errorf("failed to read client CA file: %w", err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return false, fmt.Errorf("client CA file %s holds no PEM certificates", r.clientCAFile)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert, r.clientCAs, r.modTimes = &cert, clientCAs, modTimes
+	return true, nil
+}
+
+func (r *Reloader) statFiles() ([]time.Time, error) {
+	var modTimes []time.Time
+	for _, path := range []string{r.certFile, r.keyFile, r.clientCAFile} {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		modTimes = append(modTimes, info.ModTime())
+	}
+	return modTimes, nil
+}
+
+func equalTimes(a, b []time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Run reloads the files every interval until ctx is done, passing every reload and
+// failure to report.
+func (r *Reloader) Run(ctx context.Context, interval time.Duration, report func(reloaded bool, err error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := r.Reload()
+			if reloaded || err != nil {
+				report(reloaded, err)
+			}
+		}
+	}
+}
+
+// ServerConfig returns a TLS configuration serving the current certificate on every new
+// connection. clientAuth applies to client certificates, which are verified against the
+// current client CAs; nextProtos are the protocols offered through ALPN.
+func (r *Reloader) ServerConfig(clientAuth tls.ClientAuthType, nextProtos ...string) (*tls.Config, error) {
+	if clientAuth >= tls.VerifyClientCertIfGiven && r.clientCAFile == "" {
+		return nil, errors.New("verifying client certificates requires a client CA file")
+	}
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: nextProtos,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			return &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				NextProtos:   nextProtos,
+				Certificates: []tls.Certificate{*r.cert},
+				ClientAuth:   clientAuth,
+				ClientCAs:    r.clientCAs,
+			}, nil
+		},
+	}, nil
+}
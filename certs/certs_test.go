@@ -0,0 +1,204 @@
+package certs_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"nba/certs"
+)
+
+// issued is a certificate and its key, PEM encoded.
+type issued struct {
+	cert     *x509.Certificate
+	key      *ecdsa.PrivateKey
+	certPEM  []byte
+	keyPEM   []byte
+	tlsChain tls.Certificate
+}
+
+// issue creates a certificate for name, signed by parent or self-signed if parent is nil.
+func issue(t *testing.T, name string, isCA bool, parent *issued) issued {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: name},
+		DNSNames:              []string{name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+	}
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("CreateCertificate: %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+	i := issued{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+	if i.tlsChain, err = tls.X509KeyPair(i.certPEM, i.keyPEM); err != nil {
+		t.Fatalf("X509KeyPair: %v", err)
+	}
+	return i
+}
+
+// write writes data to path and moves its modification time to at.
+func write(t *testing.T, path string, data []byte, at time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := os.Chtimes(path, at, at); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+}
+
+// handshake connects a client to a server using serverConfig and returns the leaf
+// certificate the server presented.
+func handshake(serverConfig, clientConfig *tls.Config) (*x509.Certificate, error) {
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+	defer clientConn.Close()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		server := tls.Server(serverConn, serverConfig)
+		serverErr <- server.Handshake()
+		server.Close()
+	}()
+	client := tls.Client(clientConn, clientConfig)
+	if err := client.Handshake(); err != nil {
+		return nil, err
+	}
+	// TLS 1.3 clients finish before the server has verified their certificate; read
+	// what the server sends after the handshake, as the pipe has no buffer
+	go io.Copy(io.Discard, client)
+	if err := <-serverErr; err != nil {
+		return nil, err
+	}
+	return client.ConnectionState().PeerCertificates[0], nil
+}
+
+func TestReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	first := issue(t, "first.test", false, nil)
+	start := time.Now().Add(-time.Minute)
+	write(t, certFile, first.certPEM, start)
+	write(t, keyFile, first.keyPEM, start)
+
+	r, err := certs.NewReloader(certFile, keyFile, "")
+	if err != nil {
+		t.Fatalf("NewReloader: %v", err)
+	}
+	serverConfig, err := r.ServerConfig(tls.NoClientCert, "h2")
+	if err != nil {
+		t.Fatalf("ServerConfig: %v", err)
+	}
+	client := &tls.Config{InsecureSkipVerify: true, NextProtos: []string{"h2"}}
+	if leaf, err := handshake(serverConfig, client); err != nil || leaf.Subject.CommonName != "first.test" {
+		t.Fatalf("handshake = %v, %v, want the first certificate", leaf, err)
+	}
+	if reloaded, err := r.Reload(); reloaded || err != nil {
+		t.Fatalf("Reload of unchanged files = %v, %v, want nothing reloaded", reloaded, err)
+	}
+
+	// A half-written rotation keeps the previous certificate
+	second := issue(t, "second.test", false, nil)
+	write(t, certFile, second.certPEM, start.Add(time.Second))
+	if _, err := r.Reload(); err == nil {
+		t.Fatal("Reload of a certificate without its key succeeded, want an error")
+	}
+	if leaf, err := handshake(serverConfig, client); err != nil || leaf.Subject.CommonName != "first.test" {
+		t.Fatalf("handshake = %v, %v, want the first certificate still", leaf, err)
+	}
+
+	write(t, keyFile, second.keyPEM, start.Add(time.Second))
+	if reloaded, err := r.Reload(); !reloaded || err != nil {
+		t.Fatalf("Reload = %v, %v, want the rotated certificate reloaded", reloaded, err)
+	}
+	if leaf, err := handshake(serverConfig, client); err != nil || leaf.Subject.CommonName != "second.test" {
+		t.Fatalf("handshake = %v, %v, want the second certificate", leaf, err)
+	}
+}
+
+func TestClientCertificates(t *testing.T) {
+	dir := t.TempDir()
+	ca := issue(t, "ca.test", true, nil)
+	server := issue(t, "server.test", false, &ca)
+	trusted := issue(t, "client.test", false, &ca)
+	untrusted := issue(t, "stranger.test", false, nil)
+
+	certFile, keyFile, caFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key"), filepath.Join(dir, "ca.crt")
+	write(t, certFile, server.certPEM, time.Now())
+	write(t, keyFile, server.keyPEM, time.Now())
+	write(t, caFile, ca.certPEM, time.Now())
+
+	withoutCAs, err := certs.NewReloader(certFile, keyFile, "")
+	if err != nil {
+		t.Fatalf("NewReloader: %v", err)
+	}
+	if _, err := withoutCAs.ServerConfig(tls.RequireAndVerifyClientCert); err == nil {
+		t.Fatal("ServerConfig verifying client certificates without CAs succeeded, want an error")
+	}
+
+	r, err := certs.NewReloader(certFile, keyFile, caFile)
+	if err != nil {
+		t.Fatalf("NewReloader: %v", err)
+	}
+	serverConfig, err := r.ServerConfig(tls.RequireAndVerifyClientCert, "h2")
+	if err != nil {
+		t.Fatalf("ServerConfig: %v", err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	tests := []struct {
+		name    string
+		cert    *issued
+		wantErr bool
+	}{
+		{"trusted client certificate", &trusted, false},
+		{"untrusted client certificate", &untrusted, true},
+		{"no client certificate", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &tls.Config{RootCAs: roots, ServerName: "server.test", NextProtos: []string{"h2"}}
+			if tt.cert != nil {
+				client.Certificates = []tls.Certificate{tt.cert.tlsChain}
+			}
+			_, err := handshake(serverConfig, client)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("handshake error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
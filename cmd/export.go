@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"nba/config"
+	"nba/exporter"
+	"nba/model"
+)
+
+// runExport writes the stat lines, season averages or box scores of a season, team or
+// player in the configured storage to a file or to stdout.
+func runExport(args []string) {
+	// Load the configuration like the server, along with the export flags
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	kind := flags.String("kind", string(exporter.StatLines), "data to export: stat_lines, season_averages or box_scores")
+	format := flags.String("format", "", "output format, csv, jsonl or parquet (default from the -o extension, else csv)")
+	output := flags.String("o", "", "file to write (default stdout)")
+	season := flags.Int("season", 0, "export this season")
+	team := flags.Int("team", 0, "export the lines of this team ID")
+	player := flags.Int("player", 0, "export the lines of this player ID")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s export [flags]\n\nAt least one of -season, -team and -player is required.\n\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	cfg, err := config.Load(flags, args, os.LookupEnv)
+	checkError(err, "Failed to load configuration")
+	if flags.NArg() != 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	opts := exporter.Options{Filter: model.StatLineFilter{Season: *season, TeamID: *team, PlayerID: *player}}
+	opts.Kind, err = exporter.ParseKind(*kind)
+	checkError(err, "Failed to pick the export kind")
+	if *format == "" {
+		*format = string(exporter.CSV)
+		if ext := strings.TrimPrefix(filepath.Ext(*output), "."); ext != "" {
+			*format = ext
+		}
+	}
+	opts.Format, err = exporter.ParseFormat(*format)
+	checkError(err, "Failed to pick the output format")
+
+	// Open the storage without seeding it
+	playerRepository, db := openDatabase(cfg)
+	if db == nil {
+		checkError(errors.New("in-memory storage has nothing to export"), "Failed to export")
+	}
+	defer db.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	rows, err := export(ctx, exporter.New(playerRepository), *output, opts)
+	checkError(err, "Failed to export")
+	if *output != "" {
+		fmt.Fprintf(os.Stderr, "Exported %d rows to %s\n", rows, *output)
+	}
+}
+
+// export writes the export to the file at path, or to stdout if path is empty. A failed
+// export leaves no file behind.
+func export(ctx context.Context, ex *exporter.Exporter, path string, opts exporter.Options) (int, error) {
+	var out io.Writer = os.Stdout
+	if path != "" {
+		file, err := os.Create(path)
+		if err != nil {
+			return 0, err
+		}
+		defer file.Close()
+		out = file
+	}
+	w := bufio.NewWriter(out)
+	rows, err := ex.Run(ctx, w, opts)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil && path != "" {
+		os.Remove(path)
+	}
+	return rows, err
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"nba/config"
+	"nba/importer"
+	"nba/memory"
+	"nba/validation"
+)
+
+// runImport imports the stat lines of a CSV or JSON Lines file into the configured storage.
+// Rejected records are written to a rejects file, and the last committed record to a
+// checkpoint file that a rerun resumes from.
+func runImport(args []string) {
+	// Load the configuration like the server, along with the import flags
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	format := flags.String("format", "", "input format, csv or jsonl (default from the file extension)")
+	columns := columnFlag{}
+	flags.Var(columns, "map", "read a field from another column, as field=column; repeatable")
+	dryRun := flags.Bool("dry-run", false, "validate and report without writing")
+	rejectsPath := flags.String("rejects", "", "file the rejected records are written to as JSON Lines (default FILE.rejects.jsonl)")
+	checkpointPath := flags.String("checkpoint", "", "file the last committed record is kept in and resumed from (default FILE.checkpoint)")
+	season := flags.Int("season", 0, "season of the records without one")
+	league := flags.String("league", "", "league of the records without one (default "+validation.DefaultLeague+")")
+	batchSize := flags.Int("batch-size", importer.DefaultBatchSize, "records written per transaction")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s import [flags] FILE\n\nFields: %s\n\n", os.Args[0], strings.Join(importer.Fields, ", "))
+		flags.PrintDefaults()
+	}
+	cfg, err := config.Load(flags, args, os.LookupEnv)
+	checkError(err, "Failed to load configuration")
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	path := flags.Arg(0)
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(path), ".")
+	}
+	inputFormat, err := importer.ParseFormat(*format)
+	checkError(err, "Failed to pick the input format")
+	if *rejectsPath == "" {
+		*rejectsPath = path + ".rejects.jsonl"
+	}
+	if *checkpointPath == "" {
+		*checkpointPath = path + ".checkpoint"
+	}
+
+	// Resume after the last committed record of an earlier run
+	resumeAfter, err := readCheckpoint(*checkpointPath)
+	checkError(err, "Failed to read the checkpoint")
+
+	// Open the storage without seeding it; imports into memory are lost on exit, so only dry runs make sense
+	playerRepository, db := openDatabase(cfg)
+	if db != nil {
+		defer db.Close()
+	} else {
+		if !*dryRun {
+			checkError(errors.New("in-memory storage forgets the import on exit, use -dry-run"), "Failed to import")
+		}
+		playerRepository = memory.NewPlayerRepository()
+	}
+
+	rules, err := validation.NewRegistry(cfg.Validation.RulesDir)
+	checkError(err, "Failed to load validation rules")
+
+	input, err := os.Open(path)
+	checkError(err, "Failed to open the input")
+	defer input.Close()
+
+	// Start a fresh rejects file unless resuming, since the records rejected before are not read again
+	rejects := &rejectsFile{path: *rejectsPath}
+	defer rejects.Close()
+	if resumeAfter == 0 {
+		if err := os.Remove(*rejectsPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			checkError(err, "Failed to remove the old rejects file")
+		}
+	}
+
+	// Stop at the next batch on SIGINT or SIGTERM; the batches committed before stay
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	report, err := importer.New(playerRepository, rules).Run(ctx, input, importer.Options{
+		Format:      inputFormat,
+		Columns:     columns,
+		Season:      *season,
+		League:      *league,
+		DryRun:      *dryRun,
+		ResumeAfter: resumeAfter,
+		BatchSize:   *batchSize,
+		OnReject:    rejects.Write,
+		OnCheckpoint: func(record int) error {
+			return writeCheckpoint(*checkpointPath, record)
+		},
+	})
+	printReport(report, rejects, *checkpointPath)
+	checkError(err, "Import stopped")
+
+	// The whole file is in, so a rerun starts over
+	if !report.DryRun {
+		if err := os.Remove(*checkpointPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			checkError(err, "Failed to remove the checkpoint")
+		}
+	}
+}
+
+// columnFlag collects the -map flags into field to column mappings.
+type columnFlag map[string]string
+
+func (c columnFlag) String() string {
+	pairs := make([]string, 0, len(c))
+	for field, column := range c {
+		pairs = append(pairs, field+"="+column)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (c columnFlag) Set(value string) error {
+	field, column, ok := strings.Cut(value, "=")
+	if !ok || field == "" || column == "" {
+		return fmt.Errorf("%q is not field=column", value)
+	}
+	c[field] = column
+	return nil
+}
+
+// rejectsFile writes rejected records as JSON Lines, creating the file on the first one.
+type rejectsFile struct {
+	path  string
+	file  *os.File
+	count int
+}
+
+func (r *rejectsFile) Write(reject importer.Reject) error {
+	if r.file == nil {
+		file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return err
+		}
+		r.file = file
+	}
+	line, err := json.Marshal(map[string]any{"record": reject.Record, "reason": reject.Reason, "raw": reject.Raw})
+	if err != nil {
+		return err
+	}
+	r.count++
+	_, err = r.file.Write(append(line, '\n'))
+	return err
+}
+
+func (r *rejectsFile) Close() error {
+	if r.file == nil {
+		return nil
+	}
+	return r.file.Close()
+}
+
+// readCheckpoint returns the record number kept in path, or 0 if there is none.
+func readCheckpoint(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	record, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || record < 0 {
+		return 0, fmt.Errorf("%s does not hold a record number", path)
+	}
+	return record, nil
+}
+
+// writeCheckpoint replaces the checkpoint in path, so a crash leaves the old one or the new one.
+func writeCheckpoint(path string, record int) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strconv.Itoa(record)+"\n"), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func printReport(report importer.Report, rejects *rejectsFile, checkpointPath string) {
+	if report.DryRun {
+		fmt.Println("Dry run, nothing was written")
+	}
+	fmt.Printf("Records:          %d\n", report.Records)
+	if report.Skipped > 0 {
+		fmt.Printf("Skipped:          %d (imported before the checkpoint)\n", report.Skipped)
+	}
+	fmt.Printf("Imported:         %d\n", report.Imported)
+	fmt.Printf("Rejected:         %d\n", report.Rejected)
+	fmt.Printf("Teams created:    %d\n", report.TeamsCreated)
+	fmt.Printf("Players created:  %d\n", report.PlayersCreated)
+	fmt.Printf("Games created:    %d\n", report.GamesCreated)
+	if rejects.count > 0 {
+		fmt.Printf("Rejects written to %s\n", rejects.path)
+	}
+	if !report.DryRun && report.Checkpoint < report.Records {
+		fmt.Printf("Committed through record %d; rerun to resume from %s\n", report.Checkpoint, checkpointPath)
+	}
+}
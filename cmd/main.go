@@ -2,7 +2,10 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"database/sql"
+	"errors"
+	"flag"
 	"fmt"
 	"net/http"
 
@@ -12,14 +15,40 @@ import (
 	"log"
 	"net"
 	"os"
+	"time"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/filters"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
-
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"nba/auth"
+	"nba/certs"
+	"nba/config"
+	"nba/docs"
+	"nba/health"
+	"nba/lifecycle"
+	"nba/live"
+	"nba/memory"
+	"nba/metrics"
+	"nba/middleware"
+	"nba/model"
+	"nba/multiplex"
 	"nba/pb"
 	p "nba/postgres"
+	"nba/ratelimit"
 	"nba/service"
+	"nba/sqlite"
+	"nba/tracing"
+	"nba/validation"
 
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 // Helper function to handle errors
@@ -29,175 +58,564 @@ func checkError(err error, msg string) {
 	}
 }
 
-// Helper function to get environment variables with fallback
-func getEnv(key, fallback string) string {
-	value := os.Getenv(key)
-	if value == "" {
-		return fallback
-	}
-	return value
-}
 func main() {
+	// Run the import or export command instead of the server if asked to
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "import":
+			runImport(os.Args[2:])
+			return
+		case "export":
+			runExport(os.Args[2:])
+			return
+		}
+	}
+
+	// Load the configuration from defaults, the config file, env and flags
+	fs := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	printConfig := fs.Bool("print-config", false, "print the configuration, with secrets redacted, and exit")
+	probe := fs.Bool("probe", false, "check the readiness of the server running with this configuration, and exit")
+	cfg, err := config.Load(fs, os.Args[1:], os.LookupEnv)
+	checkError(err, "Failed to load configuration")
+	if *printConfig {
+		out, err := cfg.Redacted().YAML()
+		checkError(err, "Failed to print configuration")
+		os.Stdout.Write(out)
+		return
+	}
+	if *probe {
+		checkError(probeReadiness(cfg.Server.HTTPAddr, cfg.Server.TLS.Enabled()), "Not ready")
+		return
+	}
+
 	// Initialize logger
-	logger, err := zap.NewProduction()
+	logger, err := newLogger(cfg.Logging)
 	checkError(err, "Failed to create logger")
 	defer logger.Sync()
 
 	logger.Info("Starting the NBA service")
 
-	// Fetch database connection details from environment variables (with defaults)
-	user := getEnv("DB_USER", "postgres")
-	password := getEnv("DB_PASSWORD", "password")
-	port := getEnv("DB_PORT", "5432")
-	dbName := getEnv("DB_NAME", "nba")
-
-	// Step 2: After ensuring the database exists, connect to it using the correct dbname
-	connStr := fmt.Sprintf("user=%s password=%s host=db port=%s dbname=%s sslmode=disable", user, password, port, dbName)
-	db, err := sql.Open("postgres", connStr)
-	checkError(err, "Failed to connect to the specific database")
-	defer db.Close()
+	// Shut down on SIGINT or SIGTERM, letting in-flight requests drain
+	lc := lifecycle.New(logger.Sugar(), cfg.Server.ShutdownTimeout)
+
+	// Trace requests across the gateway, gRPC and the database; spans are flushed last at shutdown
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	checkError(err, "Failed to set up tracing")
+	lc.OnShutdown("tracing", shutdownTracing)
+
+	// Export RPC, database and domain metrics
+	m := metrics.New()
+
+	// Report readiness once the database is reachable and fully migrated
+	checker := health.NewChecker(logger.Sugar(), cfg.Health.CheckInterval, cfg.Health.CheckTimeout,
+		pb.PlayerGameService_ServiceDesc.ServiceName)
+
+	// Pick the storage backend; memory needs no database and forgets everything on restart
+	var playerRepository p.PlayerRepository
+	switch cfg.Storage {
+	case "postgres":
+		var db *sql.DB
+		playerRepository, db = newPostgresRepository(cfg.Database, m)
+		lc.OnShutdown("database", closeDB(db))
+		m.RegisterDB("postgres", db)
+		checker.AddCheck("database", health.Ping(db))
+		checker.AddCheck("migrations", health.Migrations(db, p.Migrations))
+	case "sqlite":
+		var db *sql.DB
+		playerRepository, db = newSQLiteRepository(cfg.SQLite.Path, cfg.Database, m)
+		lc.OnShutdown("database", closeDB(db))
+		m.RegisterDB("sqlite", db)
+		checker.AddCheck("database", health.Ping(db))
+		checker.AddCheck("migrations", health.Migrations(db, sqlite.Migrations))
+	case "memory":
+		logger.Warn("Using in-memory storage, data will be lost on restart")
+		playerRepository = memory.NewPlayerRepository()
+	}
 
-	// Step 4: Initialize the database
-	if err := initDatabase(db); err != nil {
-		log.Fatalf("Failed to initialize database: %v", err)
+	// Seed the demo teams, games and players unless the database already holds data
+	if err := seedDatabase(context.Background(), playerRepository); err != nil {
+		log.Fatalf("Failed to seed database: %v", err)
 	}
 
-	// Create a new player repository
-	playerRepository := p.NewPlayerRepository(db)
+	// Load the stat validation rules, optionally overridden per league from a directory
+	rules, err := validation.NewRegistry(cfg.Validation.RulesDir)
+	checkError(err, "Failed to load validation rules")
+
+	// Publish committed stat lines to the clients watching them; streams end once shutdown starts
+	hub := live.NewHub()
+	lc.OnDrain(hub.Close)
 
-	// Create a new service
-	svc := service.NewService(logger.Sugar(), playerRepository)
+	// Create a new service, recording domain events and caching season averages if enabled
+	svc := service.NewService(logger.Sugar(), playerRepository, rules, hub, m)
+	if cfg.Cache.Enabled {
+		svc = service.NewCachedService(svc, cfg.Cache.TTL, cfg.Cache.MaxEntries, m)
+	}
 
 	logger.Info("Starting the NBA service")
 
-	// Create gRPC server
-	grpcServer := grpc.NewServer()
+	// Create gRPC server, tracing, measuring and logging every RPC and turning handler panics into INTERNAL errors
+	logging := middleware.LoggingOptions{SampleEvery: cfg.Logging.SampleEvery}
+	unary := []grpc.UnaryServerInterceptor{m.UnaryServerInterceptor(), middleware.UnaryLogging(logger.Sugar(), logging)}
+	stream := []grpc.StreamServerInterceptor{m.StreamServerInterceptor(), middleware.StreamLogging(logger.Sugar(), logging)}
+
+	// Authenticate callers with API keys or JWTs and authorize every RPC by role
+	if cfg.Auth.Enabled {
+		if cfg.Auth.BootstrapKey != "" {
+			checkError(bootstrapAdminKey(context.Background(), playerRepository, cfg.Auth.BootstrapKey), "Failed to store the bootstrap key")
+		} else if cfg.Auth.JWKSFile == "" {
+			key, err := generateAdminKey(context.Background(), playerRepository)
+			checkError(err, "Failed to generate the first admin key")
+			if key != "" {
+				logger.Sugar().Warnf("Created the admin API key %s; store it now, it is not shown again", key)
+			}
+		}
+		authenticator, err := auth.NewAuthenticator(playerRepository, auth.Options{
+			JWKSFile:  cfg.Auth.JWKSFile,
+			Issuer:    cfg.Auth.Issuer,
+			Audience:  cfg.Auth.Audience,
+			Anonymous: auth.Role(cfg.Auth.AnonymousRole),
+		})
+		checkError(err, "Failed to set up authentication")
+		unary = append(unary, auth.UnaryServerInterceptor(authenticator, service.AccessPolicy))
+		stream = append(stream, auth.StreamServerInterceptor(authenticator, service.AccessPolicy))
+	} else {
+		logger.Warn("Authentication is disabled, anyone can write stats and manage API keys")
+	}
 
-	// Register the PlayerGameService with the gRPC server
-	pb.RegisterPlayerGameServiceServer(grpcServer, &service.GRPCServer{Logger: logger.Sugar(), Svc: svc})
+	// Limit each client per role and RPC, after authentication has identified it
+	var quotas service.QuotaTracker
+	if cfg.RateLimit.Enabled {
+		limiter := ratelimit.New(rateLimitOptions(cfg.RateLimit), m)
+		quotas = limiter
+		unary = append(unary, limiter.UnaryServerInterceptor())
+		stream = append(stream, limiter.StreamServerInterceptor())
+	}
 
-	// Start a gRPC server on port 50051
-	go func() {
-		lis, err := net.Listen("tcp", ":50051")
-		if err != nil {
-			log.Fatalf("Failed to listen: %v", err)
-		}
-		log.Println("gRPC server listening on :50051")
-		if err := grpcServer.Serve(lis); err != nil {
-			log.Fatalf("Failed to serve gRPC server: %v", err)
-		}
-	}()
-
-	// Create HTTP Gateway
-	mux := runtime.NewServeMux()
-
-	// Register the HTTP Gateway for PlayerGameService
-	// You need to specify a gRPC client connection to the server
-	grpcConn, err := grpc.Dial("localhost:50051", grpc.WithInsecure())
-	checkError(err, "Failed to dial gRPC server")
-	defer grpcConn.Close()
-
-	// Register the gRPC service to the HTTP Gateway mux
-	err = pb.RegisterPlayerGameServiceHandlerFromEndpoint(context.Background(), mux, "localhost:50051", []grpc.DialOption{grpc.WithInsecure()})
-
-	// Start HTTP server on port 8080
-	http.Handle("/", mux)
-	log.Println("HTTP server listening on :8080")
-	if err := http.ListenAndServe("0.0.0.0:8080", nil); err != nil {
-		log.Fatalf("Failed to serve HTTP server: %v", err)
-	}
-}
-func initDatabase(db *sql.DB) error {
-	// SQL statements to create the tables
-	createPlayerTable := `
-	CREATE TABLE IF NOT EXISTS player (
-		id SERIAL PRIMARY KEY,
-		name VARCHAR(100) NOT NULL,
-		current_team_id INT NOT NULL,
-		CONSTRAINT fk_team FOREIGN KEY (current_team_id) REFERENCES team (id) ON DELETE CASCADE
-	);`
-
-	createPlayerGameStatTable := `
-	CREATE TABLE IF NOT EXISTS player_game_stats (
-		player_id INT NOT NULL,
-		game_id INT NOT NULL,
-		points INT,
-		assists INT,
-		rebounds INT,
-		steals INT,
-		blocks INT,
-		turnovers INT,
-		fouls INT,
-		minutes_played FLOAT,
-		PRIMARY KEY (player_id, game_id),
-		CONSTRAINT fk_player FOREIGN KEY (player_id) REFERENCES player (id) ON DELETE CASCADE,
-		CONSTRAINT fk_game FOREIGN KEY (game_id) REFERENCES game (id) ON DELETE CASCADE
-	);`
-
-	createGameTable := `
-	CREATE TABLE IF NOT EXISTS game (
-		id SERIAL PRIMARY KEY,
-		date DATE NOT NULL,
-		season INT NOT NULL,
-		team_a_id INT NOT NULL,
-		team_b_id INT NOT NULL
-	);`
-
-	createTeamTable := `
-	CREATE TABLE IF NOT EXISTS team (
-		id SERIAL PRIMARY KEY,
-		name VARCHAR(100) UNIQUE NOT NULL
-	);`
-
-	// Execute the SQL statements
-	tables := []string{
-		createTeamTable,
-		createGameTable,
-		createPlayerTable,
-		createPlayerGameStatTable,
-	}
-
-	for _, query := range tables {
-		_, err := db.Exec(query)
-		if err != nil {
-			return fmt.Errorf("failed to execute query: %v\nquery: %s", err, query)
+	unary = append(unary, middleware.UnaryRecovery(logger.Sugar()))
+	stream = append(stream, middleware.StreamRecovery(logger.Sugar()))
+	serverOpts := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithFilter(filters.Not(filters.HealthCheck())))),
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+
+	// Serve TLS on both listeners if a certificate is configured, reloading it when it changes on disk
+	var httpTLS *tls.Config
+	if cfg.Server.TLS.Enabled() {
+		grpcTLS, gatewayTLS, err := newTLSConfigs(cfg.Server.TLS, cfg.Server.SinglePort, logger.Sugar(), lc)
+		checkError(err, "Failed to set up TLS")
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(grpcTLS)))
+		httpTLS = gatewayTLS
+	} else {
+		logger.Warn("TLS is disabled, the gRPC and HTTP listeners serve plaintext")
+	}
+	grpcServer := grpc.NewServer(serverOpts...)
+
+	// Register the PlayerGameService and AdminService with the gRPC server
+	playerGameServer := service.NewGRPCServer(logger.Sugar(), svc)
+	adminServer := service.NewAdminServer(service.NewAdminService(playerRepository, quotas))
+	pb.RegisterPlayerGameServiceServer(grpcServer, playerGameServer)
+	pb.RegisterAdminServiceServer(grpcServer, adminServer)
+	healthpb.RegisterHealthServer(grpcServer, checker.Server())
+
+	// Describe the services to clients such as grpcurl
+	reflection.Register(grpcServer)
+
+	// Check readiness in the background; readiness goes false as soon as shutdown starts
+	checksCtx, stopChecks := context.WithCancel(context.Background())
+	go checker.Run(checksCtx)
+	lc.OnDrain(func() {
+		stopChecks()
+		checker.Drain()
+	})
+
+	// Start the gRPC server, unless gRPC shares the HTTP listener
+	if !cfg.Server.SinglePort {
+		lis, err := net.Listen("tcp", cfg.Server.GRPCAddr)
+		checkError(err, "Failed to listen")
+		lc.Go("gRPC server", func() error {
+			logger.Sugar().Infow("gRPC server listening", "addr", lis.Addr().String())
+			return grpcServer.Serve(lis)
+		})
+	}
+
+	// Create HTTP Gateway, forwarding the credentials, Idempotency-Key and X-Request-Id headers as gRPC metadata
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(service.IncomingHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(service.OutgoingHeaderMatcher),
+		runtime.WithMiddlewares(nameSpan),
+	)
+
+	// Register the services to the HTTP Gateway mux, called in process rather than over a socket.
+	// Calls run through the same interceptors, so the gateway enforces the same roles and limits.
+	gatewayInterceptor := middleware.ChainUnary(unary...)
+	checkError(pb.RegisterPlayerGameServiceHandlerServer(context.Background(), mux,
+		service.NewInProcessPlayerGameServer(playerGameServer, gatewayInterceptor)), "Failed to register HTTP gateway")
+	checkError(pb.RegisterAdminServiceHandlerServer(context.Background(), mux,
+		service.NewInProcessAdminServer(adminServer, gatewayInterceptor)), "Failed to register HTTP gateway")
+	checkError(service.RegisterEventStreams(mux, playerGameServer, middleware.ChainStream(stream...)), "Failed to register HTTP gateway")
+	checkError(service.RegisterExportDownloads(mux, playerGameServer, middleware.ChainStream(stream...)), "Failed to register HTTP gateway")
+
+	// Serve liveness and readiness next to the API
+	mux.HandlePath(http.MethodGet, "/healthz", handlerFunc(checker.LivenessHandler()))
+	mux.HandlePath(http.MethodGet, "/readyz", handlerFunc(checker.ReadinessHandler()))
+	mux.HandlePath(http.MethodGet, "/metrics", handlerFunc(m.Handler().ServeHTTP))
+
+	// Serve the OpenAPI document of the gateway and a Swagger UI for it
+	mux.HandlePath(http.MethodGet, "/docs", handlerFunc(docs.UIHandler))
+	mux.HandlePath(http.MethodGet, "/docs/openapi.json", handlerFunc(docs.SpecHandler))
+	mux.HandlePath(http.MethodGet, "/docs/swagger-ui/{file}", handlerFunc(docs.AssetHandler))
+
+	// Serve gRPC-Web next to the gateway, and native gRPC too on a single port. Plaintext
+	// gRPC needs HTTP/2 without TLS; with TLS, ALPN picks HTTP/2 or HTTP/1.1.
+	handler := multiplex.New(grpcServer, traceHTTP(mux), multiplex.Options{
+		NativeGRPC:     cfg.Server.SinglePort,
+		AllowedOrigins: cfg.Server.GRPCWebOrigins,
+	})
+	lc.OnShutdown("gRPC server", stopGRPC(grpcServer, handler))
+
+	// Start the HTTP server
+	httpServer := &http.Server{Addr: cfg.Server.HTTPAddr, Handler: handler, TLSConfig: httpTLS}
+	if cfg.Server.SinglePort && httpTLS == nil {
+		checkError(multiplex.ServeH2C(httpServer), "Failed to serve HTTP/2 without TLS")
+	}
+	lc.Go("HTTP server", func() error {
+		logger.Sugar().Infow("HTTP server listening", "addr", cfg.Server.HTTPAddr, "tls", httpTLS != nil, "single_port", cfg.Server.SinglePort)
+		serve := httpServer.ListenAndServe
+		if httpTLS != nil {
+			serve = func() error { return httpServer.ListenAndServeTLS("", "") }
+		}
+		if err := serve(); !errors.Is(err, http.ErrServerClosed) {
+			return err
 		}
+		return nil
+	})
+	lc.OnShutdown("HTTP server", httpServer.Shutdown)
+
+	// Block until asked to stop; the gateway stops first, then gRPC, then the database
+	if err := lc.Run(context.Background()); err != nil {
+		log.Fatalf("Shutdown failed: %v", err)
 	}
+	logger.Info("NBA service stopped")
+}
 
-	// Insert teams
-	teams := []string{
-		"INSERT INTO team (name) VALUES ('Team A'), ('Team B'), ('Team C');",
+// handlerFunc adapts a plain HTTP handler to the gateway mux.
+func handlerFunc(h http.HandlerFunc) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		h(w, r)
 	}
+}
 
-	for _, query := range teams {
-		_, err := db.Exec(query)
-		if err != nil {
-			return fmt.Errorf("failed to insert teams: %v", err)
+// traceHTTP starts a span for every gateway request, continuing the trace of the caller if
+// it sent a traceparent header. Probes and metric scrapes are not traced.
+func traceHTTP(h http.Handler) http.Handler {
+	return otelhttp.NewHandler(h, "gateway",
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return r.Method
+		}),
+		otelhttp.WithFilter(func(r *http.Request) bool {
+			switch r.URL.Path {
+			case "/healthz", "/readyz", "/metrics":
+				return false
+			}
+			return true
+		}),
+	)
+}
+
+// nameSpan names the request span after the matched route, such as
+// "GET /api/v1/player_game/{player_id}", rather than the path with its ids.
+func nameSpan(next runtime.HandlerFunc) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		if pattern, ok := runtime.HTTPPattern(r.Context()); ok {
+			route := pattern.String()
+			span := trace.SpanFromContext(r.Context())
+			span.SetName(r.Method + " " + route)
+			span.SetAttributes(semconv.HTTPRoute(route))
 		}
+		next(w, r, pathParams)
 	}
+}
 
-	// Insert games
-	_, err := db.Exec(`
-		INSERT INTO game (date, season, team_a_id, team_b_id)
-		VALUES
-			('2024-01-01', 2024, 1, 2),
-			('2024-01-02', 2024, 2, 3),
-			('2024-01-03', 2024, 3, 1);
-	`)
+// probeReadiness asks the server listening on the HTTP address addr whether it is ready.
+func probeReadiness(addr string, useTLS bool) error {
+	host, port, err := net.SplitHostPort(addr)
 	if err != nil {
-		return fmt.Errorf("failed to insert games: %v", err)
+		return err
+	}
+	if host == "" || net.ParseIP(host).IsUnspecified() {
+		host = "localhost"
+	}
+	client := http.Client{Timeout: 5 * time.Second}
+	scheme := "http"
+	if useTLS {
+		// The probe only reads readiness from the local server, which its certificate
+		// is unlikely to name, so the certificate is not verified.
+		scheme = "https"
+		client.Transport = &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+	}
+	resp, err := client.Get(scheme + "://" + net.JoinHostPort(host, port) + "/readyz")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("readiness returned %s", resp.Status)
+	}
+	return nil
+}
+
+// stopGRPC stops the gRPC server gracefully, cutting the remaining calls once ctx expires.
+// The calls handler passed on over HTTP are drained first, as the server cannot stop
+// gracefully while serving them.
+func stopGRPC(server *grpc.Server, handler *multiplex.Handler) lifecycle.Hook {
+	return func(ctx context.Context) error {
+		if err := handler.Drain(ctx); err != nil {
+			server.Stop()
+			return err
+		}
+		stopped := make(chan struct{})
+		go func() {
+			server.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+			return nil
+		case <-ctx.Done():
+			server.Stop()
+			return ctx.Err()
+		}
 	}
+}
 
-	// Insert players
-	_, err = db.Exec(`
-		INSERT INTO player (name, current_team_id)
-		VALUES
-			('Player 1', 1),
-			('Player 2', 2);
-	`)
+// closeDB closes the database once the servers have stopped using it.
+func closeDB(db *sql.DB) lifecycle.Hook {
+	return func(ctx context.Context) error {
+		return db.Close()
+	}
+}
+
+// newLogger builds the logger described by the logging configuration.
+func newLogger(cfg config.LoggingConfig) (*zap.Logger, error) {
+	level, err := zap.ParseAtomicLevel(cfg.Level)
 	if err != nil {
-		return fmt.Errorf("failed to insert player: %v", err)
+		return nil, err
+	}
+	zapConfig := zap.NewProductionConfig()
+	zapConfig.Level = level
+	zapConfig.Encoding = cfg.Format
+	if cfg.Format == "console" {
+		zapConfig.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 	}
+	return zapConfig.Build()
+}
 
-	return nil
+// newTLSConfigs loads the certificate files and returns the TLS configurations of the
+// gRPC and HTTP listeners. Client certificates are only verified on the gRPC port, or on
+// the HTTP port if it serves gRPC too. The files are checked for changes until shutdown.
+func newTLSConfigs(cfg config.TLSConfig, singlePort bool, logger *zap.SugaredLogger, lc *lifecycle.Manager) (grpcTLS, httpTLS *tls.Config, err error) {
+	reloader, err := certs.NewReloader(cfg.CertFile, cfg.KeyFile, cfg.ClientCAFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	clientAuth := map[string]tls.ClientAuthType{
+		"none":    tls.NoClientCert,
+		"request": tls.VerifyClientCertIfGiven,
+		"require": tls.RequireAndVerifyClientCert,
+	}[cfg.ClientAuth]
+	if grpcTLS, err = reloader.ServerConfig(clientAuth, "h2"); err != nil {
+		return nil, nil, err
+	}
+	httpClientAuth := tls.NoClientCert
+	if singlePort {
+		httpClientAuth = clientAuth
+	}
+	if httpTLS, err = reloader.ServerConfig(httpClientAuth, "h2", "http/1.1"); err != nil {
+		return nil, nil, err
+	}
+
+	reloadCtx, stopReloading := context.WithCancel(context.Background())
+	go reloader.Run(reloadCtx, cfg.ReloadInterval, func(reloaded bool, err error) {
+		if err != nil {
+			logger.Errorw("Failed to reload TLS certificates, serving the previous ones", "error", err)
+			return
+		}
+		logger.Infow("Reloaded TLS certificates", "cert_file", cfg.CertFile)
+	})
+	lc.OnDrain(stopReloading)
+	return grpcTLS, httpTLS, nil
+}
+
+// newPostgresRepository connects to Postgres, migrates the schema and creates the repository.
+func newPostgresRepository(cfg config.DatabaseConfig, m *metrics.Metrics) (p.PlayerRepository, *sql.DB) {
+	db, err := sql.Open("postgres", cfg.DSN())
+	checkError(err, "Failed to connect to the specific database")
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	// Bring the schema up to date
+	if err := p.Migrate(context.Background(), db); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+
+	return p.NewPlayerRepository(db, repositoryOptions(cfg, m)), db
+}
+
+// newSQLiteRepository opens the SQLite database file, migrates the schema and creates the repository.
+func newSQLiteRepository(path string, cfg config.DatabaseConfig, m *metrics.Metrics) (p.PlayerRepository, *sql.DB) {
+	db, err := sqlite.Open(path)
+	checkError(err, "Failed to open the SQLite database")
+
+	if err := sqlite.Migrate(context.Background(), db); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+	return sqlite.NewPlayerRepository(db, repositoryOptions(cfg, m)), db
+}
+
+// openDatabase opens the Postgres or SQLite database of cfg for the import and export
+// commands, without seeding it. In-memory storage has no database, so it returns nil.
+func openDatabase(cfg config.Config) (p.PlayerRepository, *sql.DB) {
+	switch cfg.Storage {
+	case "postgres":
+		return newPostgresRepository(cfg.Database, metrics.New())
+	case "sqlite":
+		return newSQLiteRepository(cfg.SQLite.Path, cfg.Database, metrics.New())
+	}
+	return nil, nil
+}
+
+// repositoryOptions bounds how long each query may run, how units of work
+// are isolated and retried, and reports query latencies to m
+func repositoryOptions(cfg config.DatabaseConfig, m *metrics.Metrics) p.Options {
+	// The configuration was validated, so the isolation level parses
+	isolation, _ := p.ParseIsolationLevel(cfg.TxIsolation)
+	return p.Options{
+		Timeouts:     p.Timeouts{Read: cfg.ReadTimeout, Write: cfg.WriteTimeout, Operations: cfg.OperationTimeouts},
+		Tx:           p.TxOptions{Isolation: isolation, MaxRetries: cfg.TxMaxRetries},
+		ObserveQuery: m.ObserveQuery,
+	}
+}
+
+// rateLimitOptions converts the role names of the configuration to roles.
+func rateLimitOptions(cfg config.RateLimitConfig) ratelimit.Options {
+	limits := func(byRole map[string]config.RateLimit) map[auth.Role]ratelimit.Limit {
+		out := make(map[auth.Role]ratelimit.Limit, len(byRole))
+		for role, l := range byRole {
+			out[auth.Role(role)] = ratelimit.Limit{RPS: l.RPS, Burst: l.Burst}
+		}
+		return out
+	}
+	opts := ratelimit.Options{
+		Roles:      limits(cfg.Roles),
+		Methods:    make(map[string]map[auth.Role]ratelimit.Limit, len(cfg.Methods)),
+		DailyQuota: make(map[auth.Role]int, len(cfg.DailyQuota)),
+	}
+	for method, byRole := range cfg.Methods {
+		opts.Methods[method] = limits(byRole)
+	}
+	for role, n := range cfg.DailyQuota {
+		opts.DailyQuota[auth.Role(role)] = n
+	}
+	return opts
+}
+
+// bootstrapAdminKey stores key as an admin API key, unless it is stored already.
+func bootstrapAdminKey(ctx context.Context, repo p.PlayerRepository, key string) error {
+	existing, err := repo.GetAPIKeyByHash(ctx, auth.HashAPIKey(key))
+	if err != nil || existing.ID != 0 {
+		return err
+	}
+	_, err = repo.SaveAPIKey(ctx, model.APIKey{
+		Name:      "bootstrap",
+		Prefix:    auth.KeyPrefix(key),
+		Hash:      auth.HashAPIKey(key),
+		Role:      string(auth.Admin),
+		CreatedAt: time.Now().UTC(),
+	})
+	return err
+}
+
+// generateAdminKey stores a new admin API key and returns it if the database has no API
+// keys at all, so a fresh deployment without a bootstrap key can still be administered.
+// It returns "" otherwise.
+func generateAdminKey(ctx context.Context, repo p.PlayerRepository) (string, error) {
+	keys, err := repo.ListAPIKeys(ctx)
+	if err != nil || len(keys) > 0 {
+		return "", err
+	}
+	key, prefix, hash, err := auth.NewAPIKey()
+	if err != nil {
+		return "", err
+	}
+	_, err = repo.SaveAPIKey(ctx, model.APIKey{
+		Name:      "generated",
+		Prefix:    prefix,
+		Hash:      hash,
+		Role:      string(auth.Admin),
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// seedDatabase stores the demo teams, games, players and rosters in an empty database.
+// Records are saved with fixed IDs that would overwrite existing ones, so a database
+// already holding team 1, the first team any database gets, is left as it is.
+func seedDatabase(ctx context.Context, repo p.PlayerRepository) error {
+	return repo.WithTx(ctx, func(repos p.Repositories) error {
+		team, err := repos.Players.GetTeam(ctx, 1)
+		if err != nil {
+			return fmt.Errorf("failed to check for existing teams: %v", err)
+		}
+		if team.Id != 0 {
+			return nil
+		}
+
+		// Insert teams
+		for i, name := range []string{"Team A", "Team B", "Team C"} {
+			if _, err := repos.Players.SaveTeam(ctx, model.Team{Id: i + 1, Name: name}); err != nil {
+				return fmt.Errorf("failed to insert teams: %v", err)
+			}
+		}
+
+		// Insert games
+		games := []model.Game{
+			{Id: 1, Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), SeasonID: 2024, TeamAID: 1, TeamBID: 2},
+			{Id: 2, Date: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), SeasonID: 2024, TeamAID: 2, TeamBID: 3},
+			{Id: 3, Date: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), SeasonID: 2024, TeamAID: 3, TeamBID: 1},
+		}
+		for _, game := range games {
+			game.League = validation.DefaultLeague
+			if _, err := repos.Players.SaveGame(ctx, game); err != nil {
+				return fmt.Errorf("failed to insert games: %v", err)
+			}
+		}
+
+		// Insert players
+		players := []model.Player{
+			{Id: 1, Name: "Player 1", CurrentTeamID: 1},
+			{Id: 2, Name: "Player 2", CurrentTeamID: 2},
+		}
+		for _, player := range players {
+			if _, err := repos.Players.SavePlayer(ctx, player); err != nil {
+				return fmt.Errorf("failed to insert player: %v", err)
+			}
+
+			// Insert rosters
+			err := repos.Players.SaveRosterEntry(ctx, model.RosterEntry{
+				PlayerID:  player.Id,
+				TeamID:    player.CurrentTeamID,
+				StartDate: time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to insert rosters: %v", err)
+			}
+		}
+		return nil
+	})
 }
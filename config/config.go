@@ -0,0 +1,678 @@
+// Package config loads the service configuration. Every setting has a default and can be
+// overridden, in increasing order of precedence, by a YAML file, an environment variable
+// and a command line flag.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/yaml.v3"
+
+	"nba/auth"
+	"nba/postgres"
+)
+
+// Config is the complete service configuration.
+type Config struct {
+	// Storage is the storage backend: postgres, sqlite or memory.
+	Storage    string           `yaml:"storage"`
+	Database   DatabaseConfig   `yaml:"database"`
+	SQLite     SQLiteConfig     `yaml:"sqlite"`
+	Server     ServerConfig     `yaml:"server"`
+	Health     HealthConfig     `yaml:"health"`
+	Cache      CacheConfig      `yaml:"cache"`
+	Logging    LoggingConfig    `yaml:"logging"`
+	Tracing    TracingConfig    `yaml:"tracing"`
+	Auth       AuthConfig       `yaml:"auth"`
+	RateLimit  RateLimitConfig  `yaml:"rate_limit"`
+	Validation ValidationConfig `yaml:"validation"`
+}
+
+// DatabaseConfig configures the Postgres connection, its pool and its queries.
+type DatabaseConfig struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	Name     string `yaml:"name"`
+
+	// SSLMode is a libpq sslmode: disable, require, verify-ca or verify-full.
+	SSLMode     string `yaml:"sslmode"`
+	SSLRootCert string `yaml:"sslrootcert"`
+	SSLCert     string `yaml:"sslcert"`
+	SSLKey      string `yaml:"sslkey"`
+
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time"`
+
+	ReadTimeout  time.Duration `yaml:"read_timeout"`
+	WriteTimeout time.Duration `yaml:"write_timeout"`
+	// OperationTimeouts overrides the read or write timeout of single repository
+	// methods, such as GetStatLines, keyed by method name. Zero means no limit.
+	OperationTimeouts map[string]time.Duration `yaml:"operation_timeouts"`
+	TxIsolation       string                   `yaml:"tx_isolation"`
+	TxMaxRetries      int                      `yaml:"tx_max_retries"`
+}
+
+// SQLiteConfig configures the SQLite storage backend.
+type SQLiteConfig struct {
+	Path string `yaml:"path"`
+}
+
+// ServerConfig sets the addresses the servers listen on and how they shut down.
+type ServerConfig struct {
+	GRPCAddr string `yaml:"grpc_addr"`
+	HTTPAddr string `yaml:"http_addr"`
+	// SinglePort serves native gRPC on the HTTP address next to gRPC-Web and the gateway,
+	// and leaves the gRPC address unused.
+	SinglePort bool `yaml:"single_port"`
+	// GRPCWebOrigins are the origins browsers may call gRPC-Web from, "*" allowing any.
+	GRPCWebOrigins []string `yaml:"grpc_web_origins"`
+	// ShutdownTimeout bounds how long in-flight requests may drain at shutdown.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	TLS             TLSConfig     `yaml:"tls"`
+}
+
+// TLSConfig configures TLS on the gRPC and HTTP listeners. The files are reloaded when
+// they change, so certificates can be rotated without a restart.
+type TLSConfig struct {
+	// CertFile and KeyFile hold the server certificate and its key. Empty serves plaintext.
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+	// ClientCAFile holds the CAs client certificates on the gRPC port are verified against.
+	ClientCAFile string `yaml:"client_ca_file"`
+	// ClientAuth is none, request, which verifies client certificates that are sent,
+	// or require, which rejects gRPC connections without one. With SinglePort it applies
+	// to every connection, browsers and REST clients included.
+	ClientAuth string `yaml:"client_auth"`
+	// ReloadInterval is how often the files are checked for changes.
+	ReloadInterval time.Duration `yaml:"reload_interval"`
+}
+
+// Enabled reports whether the listeners serve TLS.
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != ""
+}
+
+// HealthConfig configures the dependency checks behind readiness.
+type HealthConfig struct {
+	CheckInterval time.Duration `yaml:"check_interval"`
+	CheckTimeout  time.Duration `yaml:"check_timeout"`
+}
+
+// CacheConfig configures the season averages cache.
+type CacheConfig struct {
+	Enabled    bool          `yaml:"enabled"`
+	TTL        time.Duration `yaml:"ttl"`
+	MaxEntries int           `yaml:"max_entries"`
+}
+
+// LoggingConfig configures the logger.
+type LoggingConfig struct {
+	// Level is a zap level: debug, info, warn or error.
+	Level string `yaml:"level"`
+	// Format is json or console.
+	Format string `yaml:"format"`
+	// SampleEvery logs only one in every n successful calls of the given full gRPC method
+	// names, to keep high-volume endpoints from flooding the log.
+	SampleEvery map[string]int `yaml:"sample_every"`
+}
+
+// TracingConfig configures the export of OpenTelemetry spans.
+type TracingConfig struct {
+	// Exporter is none, stdout or otlp.
+	Exporter string `yaml:"exporter"`
+	// Endpoint is the host:port of the OTLP gRPC collector.
+	Endpoint string `yaml:"endpoint"`
+	// Insecure sends spans to the collector without TLS.
+	Insecure bool `yaml:"insecure"`
+	// SampleRatio is the fraction of new traces that are sampled. Traces started
+	// upstream follow the sampling decision of their parent.
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+// AuthConfig configures how callers authenticate. API keys are stored in the database;
+// JWTs are verified against a local JWKS file.
+type AuthConfig struct {
+	// Enabled requires every RPC but health checks to be authenticated and authorized.
+	Enabled bool `yaml:"enabled"`
+	// JWKSFile holds the public keys JWTs are verified against. Empty rejects JWTs.
+	JWKSFile string `yaml:"jwks_file"`
+	// Issuer and Audience, if set, must match the iss and aud claims of JWTs.
+	Issuer   string `yaml:"issuer"`
+	Audience string `yaml:"audience"`
+	// AnonymousRole is granted to callers without credentials, e.g. reader for public
+	// reads. Empty rejects them.
+	AnonymousRole string `yaml:"anonymous_role"`
+	// BootstrapKey, if set, is stored as an admin API key at startup, so the first keys
+	// can be created through AdminService.
+	BootstrapKey string `yaml:"bootstrap_key"`
+}
+
+// RateLimitConfig configures the limits of each client: an API key, a JWT subject or,
+// without credentials, an IP address. Limits are set per role: public, for callers
+// without credentials, reader, scorekeeper or admin.
+type RateLimitConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Roles limits the calls of each client of a role across every RPC. Roles missing
+	// from the map are not limited.
+	Roles map[string]RateLimit `yaml:"roles"`
+	// Methods overrides the role limits for full gRPC method names, per role.
+	Methods map[string]map[string]RateLimit `yaml:"methods"`
+	// DailyQuota caps the calls of each client of a role per UTC day. Roles missing
+	// from the map have no quota.
+	DailyQuota map[string]int `yaml:"daily_quota"`
+}
+
+// RateLimit is a token bucket refilling at RPS calls per second, up to Burst calls.
+// A zero RPS is unlimited; a zero Burst is RPS rounded up.
+type RateLimit struct {
+	RPS   float64 `yaml:"rps"`
+	Burst int     `yaml:"burst"`
+}
+
+// ValidationConfig configures the stat validation rules.
+type ValidationConfig struct {
+	// RulesDir optionally overrides the built-in rules per league.
+	RulesDir string `yaml:"rules_dir"`
+}
+
+// Default returns the configuration used when nothing is overridden.
+func Default() Config {
+	return Config{
+		Storage: "postgres",
+		Database: DatabaseConfig{
+			Host:         "localhost",
+			Port:         5432,
+			User:         "postgres",
+			Password:     "password",
+			Name:         "nba",
+			SSLMode:      "disable",
+			MaxOpenConns: 25,
+			MaxIdleConns: 5,
+
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+
+			ReadTimeout:  5 * time.Second,
+			WriteTimeout: 10 * time.Second,
+			TxIsolation:  "read committed",
+			TxMaxRetries: 3,
+		},
+		SQLite: SQLiteConfig{Path: "nba.db"},
+		Server: ServerConfig{
+			GRPCAddr:        ":50051",
+			HTTPAddr:        ":8080",
+			ShutdownTimeout: 15 * time.Second,
+			TLS:             TLSConfig{ClientAuth: "none", ReloadInterval: time.Minute},
+		},
+		Health: HealthConfig{CheckInterval: 5 * time.Second, CheckTimeout: 2 * time.Second},
+		Cache:  CacheConfig{Enabled: true, TTL: 30 * time.Second, MaxEntries: 10000},
+		Logging: LoggingConfig{
+			Level:  "info",
+			Format: "json",
+			SampleEvery: map[string]int{
+				"/pb.PlayerGameService/GetPlayerGameSeasonStats": 10,
+				"/pb.PlayerGameService/GetTeamSeasonStats":       10,
+			},
+		},
+		Tracing: TracingConfig{Exporter: "none", Endpoint: "localhost:4317", SampleRatio: 1},
+		Auth:    AuthConfig{Enabled: true},
+		RateLimit: RateLimitConfig{
+			Enabled: true,
+			Roles: map[string]RateLimit{
+				"public":      {RPS: 5, Burst: 10},
+				"reader":      {RPS: 20, Burst: 40},
+				"scorekeeper": {RPS: 50, Burst: 100},
+			},
+			DailyQuota: map[string]int{"public": 10000, "reader": 100000},
+		},
+	}
+}
+
+// setting is a single value that can be set from the environment or the command line.
+type setting struct {
+	flag  string
+	env   string
+	usage string
+	set   func(c *Config, value string) error
+}
+
+func stringSetting(flag, env, usage string, field func(c *Config) *string) setting {
+	return setting{flag, env, usage, func(c *Config, value string) error {
+		*field(c) = value
+		return nil
+	}}
+}
+
+func intSetting(flag, env, usage string, field func(c *Config) *int) setting {
+	return setting{flag, env, usage, func(c *Config, value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", value)
+		}
+		*field(c) = n
+		return nil
+	}}
+}
+
+func durationSetting(flag, env, usage string, field func(c *Config) *time.Duration) setting {
+	return setting{flag, env, usage, func(c *Config, value string) error {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		*field(c) = d
+		return nil
+	}}
+}
+
+func floatSetting(flag, env, usage string, field func(c *Config) *float64) setting {
+	return setting{flag, env, usage, func(c *Config, value string) error {
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", value)
+		}
+		*field(c) = f
+		return nil
+	}}
+}
+
+// intMapSetting parses comma separated key=n pairs, replacing the whole map. key names
+// the keys in error messages.
+func intMapSetting(flag, env, usage, key string, field func(c *Config) *map[string]int) setting {
+	return setting{flag, env, usage, func(c *Config, value string) error {
+		values := make(map[string]int)
+		for _, pair := range pairs(value) {
+			name, number, ok := strings.Cut(pair, "=")
+			n, err := strconv.Atoi(number)
+			if !ok || err != nil {
+				return fmt.Errorf("invalid %q, want %s=n", pair, key)
+			}
+			values[name] = n
+		}
+		*field(c) = values
+		return nil
+	}}
+}
+
+// durationMapSetting parses comma separated key=duration pairs, replacing the whole map.
+// key names the keys in error messages.
+func durationMapSetting(flag, env, usage, key string, field func(c *Config) *map[string]time.Duration) setting {
+	return setting{flag, env, usage, func(c *Config, value string) error {
+		values := make(map[string]time.Duration)
+		for _, pair := range pairs(value) {
+			name, duration, ok := strings.Cut(pair, "=")
+			d, err := time.ParseDuration(duration)
+			if !ok || err != nil {
+				return fmt.Errorf("invalid %q, want %s=duration", pair, key)
+			}
+			values[name] = d
+		}
+		*field(c) = values
+		return nil
+	}}
+}
+
+// limitSetting parses comma separated role=rps:burst pairs, the burst being optional,
+// replacing the whole map.
+func limitSetting(flag, env, usage string, field func(c *Config) *map[string]RateLimit) setting {
+	return setting{flag, env, usage, func(c *Config, value string) error {
+		limits := make(map[string]RateLimit)
+		for _, pair := range pairs(value) {
+			role, limit, ok := strings.Cut(pair, "=")
+			rps, burst, hasBurst := strings.Cut(limit, ":")
+			l := RateLimit{}
+			var err error
+			if l.RPS, err = strconv.ParseFloat(rps, 64); err == nil && hasBurst {
+				l.Burst, err = strconv.Atoi(burst)
+			}
+			if !ok || err != nil {
+				return fmt.Errorf("invalid %q, want role=rps:burst", pair)
+			}
+			limits[role] = l
+		}
+		*field(c) = limits
+		return nil
+	}}
+}
+
+// listSetting parses a comma separated list, replacing the whole list.
+func listSetting(flag, env, usage string, field func(c *Config) *[]string) setting {
+	return setting{flag, env, usage, func(c *Config, value string) error {
+		*field(c) = pairs(value)
+		return nil
+	}}
+}
+
+// pairs splits a comma separated list, dropping blank entries.
+func pairs(value string) []string {
+	var out []string
+	for _, pair := range strings.Split(value, ",") {
+		if pair = strings.TrimSpace(pair); pair != "" {
+			out = append(out, pair)
+		}
+	}
+	return out
+}
+
+func boolSetting(flag, env, usage string, field func(c *Config) *bool) setting {
+	return setting{flag, env, usage, func(c *Config, value string) error {
+		switch strings.ToLower(value) {
+		case "1", "true", "yes", "on":
+			*field(c) = true
+		case "0", "false", "no", "off":
+			*field(c) = false
+		default:
+			return fmt.Errorf("invalid boolean %q", value)
+		}
+		return nil
+	}}
+}
+
+var settings = []setting{
+	stringSetting("storage", "STORAGE", "storage backend: postgres, sqlite or memory", func(c *Config) *string { return &c.Storage }),
+
+	stringSetting("db-host", "DB_HOST", "Postgres host", func(c *Config) *string { return &c.Database.Host }),
+	intSetting("db-port", "DB_PORT", "Postgres port", func(c *Config) *int { return &c.Database.Port }),
+	stringSetting("db-user", "DB_USER", "Postgres user", func(c *Config) *string { return &c.Database.User }),
+	stringSetting("db-password", "DB_PASSWORD", "Postgres password", func(c *Config) *string { return &c.Database.Password }),
+	stringSetting("db-name", "DB_NAME", "Postgres database", func(c *Config) *string { return &c.Database.Name }),
+	stringSetting("db-sslmode", "DB_SSLMODE", "Postgres sslmode: disable, require, verify-ca or verify-full", func(c *Config) *string { return &c.Database.SSLMode }),
+	stringSetting("db-sslrootcert", "DB_SSLROOTCERT", "CA certificate the Postgres server certificate is verified against", func(c *Config) *string { return &c.Database.SSLRootCert }),
+	stringSetting("db-sslcert", "DB_SSLCERT", "client certificate presented to Postgres", func(c *Config) *string { return &c.Database.SSLCert }),
+	stringSetting("db-sslkey", "DB_SSLKEY", "key of the client certificate presented to Postgres", func(c *Config) *string { return &c.Database.SSLKey }),
+	intSetting("db-max-open-conns", "DB_MAX_OPEN_CONNS", "maximum open Postgres connections", func(c *Config) *int { return &c.Database.MaxOpenConns }),
+	intSetting("db-max-idle-conns", "DB_MAX_IDLE_CONNS", "maximum idle Postgres connections", func(c *Config) *int { return &c.Database.MaxIdleConns }),
+	durationSetting("db-conn-max-lifetime", "DB_CONN_MAX_LIFETIME", "maximum lifetime of a Postgres connection", func(c *Config) *time.Duration { return &c.Database.ConnMaxLifetime }),
+	durationSetting("db-conn-max-idle-time", "DB_CONN_MAX_IDLE_TIME", "maximum idle time of a Postgres connection", func(c *Config) *time.Duration { return &c.Database.ConnMaxIdleTime }),
+	durationSetting("db-read-timeout", "DB_READ_TIMEOUT", "timeout of read queries", func(c *Config) *time.Duration { return &c.Database.ReadTimeout }),
+	durationSetting("db-write-timeout", "DB_WRITE_TIMEOUT", "timeout of write queries", func(c *Config) *time.Duration { return &c.Database.WriteTimeout }),
+	durationMapSetting("db-operation-timeouts", "DB_OPERATION_TIMEOUTS", "comma separated operation=duration pairs overriding the timeout of a repository method", "operation", func(c *Config) *map[string]time.Duration { return &c.Database.OperationTimeouts }),
+	stringSetting("db-tx-isolation", "DB_TX_ISOLATION", "isolation level of units of work", func(c *Config) *string { return &c.Database.TxIsolation }),
+	intSetting("db-tx-max-retries", "DB_TX_MAX_RETRIES", "retries of units of work after serialization failures", func(c *Config) *int { return &c.Database.TxMaxRetries }),
+
+	stringSetting("sqlite-path", "SQLITE_PATH", "SQLite database file", func(c *Config) *string { return &c.SQLite.Path }),
+
+	stringSetting("grpc-addr", "GRPC_ADDR", "address of the gRPC server", func(c *Config) *string { return &c.Server.GRPCAddr }),
+	stringSetting("http-addr", "HTTP_ADDR", "address of the HTTP gateway", func(c *Config) *string { return &c.Server.HTTPAddr }),
+	boolSetting("single-port", "SINGLE_PORT", "serve gRPC, gRPC-Web and the gateway on the HTTP address only", func(c *Config) *bool { return &c.Server.SinglePort }),
+	listSetting("grpc-web-origins", "GRPC_WEB_ORIGINS", "comma separated origins browsers may call gRPC-Web from, * for any", func(c *Config) *[]string { return &c.Server.GRPCWebOrigins }),
+	durationSetting("shutdown-timeout", "SHUTDOWN_TIMEOUT", "how long in-flight requests may drain at shutdown", func(c *Config) *time.Duration { return &c.Server.ShutdownTimeout }),
+	stringSetting("tls-cert-file", "TLS_CERT_FILE", "server certificate, empty to serve plaintext", func(c *Config) *string { return &c.Server.TLS.CertFile }),
+	stringSetting("tls-key-file", "TLS_KEY_FILE", "key of the server certificate", func(c *Config) *string { return &c.Server.TLS.KeyFile }),
+	stringSetting("tls-client-ca-file", "TLS_CLIENT_CA_FILE", "CAs client certificates on the gRPC port are verified against", func(c *Config) *string { return &c.Server.TLS.ClientCAFile }),
+	stringSetting("tls-client-auth", "TLS_CLIENT_AUTH", "client certificates on the gRPC port: none, request or require", func(c *Config) *string { return &c.Server.TLS.ClientAuth }),
+	durationSetting("tls-reload-interval", "TLS_RELOAD_INTERVAL", "how often the certificate files are checked for changes", func(c *Config) *time.Duration { return &c.Server.TLS.ReloadInterval }),
+
+	durationSetting("health-check-interval", "HEALTH_CHECK_INTERVAL", "how often readiness checks run", func(c *Config) *time.Duration { return &c.Health.CheckInterval }),
+	durationSetting("health-check-timeout", "HEALTH_CHECK_TIMEOUT", "timeout of each readiness check", func(c *Config) *time.Duration { return &c.Health.CheckTimeout }),
+
+	boolSetting("cache-enabled", "CACHE_ENABLED", "cache season averages", func(c *Config) *bool { return &c.Cache.Enabled }),
+	durationSetting("cache-ttl", "CACHE_TTL", "how long season averages are cached", func(c *Config) *time.Duration { return &c.Cache.TTL }),
+	intSetting("cache-max-entries", "CACHE_MAX_ENTRIES", "maximum cached season averages", func(c *Config) *int { return &c.Cache.MaxEntries }),
+
+	stringSetting("log-level", "LOG_LEVEL", "log level: debug, info, warn or error", func(c *Config) *string { return &c.Logging.Level }),
+	stringSetting("log-format", "LOG_FORMAT", "log format: json or console", func(c *Config) *string { return &c.Logging.Format }),
+	intMapSetting("log-sample-every", "LOG_SAMPLE_EVERY", "comma separated method=n pairs logging one in n successful calls of a gRPC method", "method", func(c *Config) *map[string]int { return &c.Logging.SampleEvery }),
+
+	stringSetting("tracing-exporter", "TRACING_EXPORTER", "span exporter: none, stdout or otlp", func(c *Config) *string { return &c.Tracing.Exporter }),
+	stringSetting("tracing-endpoint", "TRACING_ENDPOINT", "host:port of the OTLP gRPC collector", func(c *Config) *string { return &c.Tracing.Endpoint }),
+	boolSetting("tracing-insecure", "TRACING_INSECURE", "send spans to the collector without TLS", func(c *Config) *bool { return &c.Tracing.Insecure }),
+	floatSetting("tracing-sample-ratio", "TRACING_SAMPLE_RATIO", "fraction of new traces that are sampled", func(c *Config) *float64 { return &c.Tracing.SampleRatio }),
+
+	boolSetting("auth-enabled", "AUTH_ENABLED", "require callers to authenticate", func(c *Config) *bool { return &c.Auth.Enabled }),
+	stringSetting("auth-jwks-file", "AUTH_JWKS_FILE", "JWKS file JWTs are verified against", func(c *Config) *string { return &c.Auth.JWKSFile }),
+	stringSetting("auth-issuer", "AUTH_ISSUER", "required iss claim of JWTs", func(c *Config) *string { return &c.Auth.Issuer }),
+	stringSetting("auth-audience", "AUTH_AUDIENCE", "required aud claim of JWTs", func(c *Config) *string { return &c.Auth.Audience }),
+	stringSetting("auth-anonymous-role", "AUTH_ANONYMOUS_ROLE", "role of callers without credentials, empty to reject them", func(c *Config) *string { return &c.Auth.AnonymousRole }),
+	stringSetting("auth-bootstrap-key", "AUTH_BOOTSTRAP_KEY", "admin API key stored at startup", func(c *Config) *string { return &c.Auth.BootstrapKey }),
+
+	boolSetting("rate-limit-enabled", "RATE_LIMIT_ENABLED", "limit the calls of each client", func(c *Config) *bool { return &c.RateLimit.Enabled }),
+	limitSetting("rate-limit-roles", "RATE_LIMIT_ROLES", "comma separated role=rps:burst pairs limiting each client of a role", func(c *Config) *map[string]RateLimit { return &c.RateLimit.Roles }),
+	intMapSetting("rate-limit-daily-quota", "RATE_LIMIT_DAILY_QUOTA", "comma separated role=n pairs capping the daily calls of each client of a role", "role", func(c *Config) *map[string]int { return &c.RateLimit.DailyQuota }),
+
+	stringSetting("validation-rules-dir", "VALIDATION_RULES_DIR", "directory overriding the stat validation rules per league", func(c *Config) *string { return &c.Validation.RulesDir }),
+}
+
+// Load registers the configuration flags on fs, parses args and returns the configuration
+// layered from the defaults, the YAML file named by --config or CONFIG_FILE, the environment
+// and the flags that were set. The result is validated.
+func Load(fs *flag.FlagSet, args []string, lookupEnv func(string) (string, bool)) (Config, error) {
+	configFile := fs.String("config", "", "YAML configuration file (env CONFIG_FILE)")
+	flagValues := make(map[string]*string, len(settings))
+	for _, s := range settings {
+		flagValues[s.flag] = fs.String(s.flag, "", fmt.Sprintf("%s (env %s)", s.usage, s.env))
+	}
+	if err := fs.Parse(args); err != nil {
+		return Config{}, err
+	}
+
+	cfg := Default()
+
+	path := *configFile
+	if path == "" {
+		path, _ = lookupEnv("CONFIG_FILE")
+	}
+	if path != "" {
+		if err := loadFile(&cfg, path); err != nil {
+			return Config{}, err
+		}
+	}
+
+	for _, s := range settings {
+		if value, ok := lookupEnv(s.env); ok && value != "" {
+			if err := s.set(&cfg, value); err != nil {
+				return Config{}, fmt.Errorf("invalid %s: %w", s.env, err)
+			}
+		}
+	}
+
+	// Only flags given on the command line override the other layers
+	passed := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { passed[f.Name] = true })
+	for _, s := range settings {
+		if passed[s.flag] {
+			if err := s.set(&cfg, *flagValues[s.flag]); err != nil {
+				return Config{}, fmt.Errorf("invalid --%s: %w", s.flag, err)
+			}
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+func loadFile(cfg *Config, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer f.Close()
+
+	decoder := yaml.NewDecoder(f)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// Validate reports every invalid setting at once.
+func (c Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(oneOf(c.Storage, "postgres", "sqlite", "memory"), "unknown storage %q", c.Storage)
+
+	db := c.Database
+	if c.Storage == "postgres" {
+		check(db.Host != "", "database host is required")
+		check(db.Port > 0 && db.Port < 65536, "invalid database port %d", db.Port)
+		check(db.Name != "", "database name is required")
+		check(oneOf(db.SSLMode, "disable", "allow", "prefer", "require", "verify-ca", "verify-full"), "unknown database sslmode %q", db.SSLMode)
+		check((db.SSLCert == "") == (db.SSLKey == ""), "database sslcert and sslkey must be set together")
+		check(db.MaxOpenConns >= 0, "database max_open_conns must not be negative")
+		check(db.MaxIdleConns >= 0, "database max_idle_conns must not be negative")
+		check(db.ConnMaxLifetime >= 0, "database conn_max_lifetime must not be negative")
+		check(db.ConnMaxIdleTime >= 0, "database conn_max_idle_time must not be negative")
+	}
+	if c.Storage == "sqlite" {
+		check(c.SQLite.Path != "", "sqlite path is required")
+	}
+	check(db.ReadTimeout >= 0, "database read_timeout must not be negative")
+	check(db.WriteTimeout >= 0, "database write_timeout must not be negative")
+	for operation, timeout := range db.OperationTimeouts {
+		check(postgres.IsOperation(operation), "database operation_timeouts: unknown operation %q", operation)
+		check(timeout >= 0, "database operation_timeouts of %s must not be negative", operation)
+	}
+	check(db.TxMaxRetries >= 0, "database tx_max_retries must not be negative")
+	if _, err := postgres.ParseIsolationLevel(db.TxIsolation); err != nil {
+		errs = append(errs, fmt.Errorf("database tx_isolation: %w", err))
+	}
+
+	check(c.Server.GRPCAddr != "", "server grpc_addr is required")
+	check(c.Server.HTTPAddr != "", "server http_addr is required")
+	check(c.Server.ShutdownTimeout > 0, "server shutdown_timeout must be positive")
+	tlsConfig := c.Server.TLS
+	check((tlsConfig.CertFile == "") == (tlsConfig.KeyFile == ""), "server tls cert_file and key_file must be set together")
+	check(oneOf(tlsConfig.ClientAuth, "none", "request", "require"), "unknown server tls client_auth %q", tlsConfig.ClientAuth)
+	if tlsConfig.ClientAuth == "request" || tlsConfig.ClientAuth == "require" {
+		check(tlsConfig.Enabled(), "server tls client_auth %s requires cert_file", tlsConfig.ClientAuth)
+		check(tlsConfig.ClientCAFile != "", "server tls client_auth %s requires client_ca_file", tlsConfig.ClientAuth)
+	}
+	check(tlsConfig.ReloadInterval > 0, "server tls reload_interval must be positive")
+
+	check(c.Health.CheckInterval > 0, "health check_interval must be positive")
+	check(c.Health.CheckTimeout > 0, "health check_timeout must be positive")
+
+	if c.Cache.Enabled {
+		check(c.Cache.TTL > 0, "cache ttl must be positive")
+		check(c.Cache.MaxEntries > 0, "cache max_entries must be positive")
+	}
+
+	if _, err := zapcore.ParseLevel(c.Logging.Level); err != nil {
+		errs = append(errs, fmt.Errorf("logging level: %w", err))
+	}
+	check(oneOf(c.Logging.Format, "json", "console"), "unknown logging format %q", c.Logging.Format)
+	for method, n := range c.Logging.SampleEvery {
+		check(n > 0, "logging sample_every of %s must be positive", method)
+	}
+
+	check(oneOf(c.Tracing.Exporter, "none", "stdout", "otlp"), "unknown tracing exporter %q", c.Tracing.Exporter)
+	if c.Tracing.Exporter == "otlp" {
+		check(c.Tracing.Endpoint != "", "tracing endpoint is required")
+	}
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing sample_ratio must be between 0 and 1")
+
+	if c.Auth.AnonymousRole != "" {
+		if _, err := auth.ParseRole(c.Auth.AnonymousRole); err != nil {
+			errs = append(errs, fmt.Errorf("auth anonymous_role: %w", err))
+		}
+	}
+	if c.Auth.BootstrapKey != "" {
+		if err := auth.ValidateAPIKey(c.Auth.BootstrapKey); err != nil {
+			errs = append(errs, fmt.Errorf("auth bootstrap_key: %w", err))
+		}
+	}
+
+	checkLimit := func(where string, l RateLimit) {
+		check(l.RPS >= 0 && l.Burst >= 0, "rate_limit %s must not be negative", where)
+	}
+	for role, l := range c.RateLimit.Roles {
+		check(limitRole(role), "rate_limit roles: unknown role %q", role)
+		checkLimit("roles "+role, l)
+	}
+	for method, roles := range c.RateLimit.Methods {
+		check(strings.HasPrefix(method, "/"), "rate_limit methods: %q is not a full method name", method)
+		for role, l := range roles {
+			check(limitRole(role), "rate_limit methods of %s: unknown role %q", method, role)
+			checkLimit("methods "+method+" "+role, l)
+		}
+	}
+	for role, n := range c.RateLimit.DailyQuota {
+		check(limitRole(role), "rate_limit daily_quota: unknown role %q", role)
+		check(n >= 0, "rate_limit daily_quota of %s must not be negative", role)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// limitRole reports whether role names a role limits can be set for.
+func limitRole(role string) bool {
+	_, err := auth.ParseRole(role)
+	return err == nil || auth.Role(role) == auth.Public
+}
+
+func oneOf(value string, allowed ...string) bool {
+	for _, a := range allowed {
+		if value == a {
+			return true
+		}
+	}
+	return false
+}
+
+// Redacted returns a copy of the configuration with secrets replaced, safe to print or log.
+func (c Config) Redacted() Config {
+	if c.Database.Password != "" {
+		c.Database.Password = redacted
+	}
+	if c.Auth.BootstrapKey != "" {
+		c.Auth.BootstrapKey = redacted
+	}
+	return c
+}
+
+const redacted = "[REDACTED]"
+
+// YAML renders the configuration in the format of the configuration file.
+func (c Config) YAML() ([]byte, error) {
+	return yaml.Marshal(c)
+}
+
+// DSN returns the libpq connection string of the database.
+func (d DatabaseConfig) DSN() string {
+	params := []struct{ key, value string }{
+		{"host", d.Host},
+		{"port", strconv.Itoa(d.Port)},
+		{"user", d.User},
+		{"password", d.Password},
+		{"dbname", d.Name},
+		{"sslmode", d.SSLMode},
+		{"sslrootcert", d.SSLRootCert},
+		{"sslcert", d.SSLCert},
+		{"sslkey", d.SSLKey},
+	}
+	var parts []string
+	for _, p := range params {
+		if p.value != "" {
+			parts = append(parts, p.key+"="+quoteDSN(p.value))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// quoteDSN quotes a connection string value, escaping quotes and backslashes.
+func quoteDSN(value string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value)
+	return "'" + escaped + "'"
+}
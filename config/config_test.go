@@ -0,0 +1,293 @@
+package config_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"nba/config"
+)
+
+func env(values map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := values[key]
+		return value, ok
+	}
+}
+
+func load(t *testing.T, args []string, environment map[string]string) (config.Config, error) {
+	t.Helper()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	return config.Load(fs, args, env(environment))
+}
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return path
+}
+
+func TestLoadDefaults(t *testing.T) {
+	cfg, err := load(t, nil, nil)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !reflect.DeepEqual(cfg, config.Default()) {
+		t.Fatalf("Load() = %+v, want the defaults", cfg)
+	}
+}
+
+func TestLoadLayers(t *testing.T) {
+	path := writeFile(t, `
+database:
+  host: file-host
+  port: 6432
+  read_timeout: 2s
+server:
+  grpc_addr: ":9000"
+logging:
+  level: debug
+`)
+
+	tests := []struct {
+		name        string
+		args        []string
+		environment map[string]string
+		check       func(t *testing.T, cfg config.Config)
+	}{
+		{
+			name: "file overrides defaults",
+			args: []string{"--config", path},
+			check: func(t *testing.T, cfg config.Config) {
+				if cfg.Database.Host != "file-host" || cfg.Database.Port != 6432 || cfg.Database.ReadTimeout != 2*time.Second {
+					t.Errorf("database = %+v", cfg.Database)
+				}
+				if cfg.Database.Name != "nba" || cfg.Server.HTTPAddr != ":8080" {
+					t.Errorf("settings missing from the file lost their defaults: %+v", cfg)
+				}
+			},
+		},
+		{
+			name:        "env overrides the file",
+			environment: map[string]string{"CONFIG_FILE": path, "DB_HOST": "env-host", "LOG_LEVEL": "warn"},
+			check: func(t *testing.T, cfg config.Config) {
+				if cfg.Database.Host != "env-host" || cfg.Database.Port != 6432 || cfg.Logging.Level != "warn" {
+					t.Errorf("config = %+v", cfg)
+				}
+			},
+		},
+		{
+			name:        "flags override env",
+			args:        []string{"--config", path, "--db-host", "flag-host", "--cache-enabled=false", "--grpc-addr=:9100"},
+			environment: map[string]string{"DB_HOST": "env-host", "CACHE_ENABLED": "true"},
+			check: func(t *testing.T, cfg config.Config) {
+				if cfg.Database.Host != "flag-host" || cfg.Cache.Enabled || cfg.Server.GRPCAddr != ":9100" {
+					t.Errorf("config = %+v", cfg)
+				}
+			},
+		},
+		{
+			name:        "sampling replaces the defaults",
+			environment: map[string]string{"LOG_SAMPLE_EVERY": "/pb.PlayerGameService/GetPlayer=5, /pb.PlayerGameService/ValidateGame=2"},
+			check: func(t *testing.T, cfg config.Config) {
+				want := map[string]int{"/pb.PlayerGameService/GetPlayer": 5, "/pb.PlayerGameService/ValidateGame": 2}
+				if !reflect.DeepEqual(cfg.Logging.SampleEvery, want) {
+					t.Errorf("sample_every = %v, want %v", cfg.Logging.SampleEvery, want)
+				}
+			},
+		},
+		{
+			name:        "operation timeouts",
+			environment: map[string]string{"DB_OPERATION_TIMEOUTS": "GetStatLines=1m, LogPlayerGame=0s"},
+			check: func(t *testing.T, cfg config.Config) {
+				want := map[string]time.Duration{"GetStatLines": time.Minute, "LogPlayerGame": 0}
+				if !reflect.DeepEqual(cfg.Database.OperationTimeouts, want) {
+					t.Errorf("operation_timeouts = %v, want %v", cfg.Database.OperationTimeouts, want)
+				}
+			},
+		},
+		{
+			name:        "rate limits replace the defaults",
+			environment: map[string]string{"RATE_LIMIT_ROLES": "public=1:2,reader=10", "RATE_LIMIT_DAILY_QUOTA": "public=100"},
+			check: func(t *testing.T, cfg config.Config) {
+				want := map[string]config.RateLimit{"public": {RPS: 1, Burst: 2}, "reader": {RPS: 10}}
+				if !reflect.DeepEqual(cfg.RateLimit.Roles, want) {
+					t.Errorf("roles = %v, want %v", cfg.RateLimit.Roles, want)
+				}
+				if !reflect.DeepEqual(cfg.RateLimit.DailyQuota, map[string]int{"public": 100}) {
+					t.Errorf("daily_quota = %v, want only public", cfg.RateLimit.DailyQuota)
+				}
+			},
+		},
+		{
+			name:        "single port with gRPC-Web origins",
+			environment: map[string]string{"SINGLE_PORT": "true", "GRPC_WEB_ORIGINS": "https://stats.example, http://localhost:3000"},
+			check: func(t *testing.T, cfg config.Config) {
+				want := []string{"https://stats.example", "http://localhost:3000"}
+				if !cfg.Server.SinglePort || !reflect.DeepEqual(cfg.Server.GRPCWebOrigins, want) {
+					t.Errorf("server = %+v, want single port and origins %v", cfg.Server, want)
+				}
+			},
+		},
+		{
+			name:        "empty env values are ignored",
+			environment: map[string]string{"DB_HOST": ""},
+			check: func(t *testing.T, cfg config.Config) {
+				if cfg.Database.Host != "localhost" {
+					t.Errorf("host = %q, want the default", cfg.Database.Host)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := load(t, tt.args, tt.environment)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		environment map[string]string
+		file        string
+		wantErr     []string
+	}{
+		{
+			name:        "malformed env value",
+			environment: map[string]string{"DB_READ_TIMEOUT": "soon"},
+			wantErr:     []string{"invalid DB_READ_TIMEOUT"},
+		},
+		{
+			name:    "malformed flag value",
+			args:    []string{"--db-port", "fifty"},
+			wantErr: []string{"invalid --db-port"},
+		},
+		{
+			name:    "unknown file setting",
+			file:    "database:\n  hostname: db\n",
+			wantErr: []string{"field hostname not found"},
+		},
+		{
+			name: "every invalid setting is reported",
+			environment: map[string]string{
+				"STORAGE":         "postgres",
+				"DB_SSLMODE":      "sometimes",
+				"DB_TX_ISOLATION": "chaotic",
+				"LOG_FORMAT":      "xml",
+				"CACHE_TTL":       "0s",
+			},
+			wantErr: []string{`unknown database sslmode "sometimes"`, `unknown isolation level "chaotic"`, `unknown logging format "xml"`, "cache ttl must be positive"},
+		},
+		{
+			name:    "invalid sampling",
+			args:    []string{"--log-sample-every", "/pb.PlayerGameService/GetPlayer=0,/pb.PlayerGameService/ValidateGame"},
+			wantErr: []string{"want method=n"},
+		},
+		{
+			name:    "invalid operation timeouts",
+			file:    "database:\n  operation_timeouts:\n    GetTeams: 1s\n    GetTeam: -1s\n",
+			wantErr: []string{`unknown operation "GetTeams"`, "operation_timeouts of GetTeam must not be negative"},
+		},
+		{
+			name:        "malformed operation timeout",
+			environment: map[string]string{"DB_OPERATION_TIMEOUTS": "GetTeam=soon"},
+			wantErr:     []string{"want operation=duration"},
+		},
+		{
+			name:        "invalid tracing",
+			environment: map[string]string{"TRACING_EXPORTER": "zipkin", "TRACING_SAMPLE_RATIO": "1.5"},
+			wantErr:     []string{`unknown tracing exporter "zipkin"`, "sample_ratio must be between 0 and 1"},
+		},
+		{
+			name:        "invalid auth",
+			environment: map[string]string{"AUTH_ANONYMOUS_ROLE": "guest", "AUTH_BOOTSTRAP_KEY": "letmein"},
+			wantErr:     []string{`unknown role "guest"`, `bootstrap_key: api key must start with "nba_"`},
+		},
+		{
+			name:    "invalid rate limits",
+			file:    "rate_limit:\n  roles:\n    guest: {rps: 1}\n  methods:\n    GetPlayer:\n      reader: {rps: -1}\n  daily_quota:\n    reader: -5\n",
+			wantErr: []string{`roles: unknown role "guest"`, `"GetPlayer" is not a full method name`, "methods GetPlayer reader must not be negative", "daily_quota of reader must not be negative"},
+		},
+		{
+			name:        "malformed rate limit",
+			environment: map[string]string{"RATE_LIMIT_ROLES": "reader=fast"},
+			wantErr:     []string{"want role=rps:burst"},
+		},
+		{
+			name:        "invalid tls",
+			environment: map[string]string{"TLS_KEY_FILE": "tls.key", "TLS_CLIENT_AUTH": "require", "TLS_RELOAD_INTERVAL": "0s"},
+			wantErr:     []string{"tls cert_file and key_file must be set together", "client_auth require requires cert_file", "client_auth require requires client_ca_file", "reload_interval must be positive"},
+		},
+		{
+			name:        "client certificate without key",
+			environment: map[string]string{"DB_SSLCERT": "client.crt"},
+			wantErr:     []string{"sslcert and sslkey must be set together"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := tt.args
+			if tt.file != "" {
+				args = append(args, "--config", writeFile(t, tt.file))
+			}
+			_, err := load(t, args, tt.environment)
+			if err == nil {
+				t.Fatalf("Load() succeeded, want errors %q", tt.wantErr)
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Load() error = %v, want it to contain %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := config.Default()
+	cfg.Database.Password = "hunter2"
+	cfg.Auth.BootstrapKey = "nba_0123456789abcdef0123456789abcdef"
+
+	out, err := cfg.Redacted().YAML()
+	if err != nil {
+		t.Fatalf("YAML() error = %v", err)
+	}
+	if strings.Contains(string(out), "hunter2") || !strings.Contains(string(out), "[REDACTED]") {
+		t.Fatalf("redacted config leaks the password:\n%s", out)
+	}
+	if strings.Contains(string(out), cfg.Auth.BootstrapKey) {
+		t.Fatalf("redacted config leaks the bootstrap key:\n%s", out)
+	}
+	if !strings.Contains(string(out), "read_timeout: 5s") {
+		t.Fatalf("durations are not human readable:\n%s", out)
+	}
+	if cfg.Database.Password != "hunter2" {
+		t.Fatalf("Redacted() modified the original config")
+	}
+}
+
+func TestDSN(t *testing.T) {
+	db := config.Default().Database
+	db.Host = "db"
+	db.Password = `it's a \secret`
+	db.SSLMode = "verify-full"
+	db.SSLRootCert = "/etc/ssl/ca.pem"
+
+	want := `host='db' port='5432' user='postgres' password='it\'s a \\secret' dbname='nba' sslmode='verify-full' sslrootcert='/etc/ssl/ca.pem'`
+	if got := db.DSN(); got != want {
+		t.Fatalf("DSN() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,41 @@
+// Package docs serves the OpenAPI document of the HTTP gateway and a Swagger UI page
+// rendering it. player_game.swagger.json is generated from pb/player_game.proto by
+// protoc-gen-openapiv2, next to the gateway itself, so the two stay in step.
+package docs
+
+import (
+	"embed"
+	"net/http"
+)
+
+var (
+	//go:embed player_game.swagger.json
+	spec []byte
+	//go:embed index.html
+	index []byte
+	//go:embed swagger-ui/swagger-ui-bundle.js swagger-ui/swagger-ui.css
+	swaggerUI embed.FS
+)
+
+// assets serves swaggerUI at /docs/swagger-ui/.
+var assets = http.StripPrefix("/docs", http.FileServerFS(swaggerUI))
+
+// SpecHandler serves the OpenAPI v2 document.
+func SpecHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(spec)
+}
+
+// UIHandler serves the Swagger UI page, which loads the document from docs/openapi.json
+// and the Swagger UI script and stylesheet from docs/swagger-ui/, relative to where it is
+// served.
+func UIHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(index)
+}
+
+// AssetHandler serves the Swagger UI script and stylesheet vendored in swagger-ui/, at
+// /docs/swagger-ui/swagger-ui-bundle.js and /docs/swagger-ui/swagger-ui.css.
+func AssetHandler(w http.ResponseWriter, r *http.Request) {
+	assets.ServeHTTP(w, r)
+}
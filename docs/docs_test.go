@@ -0,0 +1,75 @@
+package docs_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"nba/docs"
+)
+
+func TestSpecHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	docs.SpecHandler(rec, httptest.NewRequest(http.MethodGet, "/docs/openapi.json", nil))
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("Content-Type = %q, want application/json", ct)
+	}
+
+	var spec struct {
+		Swagger string `json:"swagger"`
+		Paths   map[string]map[string]struct {
+			OperationID string `json:"operationId"`
+			Summary     string `json:"summary"`
+		} `json:"paths"`
+		Definitions map[string]struct {
+			Properties map[string]struct {
+				Title string `json:"title"`
+			} `json:"properties"`
+		} `json:"definitions"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("spec is not JSON: %v", err)
+	}
+	if spec.Swagger != "2.0" {
+		t.Fatalf("swagger = %q, want 2.0", spec.Swagger)
+	}
+
+	op := spec.Paths["/api/v1/player_game/seasons/{season}/players/{playerId}"]["get"]
+	if op.OperationID != "PlayerGameService_GetPlayerGameSeasonStats" || !strings.Contains(op.Summary, "averages of a player") {
+		t.Fatalf("season stats operation = %+v, want it documented from the proto", op)
+	}
+	upsert := spec.Definitions["pbLogPlayerGameRequest"].Properties["upsert"]
+	if !strings.Contains(upsert.Title, "Replace an existing stat line") {
+		t.Fatalf("upsert field = %+v, want its proto comment", upsert)
+	}
+}
+
+func TestUIHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	docs.UIHandler(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	if !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/html") || !strings.Contains(rec.Body.String(), `url: "docs/openapi.json"`) ||
+		strings.Contains(rec.Body.String(), "https://") {
+		t.Fatalf("UI = %s %q, want a page loading the spec and nothing from other hosts", rec.Header().Get("Content-Type"), rec.Body.String())
+	}
+}
+
+func TestAssetHandler(t *testing.T) {
+	for path, contentType := range map[string]string{
+		"/docs/swagger-ui/swagger-ui-bundle.js": "text/javascript",
+		"/docs/swagger-ui/swagger-ui.css":       "text/css",
+	} {
+		rec := httptest.NewRecorder()
+		docs.AssetHandler(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), contentType) || rec.Body.Len() == 0 {
+			t.Errorf("GET %s = %d %s, want the embedded file", path, rec.Code, rec.Header().Get("Content-Type"))
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	docs.AssetHandler(rec, httptest.NewRequest(http.MethodGet, "/docs/swagger-ui/README.md", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("GET README.md = %d, want only the UI files served", rec.Code)
+	}
+}
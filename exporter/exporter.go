@@ -0,0 +1,398 @@
+// Package exporter dumps stat lines, season averages and box scores as CSV, JSON Lines
+// or Parquet, for loading the service's data into other tools.
+package exporter
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"nba/model"
+	"nba/postgres"
+)
+
+// Kind is the data an export holds.
+type Kind string
+
+const (
+	// StatLines holds a row per player per game.
+	StatLines Kind = "stat_lines"
+	// SeasonAverages holds a row per player per season and team, averaged over its games.
+	SeasonAverages Kind = "season_averages"
+	// BoxScores holds a row per team per game with the totals of its players. Both
+	// teams of every game the filter matches are included.
+	BoxScores Kind = "box_scores"
+)
+
+// Kinds lists every kind of export.
+var Kinds = []Kind{StatLines, SeasonAverages, BoxScores}
+
+// ParseKind returns the kind named by s, such as "stat_lines".
+func ParseKind(s string) (Kind, error) {
+	k := Kind(strings.ToLower(s))
+	if !slices.Contains(Kinds, k) {
+		return "", fmt.Errorf("unknown export kind %q, want stat_lines, season_averages or box_scores", s)
+	}
+	return k, nil
+}
+
+// Format is the encoding of an export.
+type Format string
+
+const (
+	// CSV holds a header row naming the columns, then a row per record.
+	CSV Format = "csv"
+	// JSONL holds one JSON object per line.
+	JSONL Format = "jsonl"
+	// Parquet is the Apache Parquet columnar format.
+	Parquet Format = "parquet"
+)
+
+// ParseFormat returns the format named by s, such as "csv", "jsonl" or "parquet".
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case CSV, JSONL, Parquet:
+		return f, nil
+	case "ndjson":
+		return JSONL, nil
+	}
+	return "", fmt.Errorf("unknown export format %q, want csv, jsonl or parquet", s)
+}
+
+// ContentType returns the media type of f.
+func (f Format) ContentType() string {
+	switch f {
+	case CSV:
+		return "text/csv"
+	case JSONL:
+		return "application/x-ndjson"
+	case Parquet:
+		return "application/vnd.apache.parquet"
+	}
+	return "application/octet-stream"
+}
+
+// ErrInvalidOptions is returned when the options of an export cannot be used.
+var ErrInvalidOptions = errors.New("invalid export options")
+
+// Options describe an export.
+type Options struct {
+	Kind   Kind
+	Format Format
+	// Filter selects the stat lines the export is made of; at least one of its
+	// fields must be set.
+	Filter model.StatLineFilter
+}
+
+// Exporter reads the exported data from a repository.
+type Exporter struct {
+	repo postgres.PlayerRepository
+}
+
+// New creates an exporter reading from repo.
+func New(repo postgres.PlayerRepository) *Exporter {
+	return &Exporter{repo: repo}
+}
+
+// Run writes the export described by opts to w and returns the number of rows written.
+// Everything is read before the first byte is written, so a failed read writes nothing.
+func (ex *Exporter) Run(ctx context.Context, w io.Writer, opts Options) (int, error) {
+	if !slices.Contains(Kinds, opts.Kind) {
+		return 0, fmt.Errorf("%w: unknown kind %q", ErrInvalidOptions, opts.Kind)
+	}
+	if opts.Format != CSV && opts.Format != JSONL && opts.Format != Parquet {
+		return 0, fmt.Errorf("%w: unknown format %q", ErrInvalidOptions, opts.Format)
+	}
+	f := opts.Filter
+	if f.Season < 0 || f.TeamID < 0 || f.PlayerID < 0 {
+		return 0, fmt.Errorf("%w: season, team and player must not be negative", ErrInvalidOptions)
+	}
+	if f == (model.StatLineFilter{}) {
+		return 0, fmt.Errorf("%w: a season, team or player is required", ErrInvalidOptions)
+	}
+
+	lines, err := ex.repo.GetStatLines(ctx, f)
+	if err != nil {
+		return 0, err
+	}
+	l := &lookup{repo: ex.repo, games: make(map[int]model.Game), teams: make(map[int]string)}
+	switch opts.Kind {
+	case StatLines:
+		rows, err := statLineRows(ctx, l, lines)
+		if err != nil {
+			return 0, err
+		}
+		return len(rows), writeRows(w, opts.Format, rows)
+	case SeasonAverages:
+		rows, err := seasonAverageRows(ctx, l, lines)
+		if err != nil {
+			return 0, err
+		}
+		return len(rows), writeRows(w, opts.Format, rows)
+	default:
+		rows, err := boxScoreRows(ctx, l, ex.repo, lines)
+		if err != nil {
+			return 0, err
+		}
+		return len(rows), writeRows(w, opts.Format, rows)
+	}
+}
+
+// StatLineRow is a row of a stat lines export.
+type StatLineRow struct {
+	Season        int     `json:"season" parquet:"season"`
+	Date          string  `json:"date" parquet:"date"`
+	GameID        int     `json:"game_id" parquet:"game_id"`
+	League        string  `json:"league" parquet:"league"`
+	TeamID        int     `json:"team_id" parquet:"team_id"`
+	Team          string  `json:"team" parquet:"team"`
+	OpponentID    int     `json:"opponent_id" parquet:"opponent_id"`
+	Opponent      string  `json:"opponent" parquet:"opponent"`
+	PlayerID      int     `json:"player_id" parquet:"player_id"`
+	PlayerName    string  `json:"player_name" parquet:"player_name"`
+	Points        int     `json:"points" parquet:"points"`
+	Assists       int     `json:"assists" parquet:"assists"`
+	Rebounds      int     `json:"rebounds" parquet:"rebounds"`
+	Steals        int     `json:"steals" parquet:"steals"`
+	Blocks        int     `json:"blocks" parquet:"blocks"`
+	Turnovers     int     `json:"turnovers" parquet:"turnovers"`
+	Fouls         int     `json:"fouls" parquet:"fouls"`
+	MinutesPlayed float32 `json:"minutes_played" parquet:"minutes_played"`
+	PlusMinus     int     `json:"plus_minus" parquet:"plus_minus"`
+}
+
+// SeasonAverageRow is a row of a season averages export. A player traded during a
+// season has a row per team.
+type SeasonAverageRow struct {
+	Season               int     `json:"season" parquet:"season"`
+	PlayerID             int     `json:"player_id" parquet:"player_id"`
+	PlayerName           string  `json:"player_name" parquet:"player_name"`
+	TeamID               int     `json:"team_id" parquet:"team_id"`
+	Team                 string  `json:"team" parquet:"team"`
+	GamesPlayed          int     `json:"games_played" parquet:"games_played"`
+	PointsPerGame        float32 `json:"points_per_game" parquet:"points_per_game"`
+	AssistsPerGame       float32 `json:"assists_per_game" parquet:"assists_per_game"`
+	ReboundsPerGame      float32 `json:"rebounds_per_game" parquet:"rebounds_per_game"`
+	StealsPerGame        float32 `json:"steals_per_game" parquet:"steals_per_game"`
+	BlocksPerGame        float32 `json:"blocks_per_game" parquet:"blocks_per_game"`
+	TurnoversPerGame     float32 `json:"turnovers_per_game" parquet:"turnovers_per_game"`
+	FoulsPerGame         float32 `json:"fouls_per_game" parquet:"fouls_per_game"`
+	MinutesPlayedPerGame float32 `json:"minutes_played_per_game" parquet:"minutes_played_per_game"`
+	PlusMinusPerGame     float32 `json:"plus_minus_per_game" parquet:"plus_minus_per_game"`
+}
+
+// BoxScoreRow is a row of a box scores export: the totals of a team's players in a game.
+type BoxScoreRow struct {
+	Season          int     `json:"season" parquet:"season"`
+	Date            string  `json:"date" parquet:"date"`
+	GameID          int     `json:"game_id" parquet:"game_id"`
+	League          string  `json:"league" parquet:"league"`
+	OvertimePeriods int     `json:"overtime_periods" parquet:"overtime_periods"`
+	TeamID          int     `json:"team_id" parquet:"team_id"`
+	Team            string  `json:"team" parquet:"team"`
+	OpponentID      int     `json:"opponent_id" parquet:"opponent_id"`
+	Opponent        string  `json:"opponent" parquet:"opponent"`
+	Players         int     `json:"players" parquet:"players"`
+	Points          int     `json:"points" parquet:"points"`
+	OpponentPoints  int     `json:"opponent_points" parquet:"opponent_points"`
+	Assists         int     `json:"assists" parquet:"assists"`
+	Rebounds        int     `json:"rebounds" parquet:"rebounds"`
+	Steals          int     `json:"steals" parquet:"steals"`
+	Blocks          int     `json:"blocks" parquet:"blocks"`
+	Turnovers       int     `json:"turnovers" parquet:"turnovers"`
+	Fouls           int     `json:"fouls" parquet:"fouls"`
+	MinutesPlayed   float32 `json:"minutes_played" parquet:"minutes_played"`
+}
+
+func statLineRows(ctx context.Context, l *lookup, lines []model.PlayerGameStats) ([]StatLineRow, error) {
+	rows := make([]StatLineRow, 0, len(lines))
+	for _, line := range lines {
+		game, err := l.game(ctx, line.GameID)
+		if err != nil {
+			return nil, err
+		}
+		row := StatLineRow{
+			Season:        game.SeasonID,
+			Date:          game.Date.Format(time.DateOnly),
+			GameID:        game.Id,
+			League:        game.League,
+			TeamID:        line.TeamID,
+			OpponentID:    opponentOf(game, line.TeamID),
+			PlayerID:      line.PlayerID,
+			PlayerName:    line.PlayerName,
+			Points:        line.Points,
+			Assists:       line.Assists,
+			Rebounds:      line.Rebounds,
+			Steals:        line.Steals,
+			Blocks:        line.Blocks,
+			Turnovers:     line.Turnovers,
+			Fouls:         line.Fouls,
+			MinutesPlayed: line.MinutesPlayed,
+			PlusMinus:     line.PlusMinus,
+		}
+		if row.Team, err = l.team(ctx, row.TeamID); err != nil {
+			return nil, err
+		}
+		if row.Opponent, err = l.team(ctx, row.OpponentID); err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func seasonAverageRows(ctx context.Context, l *lookup, lines []model.PlayerGameStats) ([]SeasonAverageRow, error) {
+	type key struct{ season, playerID, teamID int }
+	sums := make(map[key]*SeasonAverageRow)
+	var rows []*SeasonAverageRow
+	for _, line := range lines {
+		game, err := l.game(ctx, line.GameID)
+		if err != nil {
+			return nil, err
+		}
+		k := key{game.SeasonID, line.PlayerID, line.TeamID}
+		row, ok := sums[k]
+		if !ok {
+			row = &SeasonAverageRow{Season: game.SeasonID, PlayerID: line.PlayerID, PlayerName: line.PlayerName, TeamID: line.TeamID}
+			if row.Team, err = l.team(ctx, line.TeamID); err != nil {
+				return nil, err
+			}
+			sums[k] = row
+			rows = append(rows, row)
+		}
+		row.GamesPlayed++
+		row.PointsPerGame += float32(line.Points)
+		row.AssistsPerGame += float32(line.Assists)
+		row.ReboundsPerGame += float32(line.Rebounds)
+		row.StealsPerGame += float32(line.Steals)
+		row.BlocksPerGame += float32(line.Blocks)
+		row.TurnoversPerGame += float32(line.Turnovers)
+		row.FoulsPerGame += float32(line.Fouls)
+		row.MinutesPlayedPerGame += line.MinutesPlayed
+		row.PlusMinusPerGame += float32(line.PlusMinus)
+	}
+
+	averages := make([]SeasonAverageRow, 0, len(rows))
+	for _, row := range rows {
+		games := float32(row.GamesPlayed)
+		row.PointsPerGame /= games
+		row.AssistsPerGame /= games
+		row.ReboundsPerGame /= games
+		row.StealsPerGame /= games
+		row.BlocksPerGame /= games
+		row.TurnoversPerGame /= games
+		row.FoulsPerGame /= games
+		row.MinutesPlayedPerGame /= games
+		row.PlusMinusPerGame /= games
+		averages = append(averages, *row)
+	}
+	slices.SortFunc(averages, func(a, b SeasonAverageRow) int {
+		return cmp.Or(cmp.Compare(a.Season, b.Season), cmp.Compare(a.PlayerID, b.PlayerID), cmp.Compare(a.TeamID, b.TeamID))
+	})
+	return averages, nil
+}
+
+func boxScoreRows(ctx context.Context, l *lookup, repo postgres.PlayerRepository, lines []model.PlayerGameStats) ([]BoxScoreRow, error) {
+	var rows []BoxScoreRow
+	// The lines are ordered by game, so each game comes up once
+	for i, line := range lines {
+		if i > 0 && lines[i-1].GameID == line.GameID {
+			continue
+		}
+		game, err := l.game(ctx, line.GameID)
+		if err != nil {
+			return nil, err
+		}
+		gameLines, err := repo.GetGameStats(ctx, game.Id)
+		if err != nil {
+			return nil, err
+		}
+		teams := [2]BoxScoreRow{}
+		for j, teamID := range []int{game.TeamAID, game.TeamBID} {
+			row := BoxScoreRow{
+				Season:          game.SeasonID,
+				Date:            game.Date.Format(time.DateOnly),
+				GameID:          game.Id,
+				League:          game.League,
+				OvertimePeriods: game.OvertimePeriods,
+				TeamID:          teamID,
+				OpponentID:      opponentOf(game, teamID),
+			}
+			if row.Team, err = l.team(ctx, row.TeamID); err != nil {
+				return nil, err
+			}
+			if row.Opponent, err = l.team(ctx, row.OpponentID); err != nil {
+				return nil, err
+			}
+			for _, gameLine := range gameLines {
+				if gameLine.TeamID != teamID {
+					continue
+				}
+				row.Players++
+				row.Points += gameLine.Points
+				row.Assists += gameLine.Assists
+				row.Rebounds += gameLine.Rebounds
+				row.Steals += gameLine.Steals
+				row.Blocks += gameLine.Blocks
+				row.Turnovers += gameLine.Turnovers
+				row.Fouls += gameLine.Fouls
+				row.MinutesPlayed += gameLine.MinutesPlayed
+			}
+			teams[j] = row
+		}
+		teams[0].OpponentPoints, teams[1].OpponentPoints = teams[1].Points, teams[0].Points
+		rows = append(rows, teams[0], teams[1])
+	}
+	return rows, nil
+}
+
+// opponentOf returns the team teamID played in game, or 0 if it did not play in it.
+func opponentOf(game model.Game, teamID int) int {
+	switch teamID {
+	case game.TeamAID:
+		return game.TeamBID
+	case game.TeamBID:
+		return game.TeamAID
+	}
+	return 0
+}
+
+// lookup reads the games and team names of an export once each.
+type lookup struct {
+	repo  postgres.PlayerRepository
+	games map[int]model.Game
+	teams map[int]string
+}
+
+func (l *lookup) game(ctx context.Context, id int) (model.Game, error) {
+	if game, ok := l.games[id]; ok {
+		return game, nil
+	}
+	game, err := l.repo.GetGame(ctx, id)
+	if err != nil {
+		return model.Game{}, err
+	}
+	l.games[id] = game
+	return game, nil
+}
+
+// team returns the name of a team, or "" for the 0 ID of an unknown team.
+func (l *lookup) team(ctx context.Context, id int) (string, error) {
+	if id == 0 {
+		return "", nil
+	}
+	if name, ok := l.teams[id]; ok {
+		return name, nil
+	}
+	team, err := l.repo.GetTeam(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	l.teams[id] = team.Name
+	return team.Name, nil
+}
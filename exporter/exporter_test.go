@@ -0,0 +1,163 @@
+package exporter_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+
+	"nba/exporter"
+	"nba/memory"
+	"nba/model"
+)
+
+// newRepository holds two games of season 2024 between the Lakers and the Celtics and
+// one of season 2023, with a Lakers player traded to the Celtics in between.
+func newRepository(t *testing.T) *memory.PlayerRepository {
+	t.Helper()
+	ctx := context.Background()
+	repo := memory.NewPlayerRepository()
+	lakers, _ := repo.SaveTeam(ctx, model.Team{Name: "Lakers"})
+	celtics, _ := repo.SaveTeam(ctx, model.Team{Name: "Celtics"})
+	john, _ := repo.SavePlayer(ctx, model.Player{Name: "John", CurrentTeamID: lakers.Id})
+	paul, _ := repo.SavePlayer(ctx, model.Player{Name: "Paul", CurrentTeamID: celtics.Id})
+	games := make([]model.Game, 3)
+	for i, g := range []model.Game{
+		{Date: time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), SeasonID: 2023},
+		{Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), SeasonID: 2024, OvertimePeriods: 1},
+		{Date: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), SeasonID: 2024},
+	} {
+		g.TeamAID, g.TeamBID, g.League = lakers.Id, celtics.Id, "nba"
+		var err error
+		if games[i], err = repo.SaveGame(ctx, g); err != nil {
+			t.Fatalf("SaveGame: %v", err)
+		}
+	}
+	for _, line := range []model.PlayerGameStats{
+		{PlayerID: john.Id, GameID: games[0].Id, TeamID: lakers.Id, Points: 8, MinutesPlayed: 20},
+		{PlayerID: john.Id, GameID: games[1].Id, TeamID: lakers.Id, Points: 10, Assists: 4, MinutesPlayed: 30.5},
+		{PlayerID: paul.Id, GameID: games[1].Id, TeamID: celtics.Id, Points: 21, Fouls: 3, MinutesPlayed: 36},
+		{PlayerID: john.Id, GameID: games[2].Id, TeamID: celtics.Id, Points: 15, Rebounds: 7, MinutesPlayed: 25},
+		{PlayerID: paul.Id, GameID: games[2].Id, TeamID: celtics.Id, Points: 30, MinutesPlayed: 40},
+	} {
+		if err := repo.LogPlayerGame(ctx, line); err != nil {
+			t.Fatalf("LogPlayerGame: %v", err)
+		}
+	}
+	return repo
+}
+
+func TestRunStatLinesCSV(t *testing.T) {
+	var out bytes.Buffer
+	n, err := exporter.New(newRepository(t)).Run(context.Background(), &out, exporter.Options{
+		Kind:   exporter.StatLines,
+		Format: exporter.CSV,
+		Filter: model.StatLineFilter{Season: 2024, PlayerID: 1},
+	})
+	if err != nil || n != 2 {
+		t.Fatalf("Run = %d, %v, want 2 rows", n, err)
+	}
+	want := `season,date,game_id,league,team_id,team,opponent_id,opponent,player_id,player_name,points,assists,rebounds,steals,blocks,turnovers,fouls,minutes_played,plus_minus
+2024,2024-01-01,2,nba,1,Lakers,2,Celtics,1,John,10,4,0,0,0,0,0,30.5,0
+2024,2024-02-01,3,nba,2,Celtics,1,Lakers,1,John,15,0,7,0,0,0,0,25,0
+`
+	if out.String() != want {
+		t.Errorf("export =\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestRunSeasonAveragesJSONL(t *testing.T) {
+	var out bytes.Buffer
+	_, err := exporter.New(newRepository(t)).Run(context.Background(), &out, exporter.Options{
+		Kind:   exporter.SeasonAverages,
+		Format: exporter.JSONL,
+		Filter: model.StatLineFilter{Season: 2024},
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	var rows []exporter.SeasonAverageRow
+	decoder := json.NewDecoder(&out)
+	for decoder.More() {
+		var row exporter.SeasonAverageRow
+		if err := decoder.Decode(&row); err != nil {
+			t.Fatalf("Decode: %v", err)
+		}
+		rows = append(rows, row)
+	}
+	// John has a row for each team he played for
+	if len(rows) != 3 {
+		t.Fatalf("rows = %+v, want 3", rows)
+	}
+	if rows[0].PlayerName != "John" || rows[0].Team != "Lakers" || rows[0].GamesPlayed != 1 || rows[0].PointsPerGame != 10 {
+		t.Errorf("John on the Lakers = %+v", rows[0])
+	}
+	if rows[1].PlayerName != "John" || rows[1].Team != "Celtics" || rows[1].ReboundsPerGame != 7 {
+		t.Errorf("John on the Celtics = %+v", rows[1])
+	}
+	if rows[2].PlayerName != "Paul" || rows[2].GamesPlayed != 2 || rows[2].PointsPerGame != 25.5 || rows[2].MinutesPlayedPerGame != 38 {
+		t.Errorf("Paul = %+v", rows[2])
+	}
+}
+
+func TestRunBoxScoresParquet(t *testing.T) {
+	var out bytes.Buffer
+	n, err := exporter.New(newRepository(t)).Run(context.Background(), &out, exporter.Options{
+		Kind:   exporter.BoxScores,
+		Format: exporter.Parquet,
+		Filter: model.StatLineFilter{TeamID: 1},
+	})
+	if err != nil || n != 4 {
+		t.Fatalf("Run = %d, %v, want a row per team of the 2 games the Lakers played", n, err)
+	}
+	rows, err := parquet.Read[exporter.BoxScoreRow](bytes.NewReader(out.Bytes()), int64(out.Len()))
+	if err != nil {
+		t.Fatalf("parquet.Read: %v", err)
+	}
+	want := []exporter.BoxScoreRow{
+		{Season: 2023, Date: "2023-03-01", GameID: 1, League: "nba", TeamID: 1, Team: "Lakers", OpponentID: 2, Opponent: "Celtics", Players: 1, Points: 8, MinutesPlayed: 20},
+		{Season: 2023, Date: "2023-03-01", GameID: 1, League: "nba", TeamID: 2, Team: "Celtics", OpponentID: 1, Opponent: "Lakers", OpponentPoints: 8},
+		{Season: 2024, Date: "2024-01-01", GameID: 2, League: "nba", OvertimePeriods: 1, TeamID: 1, Team: "Lakers", OpponentID: 2, Opponent: "Celtics", Players: 1, Points: 10, OpponentPoints: 21, Assists: 4, MinutesPlayed: 30.5},
+		{Season: 2024, Date: "2024-01-01", GameID: 2, League: "nba", OvertimePeriods: 1, TeamID: 2, Team: "Celtics", OpponentID: 1, Opponent: "Lakers", Players: 1, Points: 21, OpponentPoints: 10, Fouls: 3, MinutesPlayed: 36},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("rows = %+v, want %+v", rows, want)
+	}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf("row %d = %+v, want %+v", i, rows[i], want[i])
+		}
+	}
+}
+
+func TestRunEmptyExportHasHeader(t *testing.T) {
+	var out bytes.Buffer
+	n, err := exporter.New(newRepository(t)).Run(context.Background(), &out, exporter.Options{
+		Kind:   exporter.SeasonAverages,
+		Format: exporter.CSV,
+		Filter: model.StatLineFilter{Season: 1999},
+	})
+	if err != nil || n != 0 || !strings.HasPrefix(out.String(), "season,player_id,player_name,") || strings.Count(out.String(), "\n") != 1 {
+		t.Errorf("Run = %d, %v, %q, want only the header", n, err, out.String())
+	}
+}
+
+func TestRunRejectsInvalidOptions(t *testing.T) {
+	ex := exporter.New(newRepository(t))
+	for _, opts := range []exporter.Options{
+		{Kind: exporter.StatLines, Format: exporter.CSV},
+		{Kind: "plays", Format: exporter.CSV, Filter: model.StatLineFilter{Season: 2024}},
+		{Kind: exporter.StatLines, Format: "xlsx", Filter: model.StatLineFilter{Season: 2024}},
+		{Kind: exporter.StatLines, Format: exporter.CSV, Filter: model.StatLineFilter{TeamID: -1}},
+	} {
+		var out bytes.Buffer
+		if _, err := ex.Run(context.Background(), &out, opts); !errors.Is(err, exporter.ErrInvalidOptions) || out.Len() != 0 {
+			t.Errorf("Run(%+v) = %v, wrote %d bytes, want ErrInvalidOptions", opts, err, out.Len())
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package exporter
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// writeRows encodes rows to w in format. The columns are the fields of T, named by their
+// json tags.
+func writeRows[T any](w io.Writer, format Format, rows []T) error {
+	switch format {
+	case CSV:
+		cw := csv.NewWriter(w)
+		header := columns[T]()
+		if err := cw.Write(header); err != nil {
+			return err
+		}
+		record := make([]string, 0, len(header))
+		for _, row := range rows {
+			record = csvRecord(record[:0], reflect.ValueOf(row))
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	case JSONL:
+		encoder := json.NewEncoder(w)
+		for _, row := range rows {
+			if err := encoder.Encode(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	case Parquet:
+		pw := parquet.NewGenericWriter[T](w)
+		if _, err := pw.Write(rows); err != nil {
+			return err
+		}
+		return pw.Close()
+	}
+	return fmt.Errorf("unknown export format %q", format)
+}
+
+// columns returns the names of the columns of rows of type T.
+func columns[T any]() []string {
+	t := reflect.TypeFor[T]()
+	names := make([]string, t.NumField())
+	for i := range names {
+		names[i], _, _ = strings.Cut(t.Field(i).Tag.Get("json"), ",")
+	}
+	return names
+}
+
+// csvRecord appends the fields of row to record as CSV values.
+func csvRecord(record []string, row reflect.Value) []string {
+	for i := range row.NumField() {
+		switch field := row.Field(i); field.Kind() {
+		case reflect.Int:
+			record = append(record, strconv.FormatInt(field.Int(), 10))
+		case reflect.Float32:
+			record = append(record, strconv.FormatFloat(field.Float(), 'f', -1, 32))
+		default:
+			record = append(record, field.String())
+		}
+	}
+	return record
+}
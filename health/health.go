@@ -0,0 +1,202 @@
+// Package health reports whether the service can take traffic, over the gRPC health
+// checking protocol and over HTTP. Readiness is derived from periodic dependency checks.
+package health
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"nba/postgres"
+)
+
+// Check verifies a dependency. It returns an error if the dependency is unusable.
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Checker runs the checks periodically and publishes the outcome as the serving status
+// of each service. The services are NOT_SERVING until the first round of checks passes.
+type Checker struct {
+	logger   *zap.SugaredLogger
+	server   *grpchealth.Server
+	services []string
+	interval time.Duration
+	timeout  time.Duration
+
+	mu       sync.Mutex
+	checks   []namedCheck
+	results  map[string]string
+	ready    bool
+	draining bool
+}
+
+// NewChecker creates a checker for the named gRPC services. Each round of checks runs
+// every interval and each check may take up to timeout.
+func NewChecker(logger *zap.SugaredLogger, interval, timeout time.Duration, services ...string) *Checker {
+	c := &Checker{
+		logger:   logger,
+		server:   grpchealth.NewServer(),
+		services: services,
+		interval: interval,
+		timeout:  timeout,
+		results:  make(map[string]string),
+	}
+	c.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	return c
+}
+
+// AddCheck adds a check that every service depends on.
+func (c *Checker) AddCheck(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+// Server returns the grpc.health.v1.Health implementation.
+func (c *Checker) Server() healthpb.HealthServer {
+	return c.server
+}
+
+// Run checks immediately and then every interval, until ctx is done.
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		c.CheckNow(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckNow runs every check once and updates the serving status.
+func (c *Checker) CheckNow(ctx context.Context) {
+	c.mu.Lock()
+	checks := c.checks
+	c.mu.Unlock()
+
+	results := make(map[string]string, len(checks))
+	ready := true
+	for _, nc := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+		err := nc.check(checkCtx)
+		cancel()
+		results[nc.name] = "ok"
+		if err != nil {
+			results[nc.name] = err.Error()
+			ready = false
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.draining {
+		return
+	}
+	if ready != c.ready {
+		c.logger.Infow("Readiness changed", "ready", ready, "checks", results)
+	}
+	c.results, c.ready = results, ready
+	if ready {
+		c.setStatus(healthpb.HealthCheckResponse_SERVING)
+	} else {
+		c.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+}
+
+// Drain marks the services NOT_SERVING for good, so load balancers stop routing new
+// traffic while in-flight requests finish.
+func (c *Checker) Drain() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.draining, c.ready = true, false
+	c.server.Shutdown()
+}
+
+func (c *Checker) setStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	// The empty service name is the status of the server as a whole
+	c.server.SetServingStatus("", status)
+	for _, service := range c.services {
+		c.server.SetServingStatus(service, status)
+	}
+}
+
+// readiness is the body of the readiness endpoint.
+type readiness struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// LivenessHandler reports that the process is up and serving HTTP. It does not look at
+// dependencies, so an orchestrator never restarts the service because the database is down.
+func (c *Checker) LivenessHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, readiness{Status: "ok"})
+	}
+}
+
+// ReadinessHandler reports whether the service should receive traffic, with the result
+// of every check. It fails with 503 until the checks pass and while draining.
+func (c *Checker) ReadinessHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		c.mu.Lock()
+		body := readiness{Status: "ready", Checks: c.results}
+		code := http.StatusOK
+		switch {
+		case c.draining:
+			body.Status, code = "draining", http.StatusServiceUnavailable
+		case !c.ready:
+			body.Status, code = "not ready", http.StatusServiceUnavailable
+		}
+		c.mu.Unlock()
+		writeJSON(w, code, body)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(body)
+}
+
+// Ping checks that the database accepts connections.
+func Ping(db *sql.DB) Check {
+	return db.PingContext
+}
+
+// Migrations checks that every migration has been applied to the database.
+func Migrations(db *sql.DB, migrations []postgres.Migration) Check {
+	versions := make([]int, len(migrations))
+	for i, m := range migrations {
+		versions[i] = m.Version
+	}
+	sort.Ints(versions)
+	return func(ctx context.Context) error {
+		if len(versions) == 0 {
+			return nil
+		}
+		version, err := postgres.SchemaVersion(ctx, db)
+		if err != nil {
+			return err
+		}
+		if want := versions[len(versions)-1]; version < want {
+			return fmt.Errorf("schema version is %d, want %d", version, want)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,121 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"nba/health"
+	"nba/sqlite"
+)
+
+const service = "pb.PlayerGameService"
+
+func servingStatus(t *testing.T, c *health.Checker, name string) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+	resp, err := c.Server().Check(context.Background(), &healthpb.HealthCheckRequest{Service: name})
+	if err != nil {
+		t.Fatalf("Check(%q) error = %v", name, err)
+	}
+	return resp.Status
+}
+
+func readiness(t *testing.T, c *health.Checker) (int, map[string]any) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	c.ReadinessHandler()(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	var body map[string]any
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode readiness: %v", err)
+	}
+	return rec.Code, body
+}
+
+func TestChecker(t *testing.T) {
+	c := health.NewChecker(zap.NewNop().Sugar(), time.Minute, time.Second, service)
+	var dbErr error
+	c.AddCheck("database", func(ctx context.Context) error { return dbErr })
+
+	// Nothing is served before the first round of checks
+	if got := servingStatus(t, c, service); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("status before checks = %v", got)
+	}
+	if code, _ := readiness(t, c); code != http.StatusServiceUnavailable {
+		t.Fatalf("readiness before checks = %d", code)
+	}
+
+	c.CheckNow(context.Background())
+	for _, name := range []string{"", service} {
+		if got := servingStatus(t, c, name); got != healthpb.HealthCheckResponse_SERVING {
+			t.Fatalf("status of %q = %v, want SERVING", name, got)
+		}
+	}
+	if code, body := readiness(t, c); code != http.StatusOK || body["status"] != "ready" {
+		t.Fatalf("readiness = %d %v", code, body)
+	}
+
+	// A failing dependency takes the service out of rotation
+	dbErr = errors.New("connection refused")
+	c.CheckNow(context.Background())
+	if got := servingStatus(t, c, service); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("status with database down = %v", got)
+	}
+	code, body := readiness(t, c)
+	checks, _ := body["checks"].(map[string]any)
+	if code != http.StatusServiceUnavailable || checks["database"] != "connection refused" {
+		t.Fatalf("readiness with database down = %d %v", code, body)
+	}
+
+	// Draining sticks even when the checks pass again
+	dbErr = nil
+	c.CheckNow(context.Background())
+	c.Drain()
+	c.CheckNow(context.Background())
+	if got := servingStatus(t, c, service); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("status while draining = %v", got)
+	}
+	if code, body := readiness(t, c); code != http.StatusServiceUnavailable || body["status"] != "draining" {
+		t.Fatalf("readiness while draining = %d %v", code, body)
+	}
+
+	// Liveness does not depend on the checks
+	rec := httptest.NewRecorder()
+	c.LivenessHandler()(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("liveness = %d", rec.Code)
+	}
+}
+
+func TestDatabaseChecks(t *testing.T) {
+	ctx := context.Background()
+	db, err := sqlite.Open(t.TempDir() + "/nba.db")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer db.Close()
+
+	if err := health.Ping(db)(ctx); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+
+	migrations := sqlite.Migrations
+	if err := sqlite.Migrate(ctx, db); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if err := health.Migrations(db, migrations)(ctx); err != nil {
+		t.Fatalf("Migrations() error = %v after migrating", err)
+	}
+	// A newer migration that has not been applied yet fails the check
+	pending := append(migrations[:len(migrations):len(migrations)], migrations[0])
+	pending[len(pending)-1].Version = migrations[len(migrations)-1].Version + 1
+	if err := health.Migrations(db, pending)(ctx); err == nil {
+		t.Fatal("Migrations() passed with a pending migration")
+	}
+}
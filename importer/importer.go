@@ -0,0 +1,499 @@
+// Package importer loads historical box scores from CSV or JSON Lines, one stat line per
+// record, creating the teams, players and games the records name.
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"nba/model"
+	"nba/postgres"
+	"nba/validation"
+)
+
+// DefaultBatchSize is how many records are committed together unless Options.BatchSize says otherwise.
+const DefaultBatchSize = 500
+
+// ErrInvalidOptions is returned when the options of an import cannot be used.
+var ErrInvalidOptions = errors.New("invalid import options")
+
+// Options configures an import.
+type Options struct {
+	Format Format
+	// Columns maps fields to the columns holding them; other fields are read from the
+	// column of their own name.
+	Columns map[string]string
+	// Season and League are used for records without one. League defaults to
+	// validation.DefaultLeague.
+	Season int
+	League string
+	// DryRun resolves and validates every record without writing anything.
+	DryRun bool
+	// ResumeAfter skips the records up to and including this one, which an earlier run
+	// committed; it is the Checkpoint of that run.
+	ResumeAfter int
+	// BatchSize is how many records are committed together, DefaultBatchSize if 0.
+	BatchSize int
+	// OnReject, if set, is called with the rejected records of every committed batch.
+	OnReject func(Reject) error
+	// OnCheckpoint, if set, is called with the last record of every committed batch.
+	// Dry runs commit nothing and never call it.
+	OnCheckpoint func(record int) error
+}
+
+// Reject is a record that was not imported.
+type Reject struct {
+	// Record numbers the records of the input from 1, leaving out the CSV header.
+	Record int
+	// Raw is the record as it appeared in the input.
+	Raw    string
+	Reason string
+	// Err is why the record was rejected, a *validation.Error if its stat line broke
+	// the rules of its league.
+	Err error
+}
+
+// Report sums up an import. In a dry run the counts are what the import would do.
+type Report struct {
+	DryRun bool
+	// Records counts every record read, including the skipped ones.
+	Records        int
+	Skipped        int
+	Imported       int
+	Rejected       int
+	TeamsCreated   int
+	PlayersCreated int
+	GamesCreated   int
+	// Checkpoint is the last record committed. Passed as Options.ResumeAfter, it carries
+	// on an import that failed after it.
+	Checkpoint int
+}
+
+// Importer writes imported stat lines to a repository, holding them to the rules of
+// their league.
+type Importer struct {
+	repo  postgres.PlayerRepository
+	rules *validation.Registry
+}
+
+// New creates an importer writing to repo.
+func New(repo postgres.PlayerRepository, rules *validation.Registry) *Importer {
+	return &Importer{repo: repo, rules: rules}
+}
+
+// numbered is a record with its number.
+type numbered struct {
+	number int
+	record
+}
+
+// Run imports the records of r. Records that cannot be imported are rejected and the
+// import goes on; a failure to read the input or to write a batch stops it, keeping the
+// batches committed before. Each batch is written in one unit of work.
+func (im *Importer) Run(ctx context.Context, r io.Reader, opts Options) (Report, error) {
+	for field := range opts.Columns {
+		if !slices.Contains(Fields, field) {
+			return Report{}, fmt.Errorf("%w: unknown field %q", ErrInvalidOptions, field)
+		}
+	}
+	if opts.Format != CSV && opts.Format != JSONL {
+		return Report{}, fmt.Errorf("%w: unknown format %q, want csv or jsonl", ErrInvalidOptions, opts.Format)
+	}
+	if opts.Season < 0 || opts.ResumeAfter < 0 || opts.BatchSize < 0 {
+		return Report{}, fmt.Errorf("%w: season, resume point and batch size must not be negative", ErrInvalidOptions)
+	}
+	if opts.BatchSize == 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+	if opts.League == "" {
+		opts.League = validation.DefaultLeague
+	}
+	records, err := newRecordReader(r, opts.Format)
+	if err != nil {
+		return Report{}, err
+	}
+
+	report := Report{DryRun: opts.DryRun}
+	if !opts.DryRun {
+		report.Checkpoint = opts.ResumeAfter
+	}
+	res := newResolver(opts.DryRun)
+	var batch []numbered
+	for {
+		rec, err := records.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return report, fmt.Errorf("failed to read record %d: %w", report.Records+1, err)
+		}
+		report.Records++
+		if report.Records <= opts.ResumeAfter {
+			report.Skipped++
+			continue
+		}
+		batch = append(batch, numbered{report.Records, rec})
+		if len(batch) == opts.BatchSize {
+			if err := im.importBatch(ctx, batch, opts, res, &report); err != nil {
+				return report, err
+			}
+			batch = batch[:0]
+		}
+	}
+	if len(batch) > 0 {
+		if err := im.importBatch(ctx, batch, opts, res, &report); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+// batchResult is what a batch did, counted once it commits.
+type batchResult struct {
+	imported, teams, players, games int
+	rejects                         []Reject
+}
+
+func (im *Importer) importBatch(ctx context.Context, batch []numbered, opts Options, res *resolver, report *Report) error {
+	var out batchResult
+	write := func(repo postgres.PlayerRepository) error {
+		// A retried unit of work starts over
+		out = batchResult{}
+		res.rollback()
+		for _, rec := range batch {
+			reject, err := im.importRecord(ctx, repo, res, rec.record, opts, &out)
+			if err != nil {
+				return fmt.Errorf("failed to import record %d: %w", rec.number, err)
+			}
+			if reject != nil {
+				out.rejects = append(out.rejects, Reject{Record: rec.number, Raw: rec.raw, Reason: reject.Error(), Err: reject})
+			}
+		}
+		return nil
+	}
+
+	var err error
+	if opts.DryRun {
+		err = write(im.repo)
+	} else {
+		err = im.repo.WithTx(ctx, func(repos postgres.Repositories) error { return write(repos.Players) })
+	}
+	if err != nil {
+		res.rollback()
+		return err
+	}
+	res.commit()
+
+	report.Imported += out.imported
+	report.Rejected += len(out.rejects)
+	report.TeamsCreated += out.teams
+	report.PlayersCreated += out.players
+	report.GamesCreated += out.games
+	if opts.OnReject != nil {
+		for _, reject := range out.rejects {
+			if err := opts.OnReject(reject); err != nil {
+				return err
+			}
+		}
+	}
+	if opts.DryRun {
+		return nil
+	}
+	report.Checkpoint = batch[len(batch)-1].number
+	if opts.OnCheckpoint != nil {
+		return opts.OnCheckpoint(report.Checkpoint)
+	}
+	return nil
+}
+
+// importRecord imports one record, returning why it was rejected, if it was. Other errors
+// are failures of the repository, which stop the import.
+func (im *Importer) importRecord(ctx context.Context, repo postgres.PlayerRepository, res *resolver, rec record, opts Options, out *batchResult) (reject error, err error) {
+	if rec.err != nil {
+		return rec.err, nil
+	}
+	r, err := parseRow(rec.values, opts.Columns, opts)
+	if err != nil {
+		return err, nil
+	}
+
+	// Look the player up before creating anything, so a rejected record leaves nothing behind
+	playerID, reason, err := res.findPlayer(ctx, repo, r)
+	if err != nil {
+		return nil, err
+	}
+	if reason != "" {
+		return errors.New(reason), nil
+	}
+	line := model.PlayerGameStats{
+		Points:        r.points,
+		Assists:       r.assists,
+		Rebounds:      r.rebounds,
+		Steals:        r.steals,
+		Blocks:        r.blocks,
+		Turnovers:     r.turnovers,
+		Fouls:         r.fouls,
+		MinutesPlayed: r.minutes,
+	}
+	game, err := res.findGame(ctx, repo, r)
+	if err != nil {
+		return nil, err
+	}
+	gameContext := validation.GameContext{League: r.league, OvertimePeriods: r.overtimes}
+	if game.Id != 0 {
+		gameContext = validation.GameContext{League: game.League, OvertimePeriods: game.OvertimePeriods}
+	}
+	if err := im.rules.For(gameContext.League).Validate(line, gameContext); err != nil {
+		return err, nil
+	}
+
+	teamID, err := res.team(ctx, repo, r.team, &out.teams)
+	if err != nil {
+		return nil, err
+	}
+	opponentID, err := res.team(ctx, repo, r.opponent, &out.teams)
+	if err != nil {
+		return nil, err
+	}
+	if game.Id == 0 {
+		if game, err = res.createGame(ctx, repo, r, teamID, opponentID, &out.games); err != nil {
+			return nil, err
+		}
+	}
+	if playerID == 0 {
+		if playerID, err = res.createPlayer(ctx, repo, r, teamID, &out.players); err != nil {
+			return nil, err
+		}
+	}
+
+	line.PlayerID, line.GameID, line.TeamID = playerID, game.Id, teamID
+	if !res.dryRun {
+		if err := repo.UpsertPlayerGame(ctx, line); err != nil {
+			return nil, err
+		}
+	}
+	out.imported++
+	return nil, nil
+}
+
+// resolver finds and creates the teams, players and games records name, remembering
+// them across batches. Entries of the running batch are pending until it commits, so a
+// batch that rolls back forgets the IDs it created. In dry runs nothing is created; new
+// entities get negative IDs instead.
+type resolver struct {
+	dryRun  bool
+	nextID  int
+	teams   cache[int]
+	players cache[int]
+	games   cache[model.Game]
+}
+
+func newResolver(dryRun bool) *resolver {
+	return &resolver{dryRun: dryRun, teams: newCache[int](), players: newCache[int](), games: newCache[model.Game]()}
+}
+
+func (res *resolver) commit() {
+	res.teams.commit()
+	res.players.commit()
+	res.games.commit()
+}
+
+func (res *resolver) rollback() {
+	res.teams.rollback()
+	res.players.rollback()
+	res.games.rollback()
+}
+
+// placeholderID returns the next negative ID of a dry run.
+func (res *resolver) placeholderID() int {
+	res.nextID--
+	return res.nextID
+}
+
+// team returns the ID of the team with the name, creating it if there is none.
+func (res *resolver) team(ctx context.Context, repo postgres.PlayerRepository, name string, created *int) (int, error) {
+	if id, ok := res.teams.get(name); ok {
+		return id, nil
+	}
+	team, err := repo.GetTeamByName(ctx, name)
+	if err != nil {
+		return 0, err
+	}
+	if team.Id == 0 {
+		if res.dryRun {
+			team.Id = res.placeholderID()
+		} else if team, err = repo.SaveTeam(ctx, model.Team{Name: name}); err != nil {
+			return 0, err
+		}
+		*created++
+	}
+	res.teams.set(name, team.Id)
+	return team.Id, nil
+}
+
+// findPlayer returns the ID of the player of a record, 0 for a new player, or why the
+// record is rejected. Players are found by external ID if the record has one,
+// otherwise by name.
+func (res *resolver) findPlayer(ctx context.Context, repo postgres.PlayerRepository, r row) (int, string, error) {
+	key := playerKey(r)
+	if id, ok := res.players.get(key); ok {
+		return id, "", nil
+	}
+	var player model.Player
+	if r.playerID != "" {
+		var err error
+		if player, err = repo.GetPlayerByExternalID(ctx, r.playerID); err != nil {
+			return 0, "", err
+		}
+		if player.Id == 0 && r.playerName == "" {
+			return 0, fmt.Sprintf("unknown player %q needs a %s to be created", r.playerID, FieldPlayerName), nil
+		}
+	} else {
+		players, err := repo.GetPlayersByName(ctx, r.playerName)
+		if err != nil {
+			return 0, "", err
+		}
+		if len(players) > 1 {
+			return 0, fmt.Sprintf("%d players are named %q; give the %s", len(players), r.playerName, FieldPlayerID), nil
+		}
+		if len(players) == 1 {
+			player = players[0]
+		}
+	}
+	if player.Id != 0 {
+		res.players.set(key, player.Id)
+	}
+	return player.Id, "", nil
+}
+
+func (res *resolver) createPlayer(ctx context.Context, repo postgres.PlayerRepository, r row, teamID int, created *int) (int, error) {
+	id := res.placeholderID()
+	if !res.dryRun {
+		player, err := repo.SavePlayer(ctx, model.Player{Name: r.playerName, CurrentTeamID: teamID, ExternalID: r.playerID})
+		if err != nil {
+			return 0, err
+		}
+		id = player.Id
+	}
+	*created++
+	res.players.set(playerKey(r), id)
+	return id, nil
+}
+
+func playerKey(r row) string {
+	if r.playerID != "" {
+		return "id:" + r.playerID
+	}
+	return "name:" + r.playerName
+}
+
+// findGame returns the game of a record, or the zero game if the teams did not play
+// on its date yet.
+func (res *resolver) findGame(ctx context.Context, repo postgres.PlayerRepository, r row) (model.Game, error) {
+	key := gameKey(r.date, r.team, r.opponent)
+	if game, ok := res.games.get(key); ok {
+		return game, nil
+	}
+	teamID, teamFound := res.teams.get(r.team)
+	opponentID, opponentFound := res.teams.get(r.opponent)
+	if !teamFound || !opponentFound {
+		var err error
+		if teamID, opponentID, err = res.findTeams(ctx, repo, r); err != nil {
+			return model.Game{}, err
+		}
+	}
+	// Teams a dry run would create have played no games
+	if teamID <= 0 || opponentID <= 0 {
+		return model.Game{}, nil
+	}
+	game, err := repo.GetGameByTeamsOnDate(ctx, r.date, teamID, opponentID)
+	if err != nil || game.Id == 0 {
+		return model.Game{}, err
+	}
+	res.games.set(key, game)
+	return game, nil
+}
+
+// findTeams looks up the teams of a record without creating them.
+func (res *resolver) findTeams(ctx context.Context, repo postgres.PlayerRepository, r row) (int, int, error) {
+	var ids [2]int
+	for i, name := range []string{r.team, r.opponent} {
+		if id, ok := res.teams.get(name); ok {
+			ids[i] = id
+			continue
+		}
+		team, err := repo.GetTeamByName(ctx, name)
+		if err != nil {
+			return 0, 0, err
+		}
+		ids[i] = team.Id
+	}
+	return ids[0], ids[1], nil
+}
+
+func (res *resolver) createGame(ctx context.Context, repo postgres.PlayerRepository, r row, teamID, opponentID int, created *int) (model.Game, error) {
+	game := model.Game{
+		Date:            r.date,
+		SeasonID:        r.season,
+		TeamAID:         teamID,
+		TeamBID:         opponentID,
+		League:          r.league,
+		OvertimePeriods: r.overtimes,
+	}
+	if res.dryRun {
+		game.Id = res.placeholderID()
+	} else {
+		var err error
+		if game, err = repo.SaveGame(ctx, game); err != nil {
+			return model.Game{}, err
+		}
+	}
+	*created++
+	res.games.set(gameKey(r.date, r.team, r.opponent), game)
+	return game, nil
+}
+
+// gameKey is the same for both teams of a game.
+func gameKey(date time.Time, team, opponent string) string {
+	if opponent < team {
+		team, opponent = opponent, team
+	}
+	return fmt.Sprintf("%s|%q|%q", date.Format(time.DateOnly), team, opponent)
+}
+
+// cache holds committed entries and those of the running batch.
+type cache[V any] struct {
+	committed map[string]V
+	pending   map[string]V
+}
+
+func newCache[V any]() cache[V] {
+	return cache[V]{committed: make(map[string]V), pending: make(map[string]V)}
+}
+
+func (c *cache[V]) get(key string) (V, bool) {
+	if v, ok := c.pending[key]; ok {
+		return v, true
+	}
+	v, ok := c.committed[key]
+	return v, ok
+}
+
+func (c *cache[V]) set(key string, v V) {
+	c.pending[key] = v
+}
+
+func (c *cache[V]) commit() {
+	for key, v := range c.pending {
+		c.committed[key] = v
+	}
+	clear(c.pending)
+}
+
+func (c *cache[V]) rollback() {
+	clear(c.pending)
+}
@@ -0,0 +1,189 @@
+package importer_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"nba/importer"
+	"nba/memory"
+	"nba/model"
+	"nba/validation"
+)
+
+func newImporter(t *testing.T) (*importer.Importer, *memory.PlayerRepository) {
+	t.Helper()
+	rules, err := validation.NewRegistry("")
+	if err != nil {
+		t.Fatalf("NewRegistry: %v", err)
+	}
+	repo := memory.NewPlayerRepository()
+	return importer.New(repo, rules), repo
+}
+
+const boxScores = `Date,Tm,Opp,Player,PTS,AST,TRB,PF,MP
+2001-01-02,Lakers,Celtics,Shaq,30,2,14,3,41:30
+2001-01-02,Celtics,Lakers,Pierce,25,5,6,2,40
+2001-01-02,Lakers,Celtics,Kobe,28,6,5,7,44
+2001-01-03,Lakers,Kings,Shaq,not a number,0,0,0,30
+2001-01-03,Lakers,Kings,Shaq,20,1,10,2,35
+`
+
+var columns = map[string]string{
+	importer.FieldDate:       "Date",
+	importer.FieldTeam:       "Tm",
+	importer.FieldOpponent:   "Opp",
+	importer.FieldPlayerName: "Player",
+	importer.FieldPoints:     "PTS",
+	importer.FieldAssists:    "AST",
+	importer.FieldRebounds:   "TRB",
+	importer.FieldFouls:      "PF",
+	importer.FieldMinutes:    "MP",
+}
+
+func TestRunCSV(t *testing.T) {
+	im, repo := newImporter(t)
+	ctx := context.Background()
+	if _, err := repo.SaveTeam(ctx, model.Team{Name: "Lakers"}); err != nil {
+		t.Fatalf("SaveTeam: %v", err)
+	}
+
+	var rejects []importer.Reject
+	report, err := im.Run(ctx, strings.NewReader(boxScores), importer.Options{
+		Format:   importer.CSV,
+		Columns:  columns,
+		Season:   2001,
+		OnReject: func(r importer.Reject) error { rejects = append(rejects, r); return nil },
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	want := importer.Report{Records: 5, Imported: 3, Rejected: 2, TeamsCreated: 2, PlayersCreated: 2, GamesCreated: 2, Checkpoint: 5}
+	if report != want {
+		t.Errorf("report = %+v, want %+v", report, want)
+	}
+	// Kobe fouled out one foul too many; the second Shaq line has no points
+	if len(rejects) != 2 || rejects[0].Record != 3 || !strings.Contains(rejects[0].Reason, "fouls must be at most 6") ||
+		rejects[1].Record != 4 || rejects[1].Raw != "2001-01-03,Lakers,Kings,Shaq,not a number,0,0,0,30" {
+		t.Errorf("rejects = %+v", rejects)
+	}
+
+	lakers, _ := repo.GetTeamByName(ctx, "Lakers")
+	celtics, _ := repo.GetTeamByName(ctx, "Celtics")
+	game, err := repo.GetGameByTeamsOnDate(ctx, mustDate(t, "2001-01-02"), celtics.Id, lakers.Id)
+	if err != nil || game.SeasonID != 2001 || game.League != validation.DefaultLeague {
+		t.Fatalf("game = %+v, %v", game, err)
+	}
+	lines, err := repo.GetGameStats(ctx, game.Id)
+	if err != nil || len(lines) != 2 {
+		t.Fatalf("GetGameStats = %+v, %v, want the lines of Shaq and Pierce", lines, err)
+	}
+	if lines[0].PlayerName != "Shaq" || lines[0].TeamID != lakers.Id || lines[0].MinutesPlayed != 41.5 || lines[0].Rebounds != 14 {
+		t.Errorf("Shaq's line = %+v", lines[0])
+	}
+	if lines[1].PlayerName != "Pierce" || lines[1].TeamID != celtics.Id {
+		t.Errorf("Pierce's line = %+v", lines[1])
+	}
+}
+
+func TestRunDryRunWritesNothing(t *testing.T) {
+	im, repo := newImporter(t)
+	ctx := context.Background()
+
+	report, err := im.Run(ctx, strings.NewReader(boxScores), importer.Options{Format: importer.CSV, Columns: columns, Season: 2001, DryRun: true})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	want := importer.Report{DryRun: true, Records: 5, Imported: 3, Rejected: 2, TeamsCreated: 3, PlayersCreated: 2, GamesCreated: 2}
+	if report != want {
+		t.Errorf("report = %+v, want %+v", report, want)
+	}
+	if team, _ := repo.GetTeamByName(ctx, "Lakers"); team.Id != 0 {
+		t.Errorf("dry run created team %+v", team)
+	}
+}
+
+func TestRunResumesAfterCheckpoint(t *testing.T) {
+	im, repo := newImporter(t)
+	ctx := context.Background()
+	errStop := errors.New("stopped")
+	opts := importer.Options{
+		Format:    importer.CSV,
+		Columns:   columns,
+		Season:    2001,
+		BatchSize: 2,
+		OnCheckpoint: func(record int) error {
+			if record >= 2 {
+				return errStop
+			}
+			return nil
+		},
+	}
+
+	report, err := im.Run(ctx, strings.NewReader(boxScores), opts)
+	if !errors.Is(err, errStop) || report.Checkpoint != 2 {
+		t.Fatalf("Run = %+v, %v, want to stop at checkpoint 2", report, err)
+	}
+
+	opts.ResumeAfter, opts.OnCheckpoint = report.Checkpoint, nil
+	report, err = im.Run(ctx, strings.NewReader(boxScores), opts)
+	if err != nil {
+		t.Fatalf("resumed Run: %v", err)
+	}
+	want := importer.Report{Records: 5, Skipped: 2, Imported: 1, Rejected: 2, TeamsCreated: 1, PlayersCreated: 0, GamesCreated: 1, Checkpoint: 5}
+	if report != want {
+		t.Errorf("resumed report = %+v, want %+v", report, want)
+	}
+	if players, _ := repo.GetPlayersByName(ctx, "Shaq"); len(players) != 1 {
+		t.Errorf("players named Shaq = %+v, want one", players)
+	}
+}
+
+func TestRunJSONLResolvesExternalIDs(t *testing.T) {
+	im, repo := newImporter(t)
+	ctx := context.Background()
+
+	input := `{"season": 1997, "date": "1997-03-01", "league": "nba", "team": "Bulls", "opponent": "Knicks", "player_id": "jordami01", "player_name": "Michael Jordan", "points": 35}
+
+{"season": 1997, "date": "1997-03-05", "team": "Bulls", "opponent": "Heat", "player_id": "jordami01", "player_name": "M. Jordan", "points": 29, "minutes_played": 38.5}
+{"season": 1997, "date": "1997-03-05", "team": "Heat", "opponent": "Bulls", "player_id": "mournal01", "points": 20}
+{"season": 1997, "date": "1997-03-05", "team": "Heat", "opponent": "Bulls", "player_name": "Alonzo Mourning", "points": [20]}
+not json
+`
+	report, err := im.Run(ctx, strings.NewReader(input), importer.Options{Format: importer.JSONL})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	want := importer.Report{Records: 5, Imported: 2, Rejected: 3, TeamsCreated: 3, PlayersCreated: 1, GamesCreated: 2, Checkpoint: 5}
+	if report != want {
+		t.Errorf("report = %+v, want %+v", report, want)
+	}
+	player, err := repo.GetPlayerByExternalID(ctx, "jordami01")
+	if err != nil || player.Name != "Michael Jordan" {
+		t.Fatalf("GetPlayerByExternalID = %+v, %v", player, err)
+	}
+	bulls, _ := repo.GetTeamByName(ctx, "Bulls")
+	lines, err := repo.GetTeamPlayersBySeason(ctx, bulls.Id, 1997)
+	if err != nil || len(lines) != 2 || lines[0].PlayerID != player.Id || lines[1].PlayerID != player.Id || lines[1].MinutesPlayed != 38.5 {
+		t.Errorf("GetTeamPlayersBySeason = %+v, %v, want both games of player %d", lines, err, player.Id)
+	}
+}
+
+func TestRunRejectsUnknownFields(t *testing.T) {
+	im, _ := newImporter(t)
+	_, err := im.Run(context.Background(), strings.NewReader(""), importer.Options{Format: importer.CSV, Columns: map[string]string{"pts": "PTS"}})
+	if !errors.Is(err, importer.ErrInvalidOptions) {
+		t.Fatalf("Run = %v, want ErrInvalidOptions", err)
+	}
+}
+
+func mustDate(t *testing.T, s string) time.Time {
+	t.Helper()
+	d, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
@@ -0,0 +1,287 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format is the encoding of the records to import.
+type Format string
+
+const (
+	// CSV holds one record per row after a header row naming the columns.
+	CSV Format = "csv"
+	// JSONL holds one JSON object per line, keyed by column.
+	JSONL Format = "jsonl"
+)
+
+// ParseFormat returns the format named by s, such as "csv" or "jsonl".
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case CSV, JSONL:
+		return f, nil
+	case "ndjson":
+		return JSONL, nil
+	}
+	return "", fmt.Errorf("unknown import format %q, want csv or jsonl", s)
+}
+
+// The fields of a record. Each is read from the column of the same name unless
+// Options.Columns maps it to another one.
+const (
+	FieldSeason     = "season"
+	FieldDate       = "date"
+	FieldLeague     = "league"
+	FieldOvertimes  = "overtime_periods"
+	FieldTeam       = "team"
+	FieldOpponent   = "opponent"
+	FieldPlayerID   = "player_id"
+	FieldPlayerName = "player_name"
+	FieldPoints     = "points"
+	FieldAssists    = "assists"
+	FieldRebounds   = "rebounds"
+	FieldSteals     = "steals"
+	FieldBlocks     = "blocks"
+	FieldTurnovers  = "turnovers"
+	FieldFouls      = "fouls"
+	FieldMinutes    = "minutes_played"
+)
+
+// Fields lists every field a record may hold.
+var Fields = []string{
+	FieldSeason, FieldDate, FieldLeague, FieldOvertimes, FieldTeam, FieldOpponent, FieldPlayerID, FieldPlayerName,
+	FieldPoints, FieldAssists, FieldRebounds, FieldSteals, FieldBlocks, FieldTurnovers, FieldFouls, FieldMinutes,
+}
+
+// record is one input record: its values by column, or why it could not be read.
+type record struct {
+	values map[string]string
+	// raw is the record as it appeared in the input, without the line break
+	raw string
+	err error
+}
+
+// recordReader reads records one at a time; it returns io.EOF after the last one.
+type recordReader interface {
+	next() (record, error)
+}
+
+func newRecordReader(r io.Reader, format Format) (recordReader, error) {
+	switch format {
+	case CSV:
+		cr := csv.NewReader(r)
+		cr.FieldsPerRecord = -1
+		cr.ReuseRecord = true
+		header, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return &csvReader{r: cr}, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the CSV header: %w", err)
+		}
+		columns := make([]string, len(header))
+		for i, column := range header {
+			columns[i] = strings.TrimSpace(strings.TrimPrefix(column, "\ufeff"))
+		}
+		return &csvReader{r: cr, columns: columns}, nil
+	case JSONL:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		return &jsonlReader{scanner: scanner}, nil
+	}
+	return nil, fmt.Errorf("unknown import format %q, want csv or jsonl", format)
+}
+
+type csvReader struct {
+	r       *csv.Reader
+	columns []string
+}
+
+func (c *csvReader) next() (record, error) {
+	for {
+		row, err := c.r.Read()
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return record{err: fmt.Errorf("line %d: %w", parseErr.Line, parseErr.Err)}, nil
+		}
+		if err != nil {
+			return record{}, err
+		}
+		if len(row) == 1 && strings.TrimSpace(row[0]) == "" {
+			continue
+		}
+		rec := record{raw: csvLine(row), values: make(map[string]string, len(row))}
+		if len(row) != len(c.columns) {
+			rec.err = fmt.Errorf("has %d columns, the header has %d", len(row), len(c.columns))
+			return rec, nil
+		}
+		for i, value := range row {
+			rec.values[c.columns[i]] = strings.TrimSpace(value)
+		}
+		return rec, nil
+	}
+}
+
+// csvLine encodes a row back into a CSV line.
+func csvLine(row []string) string {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write(row)
+	w.Flush()
+	return strings.TrimRight(buf.String(), "\r\n")
+}
+
+type jsonlReader struct {
+	scanner *bufio.Scanner
+}
+
+func (j *jsonlReader) next() (record, error) {
+	for j.scanner.Scan() {
+		line := strings.TrimSpace(j.scanner.Text())
+		if line == "" {
+			continue
+		}
+		rec := record{raw: line}
+		var object map[string]any
+		decoder := json.NewDecoder(strings.NewReader(line))
+		decoder.UseNumber()
+		if err := decoder.Decode(&object); err != nil {
+			rec.err = fmt.Errorf("is not a JSON object: %w", err)
+			return rec, nil
+		}
+		rec.values = make(map[string]string, len(object))
+		for column, value := range object {
+			switch v := value.(type) {
+			case nil:
+			case string:
+				rec.values[column] = strings.TrimSpace(v)
+			case json.Number, bool:
+				rec.values[column] = fmt.Sprint(v)
+			default:
+				rec.err = fmt.Errorf("column %q is not a string or a number", column)
+				return rec, nil
+			}
+		}
+		return rec, nil
+	}
+	if err := j.scanner.Err(); err != nil {
+		return record{}, err
+	}
+	return record{}, io.EOF
+}
+
+// row is a parsed record: one stat line and the game it belongs to.
+type row struct {
+	season     int
+	date       time.Time
+	league     string
+	overtimes  int
+	team       string
+	opponent   string
+	playerID   string
+	playerName string
+	points     int
+	assists    int
+	rebounds   int
+	steals     int
+	blocks     int
+	turnovers  int
+	fouls      int
+	minutes    float32
+}
+
+// parseRow reads the fields of a record through columns, using the season and league
+// of opts for records without them.
+func parseRow(values map[string]string, columns map[string]string, opts Options) (row, error) {
+	get := func(field string) string {
+		if column, ok := columns[field]; ok {
+			return values[column]
+		}
+		return values[field]
+	}
+	var r row
+	var err error
+
+	r.season = opts.Season
+	if s := get(FieldSeason); s != "" {
+		if r.season, err = strconv.Atoi(s); err != nil || r.season <= 0 {
+			return row{}, fmt.Errorf("%s %q is not a positive integer", FieldSeason, s)
+		}
+	}
+	if r.season == 0 {
+		return row{}, fmt.Errorf("%s is missing", FieldSeason)
+	}
+	if r.date, err = time.Parse(time.DateOnly, get(FieldDate)); err != nil {
+		return row{}, fmt.Errorf("%s %q is not a YYYY-MM-DD date", FieldDate, get(FieldDate))
+	}
+	r.league = get(FieldLeague)
+	if r.league == "" {
+		r.league = opts.League
+	}
+	r.team, r.opponent = get(FieldTeam), get(FieldOpponent)
+	if r.team == "" || r.opponent == "" {
+		return row{}, fmt.Errorf("%s and %s are required", FieldTeam, FieldOpponent)
+	}
+	if r.team == r.opponent {
+		return row{}, fmt.Errorf("team %q cannot play itself", r.team)
+	}
+	r.playerID, r.playerName = get(FieldPlayerID), get(FieldPlayerName)
+	if r.playerID == "" && r.playerName == "" {
+		return row{}, fmt.Errorf("%s or %s is required", FieldPlayerID, FieldPlayerName)
+	}
+
+	for field, dst := range map[string]*int{
+		FieldOvertimes: &r.overtimes,
+		FieldPoints:    &r.points,
+		FieldAssists:   &r.assists,
+		FieldRebounds:  &r.rebounds,
+		FieldSteals:    &r.steals,
+		FieldBlocks:    &r.blocks,
+		FieldTurnovers: &r.turnovers,
+		FieldFouls:     &r.fouls,
+	} {
+		s := get(field)
+		if s == "" {
+			continue
+		}
+		if *dst, err = strconv.Atoi(s); err != nil {
+			return row{}, fmt.Errorf("%s %q is not an integer", field, s)
+		}
+	}
+	if r.overtimes < 0 {
+		return row{}, fmt.Errorf("%s must not be negative", FieldOvertimes)
+	}
+	if r.minutes, err = parseMinutes(get(FieldMinutes)); err != nil {
+		return row{}, err
+	}
+	return r, nil
+}
+
+// parseMinutes reads minutes played as a number, like "34.5", or as minutes and seconds,
+// like "34:30", as box scores often print them.
+func parseMinutes(s string) (float32, error) {
+	if s == "" {
+		return 0, nil
+	}
+	if m, sec, ok := strings.Cut(s, ":"); ok {
+		minutes, err1 := strconv.Atoi(m)
+		seconds, err2 := strconv.Atoi(sec)
+		if err1 != nil || err2 != nil || seconds < 0 || seconds >= 60 {
+			return 0, fmt.Errorf("%s %q is not a number or MM:SS", FieldMinutes, s)
+		}
+		return float32(minutes) + float32(seconds)/60, nil
+	}
+	minutes, err := strconv.ParseFloat(s, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%s %q is not a number or MM:SS", FieldMinutes, s)
+	}
+	return float32(minutes), nil
+}
@@ -0,0 +1,121 @@
+// Package lifecycle runs the servers of the process until it is asked to stop,
+// then shuts everything down in order within a drain timeout.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Hook releases a resource at shutdown. It must return once ctx expires.
+type Hook func(ctx context.Context) error
+
+type hook struct {
+	name string
+	fn   Hook
+}
+
+// Manager starts servers and runs the shutdown hooks when the process receives SIGINT or
+// SIGTERM, or when a server fails. A second signal during the drain kills the process.
+type Manager struct {
+	logger       *zap.SugaredLogger
+	drainTimeout time.Duration
+	signals      []os.Signal
+
+	mu       sync.Mutex
+	hooks    []hook
+	draining []func()
+	failed   chan error
+}
+
+// New creates a manager that gives the shutdown hooks drainTimeout to finish.
+func New(logger *zap.SugaredLogger, drainTimeout time.Duration) *Manager {
+	return &Manager{
+		logger:       logger,
+		drainTimeout: drainTimeout,
+		signals:      []os.Signal{syscall.SIGINT, syscall.SIGTERM},
+		failed:       make(chan error, 1),
+	}
+}
+
+// Go runs serve in the background. If serve returns an error, the process shuts down.
+// Servers must return nil once they are stopped by their shutdown hook.
+func (m *Manager) Go(name string, serve func() error) {
+	go func() {
+		if err := serve(); err != nil {
+			select {
+			case m.failed <- fmt.Errorf("%s failed: %w", name, err):
+			default:
+			}
+		}
+	}()
+}
+
+// OnShutdown registers a hook. Hooks run in reverse order of registration, like
+// deferred calls, so servers registered after the database stop before it is closed.
+func (m *Manager) OnShutdown(name string, fn Hook) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hooks = append(m.hooks, hook{name: name, fn: fn})
+}
+
+// OnDrain registers a callback that runs as soon as shutdown starts, before any hook.
+func (m *Manager) OnDrain(fn func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.draining = append(m.draining, fn)
+}
+
+// Run blocks until ctx is done, a signal arrives or a server fails, then shuts down.
+// It returns the server failure, if any, joined with the errors of the hooks.
+func (m *Manager) Run(ctx context.Context) error {
+	ctx, stop := signal.NotifyContext(ctx, m.signals...)
+	var cause error
+	select {
+	case <-ctx.Done():
+		m.logger.Info("Shutting down")
+	case cause = <-m.failed:
+		m.logger.Errorw("Shutting down after a server failed", "error", cause)
+	}
+	// Restore the default signal handling, so a second signal kills the process
+	stop()
+
+	return errors.Join(cause, m.Shutdown())
+}
+
+// Shutdown runs the drain callbacks, then every hook, in reverse order of registration.
+// The hooks share the drain timeout; a failing hook does not stop the others.
+func (m *Manager) Shutdown() error {
+	m.mu.Lock()
+	hooks, draining := m.hooks, m.draining
+	m.hooks, m.draining = nil, nil
+	m.mu.Unlock()
+
+	for _, fn := range draining {
+		fn()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.drainTimeout)
+	defer cancel()
+
+	var errs []error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		h := hooks[i]
+		start := time.Now()
+		if err := h.fn(ctx); err != nil {
+			m.logger.Errorw("Shutdown hook failed", "hook", h.name, "error", err)
+			errs = append(errs, fmt.Errorf("%s: %w", h.name, err))
+			continue
+		}
+		m.logger.Infow("Stopped", "hook", h.name, "duration", time.Since(start))
+	}
+	return errors.Join(errs...)
+}
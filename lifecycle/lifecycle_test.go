@@ -0,0 +1,114 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"reflect"
+	"syscall"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"nba/lifecycle"
+)
+
+func TestShutdownOrder(t *testing.T) {
+	m := lifecycle.New(zap.NewNop().Sugar(), time.Second)
+	var order []string
+	record := func(name string) lifecycle.Hook {
+		return func(ctx context.Context) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+	m.OnShutdown("database", record("database"))
+	m.OnShutdown("grpc", record("grpc"))
+	m.OnShutdown("http", record("http"))
+	m.OnDrain(func() { order = append(order, "drain") })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := m.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	want := []string{"drain", "http", "grpc", "database"}
+	if !reflect.DeepEqual(order, want) {
+		t.Fatalf("shutdown order = %v, want %v", order, want)
+	}
+}
+
+func TestShutdownOnSignal(t *testing.T) {
+	m := lifecycle.New(zap.NewNop().Sugar(), time.Second)
+	stopped := make(chan struct{})
+	m.OnShutdown("server", func(ctx context.Context) error {
+		close(stopped)
+		return nil
+	})
+
+	// Keep SIGTERM from killing the test binary while Run has no handler installed
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+
+	done := make(chan error, 1)
+	go func() { done <- m.Run(context.Background()) }()
+
+	// Signal until Run, which may not have installed its handler yet, returns
+	deadline := time.After(5 * time.Second)
+	for {
+		syscall.Kill(syscall.Getpid(), syscall.SIGTERM)
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			<-stopped
+			return
+		case <-time.After(10 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("Run() did not return after SIGTERM")
+		}
+	}
+}
+
+func TestShutdownOnServerFailure(t *testing.T) {
+	m := lifecycle.New(zap.NewNop().Sugar(), time.Second)
+	errListen := errors.New("address already in use")
+	m.Go("http", func() error { return errListen })
+
+	var hookErr = errors.New("flush failed")
+	var closed bool
+	m.OnShutdown("database", func(ctx context.Context) error {
+		closed = true
+		return nil
+	})
+	m.OnShutdown("batches", func(ctx context.Context) error { return hookErr })
+
+	err := m.Run(context.Background())
+	if !errors.Is(err, errListen) || !errors.Is(err, hookErr) {
+		t.Fatalf("Run() error = %v, want the server failure and the hook error", err)
+	}
+	if !closed {
+		t.Fatal("a failing hook stopped the remaining hooks")
+	}
+}
+
+func TestDrainTimeout(t *testing.T) {
+	m := lifecycle.New(zap.NewNop().Sugar(), 20*time.Millisecond)
+	m.OnShutdown("stuck", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	start := time.Now()
+	err := m.Shutdown()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Shutdown() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Shutdown() took %v, want about the drain timeout", elapsed)
+	}
+}
@@ -0,0 +1,107 @@
+// Package lineups checks stints and adds them up into lineup and two-man combination stats.
+package lineups
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
+	"nba/model"
+	"nba/plays"
+)
+
+// ratingMinutes is the time on the court net ratings are scaled to, a regulation game.
+const ratingMinutes = 48
+
+// ErrInvalidStint is returned for a stint that is malformed or overlaps another one.
+var ErrInvalidStint = errors.New("invalid stint")
+
+// Check validates the stints of one team in one game, given in any order.
+func Check(stints []model.Stint) error {
+	for i, stint := range stints {
+		if stint.Period < 1 {
+			return fmt.Errorf("%w %d: period must be at least 1", ErrInvalidStint, i+1)
+		}
+		if stint.Start < 0 || stint.End < stint.Start {
+			return fmt.Errorf("%w %d: must end after it starts", ErrInvalidStint, i+1)
+		}
+		if len(stint.PlayerIDs) == 0 || len(stint.PlayerIDs) > plays.CourtSize {
+			return fmt.Errorf("%w %d: must have 1 to %d players", ErrInvalidStint, i+1, plays.CourtSize)
+		}
+		for j, id := range stint.PlayerIDs {
+			if id <= 0 {
+				return fmt.Errorf("%w %d: player IDs must be positive integers", ErrInvalidStint, i+1)
+			}
+			if slices.Contains(stint.PlayerIDs[:j], id) {
+				return fmt.Errorf("%w %d: player %d is listed twice", ErrInvalidStint, i+1, id)
+			}
+		}
+		if stint.PointsFor < 0 || stint.PointsAgainst < 0 {
+			return fmt.Errorf("%w %d: points must not be negative", ErrInvalidStint, i+1)
+		}
+	}
+
+	order := make([]int, len(stints))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, func(a, b int) int {
+		return cmp.Or(cmp.Compare(stints[a].Period, stints[b].Period), cmp.Compare(stints[a].Start, stints[b].Start))
+	})
+	for k := 1; k < len(order); k++ {
+		prev, next := stints[order[k-1]], stints[order[k]]
+		if prev.Period == next.Period && next.Start < prev.End {
+			return fmt.Errorf("%w %d: overlaps stint %d", ErrInvalidStint, order[k]+1, order[k-1]+1)
+		}
+	}
+	return nil
+}
+
+// Aggregate adds up stints into the five-man lineups that played them and the two-man
+// combinations within every stint, each ordered by minutes played, most first. Player
+// names are left for the caller to fill in.
+func Aggregate(stints []model.Stint) (lineups []model.LineupStats, pairs []model.LineupStats) {
+	lineupTotals := make(map[string]*model.LineupStats)
+	pairTotals := make(map[string]*model.LineupStats)
+	for _, stint := range stints {
+		players := slices.Sorted(slices.Values(stint.PlayerIDs))
+		if len(players) == plays.CourtSize {
+			add(lineupTotals, players, stint)
+		}
+		for i := range players {
+			for j := i + 1; j < len(players); j++ {
+				add(pairTotals, []int{players[i], players[j]}, stint)
+			}
+		}
+	}
+	return sorted(lineupTotals), sorted(pairTotals)
+}
+
+// add adds a stint to the totals of a group of players, in ascending order.
+func add(totals map[string]*model.LineupStats, players []int, stint model.Stint) {
+	key := fmt.Sprint(players)
+	total, ok := totals[key]
+	if !ok {
+		total = &model.LineupStats{PlayerIDs: players}
+		totals[key] = total
+	}
+	total.Minutes += float32((stint.End - stint.Start).Minutes())
+	total.PointsFor += stint.PointsFor
+	total.PointsAgainst += stint.PointsAgainst
+}
+
+// sorted returns the totals with their net rating, ordered by minutes played.
+func sorted(totals map[string]*model.LineupStats) []model.LineupStats {
+	stats := make([]model.LineupStats, 0, len(totals))
+	for _, total := range totals {
+		if total.Minutes > 0 {
+			total.NetRating = float32(total.PointsFor-total.PointsAgainst) * ratingMinutes / total.Minutes
+		}
+		stats = append(stats, *total)
+	}
+	slices.SortFunc(stats, func(a, b model.LineupStats) int {
+		return cmp.Or(cmp.Compare(b.Minutes, a.Minutes), slices.Compare(a.PlayerIDs, b.PlayerIDs))
+	})
+	return stats
+}
@@ -0,0 +1,86 @@
+package lineups_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"nba/lineups"
+	"nba/model"
+)
+
+func stint(period int, start, end int, players []int, pointsFor, pointsAgainst int) model.Stint {
+	return model.Stint{
+		Period:        period,
+		Start:         time.Duration(start) * time.Minute,
+		End:           time.Duration(end) * time.Minute,
+		PlayerIDs:     players,
+		PointsFor:     pointsFor,
+		PointsAgainst: pointsAgainst,
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	starters := []int{1, 2, 3, 4, 5}
+	bench := []int{1, 2, 3, 4, 6}
+	lineups, pairs := lineups.Aggregate([]model.Stint{
+		stint(1, 0, 8, starters, 20, 12),
+		stint(1, 8, 12, bench, 6, 10),
+		// Starters listed in another order are the same lineup
+		stint(2, 0, 4, []int{5, 4, 3, 2, 1}, 4, 6),
+		// Fewer than five players only count for the pairs
+		stint(2, 4, 6, []int{1, 6}, 2, 0),
+	})
+
+	want := []model.LineupStats{
+		{PlayerIDs: starters, Minutes: 12, PointsFor: 24, PointsAgainst: 18, NetRating: 24},
+		{PlayerIDs: bench, Minutes: 4, PointsFor: 6, PointsAgainst: 10, NetRating: -48},
+	}
+	if !reflect.DeepEqual(lineups, want) {
+		t.Errorf("lineups = %+v, want %+v", lineups, want)
+	}
+
+	// 1 and 2 played every five-man stint; 1 and 6 also played the last one
+	if len(pairs) != 14 {
+		t.Fatalf("got %d pairs, want 14: %+v", len(pairs), pairs)
+	}
+	if want := (model.LineupStats{PlayerIDs: []int{1, 2}, Minutes: 16, PointsFor: 30, PointsAgainst: 28, NetRating: 6}); !reflect.DeepEqual(pairs[0], want) {
+		t.Errorf("top pair = %+v, want %+v", pairs[0], want)
+	}
+	for _, pair := range pairs {
+		if reflect.DeepEqual(pair.PlayerIDs, []int{1, 6}) && (pair.Minutes != 6 || pair.PointsFor != 8 || pair.PointsAgainst != 10) {
+			t.Errorf("pair 1 and 6 = %+v, want 6 minutes, 8 for and 10 against", pair)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	if err := lineups.Check([]model.Stint{
+		stint(1, 6, 12, []int{1, 2}, 0, 0),
+		stint(1, 0, 6, []int{1}, 3, 0),
+		stint(2, 0, 6, []int{1}, 0, 0),
+	}); err != nil {
+		t.Fatalf("Check of valid stints: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		stints []model.Stint
+	}{
+		{"no period", []model.Stint{stint(0, 0, 1, []int{1}, 0, 0)}},
+		{"ends before it starts", []model.Stint{stint(1, 5, 4, []int{1}, 0, 0)}},
+		{"no players", []model.Stint{stint(1, 0, 1, nil, 0, 0)}},
+		{"too many players", []model.Stint{stint(1, 0, 1, []int{1, 2, 3, 4, 5, 6}, 0, 0)}},
+		{"player twice", []model.Stint{stint(1, 0, 1, []int{1, 1}, 0, 0)}},
+		{"negative points", []model.Stint{stint(1, 0, 1, []int{1}, -2, 0)}},
+		{"overlap", []model.Stint{stint(1, 0, 6, []int{1}, 0, 0), stint(1, 5, 12, []int{2}, 0, 0)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := lineups.Check(tt.stints); !errors.Is(err, lineups.ErrInvalidStint) {
+				t.Fatalf("Check = %v, want ErrInvalidStint", err)
+			}
+		})
+	}
+}
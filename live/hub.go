@@ -0,0 +1,151 @@
+// Package live pushes the changes to games to the clients watching them as they are
+// committed, through an in-process publish/subscribe hub.
+package live
+
+import (
+	"errors"
+	"sync"
+
+	"nba/model"
+)
+
+// Buffer is how many events a subscription holds for a slow reader before it is dropped.
+const Buffer = 64
+
+var (
+	// ErrFellBehind ends a subscription whose reader did not keep up with the events.
+	ErrFellBehind = errors.New("subscriber fell behind the events")
+	// ErrClosed ends the subscriptions of a closed hub.
+	ErrClosed = errors.New("event hub is closed")
+)
+
+type topic struct {
+	kind string
+	id   int
+}
+
+// Hub delivers every published event to the subscriptions on its game and, for stat
+// lines, on its player. Publishing never blocks: subscriptions that fall behind are
+// ended with ErrFellBehind.
+type Hub struct {
+	mu     sync.Mutex
+	subs   map[topic]map[*Subscription]struct{}
+	closed bool
+}
+
+// NewHub returns a hub without subscriptions.
+func NewHub() *Hub {
+	return &Hub{subs: make(map[topic]map[*Subscription]struct{})}
+}
+
+// WatchGame subscribes to the events of a game.
+func (h *Hub) WatchGame(gameID int) *Subscription {
+	return h.subscribe(topic{"game", gameID})
+}
+
+// WatchPlayer subscribes to the stat lines of a player.
+func (h *Hub) WatchPlayer(playerID int) *Subscription {
+	return h.subscribe(topic{"player", playerID})
+}
+
+func (h *Hub) subscribe(t topic) *Subscription {
+	s := &Subscription{hub: h, topic: t, events: make(chan model.GameEvent, Buffer)}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		s.end(ErrClosed)
+		return s
+	}
+	if h.subs[t] == nil {
+		h.subs[t] = make(map[*Subscription]struct{})
+	}
+	h.subs[t][s] = struct{}{}
+	return s
+}
+
+// Watched reports whether anyone watches the game or the player, so publishers can
+// skip the work of building events nobody receives.
+func (h *Hub) Watched(gameID, playerID int) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subs[topic{"game", gameID}]) > 0 || len(h.subs[topic{"player", playerID}]) > 0
+}
+
+// Publish delivers event to the watchers of its game and, for stat lines, its player.
+func (h *Hub) Publish(event model.GameEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.deliver(topic{"game", event.GameID}, event)
+	if event.Kind == model.StatLineLogged || event.Kind == model.StatLineCorrected {
+		h.deliver(topic{"player", event.Line.PlayerID}, event)
+	}
+}
+
+func (h *Hub) deliver(t topic, event model.GameEvent) {
+	for s := range h.subs[t] {
+		select {
+		case s.events <- event:
+		default:
+			h.remove(s)
+			s.end(ErrFellBehind)
+		}
+	}
+}
+
+func (h *Hub) remove(s *Subscription) {
+	delete(h.subs[s.topic], s)
+	if len(h.subs[s.topic]) == 0 {
+		delete(h.subs, s.topic)
+	}
+}
+
+// Close ends every subscription with ErrClosed, so streams finish before shutdown.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for t, subs := range h.subs {
+		for s := range subs {
+			s.end(ErrClosed)
+		}
+		delete(h.subs, t)
+	}
+}
+
+// Subscription receives the events of one game or player.
+type Subscription struct {
+	hub    *Hub
+	topic  topic
+	events chan model.GameEvent
+	err    error
+}
+
+// Events returns the events in the order they were published. The channel is closed
+// when the subscription ends; Err then tells why.
+func (s *Subscription) Events() <-chan model.GameEvent {
+	return s.events
+}
+
+// Err returns why the subscription ended, once Events is closed: ErrFellBehind,
+// ErrClosed, or nil after Close.
+func (s *Subscription) Err() error {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	return s.err
+}
+
+// Close unsubscribes. Events already delivered stay readable.
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	if _, ok := s.hub.subs[s.topic][s]; ok {
+		s.hub.remove(s)
+		s.end(nil)
+	}
+}
+
+// end closes the events of s; the hub lock must be held.
+func (s *Subscription) end(err error) {
+	s.err = err
+	close(s.events)
+}
@@ -0,0 +1,94 @@
+package live_test
+
+import (
+	"errors"
+	"testing"
+
+	"nba/live"
+	"nba/model"
+)
+
+func line(gameID, playerID, points int) model.GameEvent {
+	return model.GameEvent{
+		Kind:   model.StatLineLogged,
+		GameID: gameID,
+		Line:   model.PlayerGameStats{GameID: gameID, PlayerID: playerID, Points: points},
+	}
+}
+
+// drain reads the events already delivered to s.
+func drain(s *live.Subscription) []model.GameEvent {
+	var events []model.GameEvent
+	for {
+		select {
+		case event, ok := <-s.Events():
+			if !ok {
+				return events
+			}
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestHub(t *testing.T) {
+	hub := live.NewHub()
+	if hub.Watched(1, 7) {
+		t.Fatal("Watched without subscriptions = true")
+	}
+	game := hub.WatchGame(1)
+	player := hub.WatchPlayer(7)
+	if !hub.Watched(1, 0) || !hub.Watched(0, 7) || hub.Watched(2, 8) {
+		t.Fatal("Watched does not match the subscriptions")
+	}
+
+	hub.Publish(line(1, 7, 10))
+	hub.Publish(line(1, 8, 4))
+	hub.Publish(line(2, 7, 12))
+	hub.Publish(model.GameEvent{Kind: model.ScoreChanged, GameID: 1, Score: model.GameScore{TeamAID: 1, TeamAPoints: 14}})
+
+	if events := drain(game); len(events) != 3 || events[0].Line.PlayerID != 7 || events[1].Line.PlayerID != 8 || events[2].Kind != model.ScoreChanged {
+		t.Fatalf("game events = %+v, want both lines of game 1 and its score", events)
+	}
+	if events := drain(player); len(events) != 2 || events[0].GameID != 1 || events[1].GameID != 2 {
+		t.Fatalf("player events = %+v, want the player's lines in both games", events)
+	}
+
+	game.Close()
+	if _, ok := <-game.Events(); ok || game.Err() != nil {
+		t.Fatalf("closed subscription still open or ended with %v", game.Err())
+	}
+	if hub.Watched(1, 0) {
+		t.Fatal("Watched after Close = true")
+	}
+}
+
+func TestHubDropsSlowSubscribers(t *testing.T) {
+	hub := live.NewHub()
+	slow := hub.WatchGame(1)
+	for i := 0; i <= live.Buffer; i++ {
+		hub.Publish(line(1, 7, i))
+	}
+	if events := drain(slow); len(events) != live.Buffer {
+		t.Fatalf("slow subscriber got %d events, want the %d buffered", len(events), live.Buffer)
+	}
+	if !errors.Is(slow.Err(), live.ErrFellBehind) {
+		t.Fatalf("Err = %v, want ErrFellBehind", slow.Err())
+	}
+	slow.Close()
+}
+
+func TestHubClose(t *testing.T) {
+	hub := live.NewHub()
+	before := hub.WatchPlayer(7)
+	hub.Close()
+	after := hub.WatchGame(1)
+	for _, s := range []*live.Subscription{before, after} {
+		if _, ok := <-s.Events(); ok || !errors.Is(s.Err(), live.ErrClosed) {
+			t.Fatalf("subscription of a closed hub ended with %v, want ErrClosed", s.Err())
+		}
+		s.Close()
+	}
+	hub.Publish(line(1, 7, 10))
+}
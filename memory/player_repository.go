@@ -0,0 +1,710 @@
+// Package memory is an in-memory PlayerRepository for tests and demo mode.
+// It has the same semantics as the Postgres repository but keeps nothing on restart.
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"nba/model"
+	"nba/postgres"
+)
+
+type statKey struct {
+	playerID int
+	gameID   int
+}
+
+type playKey struct {
+	gameID   int
+	sequence int
+}
+
+type stintKey struct {
+	gameID int
+	teamID int
+}
+
+type rosterKey struct {
+	playerID  int
+	teamID    int
+	startDate time.Time
+}
+
+// data is one consistent version of the store.
+type data struct {
+	teams       map[int]model.Team
+	players     map[int]model.Player
+	games       map[int]model.Game
+	stats       map[statKey]model.PlayerGameStats
+	plays       map[playKey]model.Play
+	stints      map[stintKey][]model.Stint
+	roster      map[rosterKey]model.RosterEntry
+	idempotency map[string]model.IdempotencyKey
+	apiKeys     map[int]model.APIKey
+	nextID      map[string]int
+}
+
+func newData() *data {
+	return &data{
+		teams:       make(map[int]model.Team),
+		players:     make(map[int]model.Player),
+		games:       make(map[int]model.Game),
+		stats:       make(map[statKey]model.PlayerGameStats),
+		plays:       make(map[playKey]model.Play),
+		stints:      make(map[stintKey][]model.Stint),
+		roster:      make(map[rosterKey]model.RosterEntry),
+		idempotency: make(map[string]model.IdempotencyKey),
+		apiKeys:     make(map[int]model.APIKey),
+		nextID:      map[string]int{"team": 1, "player": 1, "game": 1, "api_key": 1},
+	}
+}
+
+func (d *data) clone() *data {
+	return &data{
+		teams:       cloneMap(d.teams),
+		players:     cloneMap(d.players),
+		games:       cloneMap(d.games),
+		stats:       cloneMap(d.stats),
+		plays:       cloneMap(d.plays),
+		stints:      cloneMap(d.stints),
+		roster:      cloneMap(d.roster),
+		idempotency: cloneMap(d.idempotency),
+		apiKeys:     cloneMap(d.apiKeys),
+		nextID:      cloneMap(d.nextID),
+	}
+}
+
+func cloneMap[K comparable, V any](m map[K]V) map[K]V {
+	c := make(map[K]V, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+// assignID returns id, or the next free ID of the table if id is 0.
+func (d *data) assignID(table string, id int) int {
+	if id == 0 {
+		id = d.nextID[table]
+	}
+	if id >= d.nextID[table] {
+		d.nextID[table] = id + 1
+	}
+	return id
+}
+
+// store is shared by the repository and every unit of work started from it.
+type store struct {
+	mu      sync.RWMutex // guards current
+	txMu    sync.Mutex   // serializes writers, so units of work never conflict
+	current *data
+}
+
+// PlayerRepository is a thread-safe, in-memory postgres.PlayerRepository.
+type PlayerRepository struct {
+	store *store
+	// tx is the private copy a unit of work writes to, nil outside WithTx
+	tx *data
+}
+
+// NewPlayerRepository returns an empty repository.
+func NewPlayerRepository() *PlayerRepository {
+	return &PlayerRepository{store: &store{current: newData()}}
+}
+
+// read runs fn against a consistent version of the data.
+func (r *PlayerRepository) read(ctx context.Context, fn func(d *data) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if r.tx != nil {
+		return fn(r.tx)
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return fn(r.store.current)
+}
+
+// write runs fn against the data, or against the unit of work's copy inside WithTx.
+// Outside a unit of work the change is applied to a copy and published only if fn succeeds.
+func (r *PlayerRepository) write(ctx context.Context, fn func(d *data) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if r.tx != nil {
+		return fn(r.tx)
+	}
+	r.store.txMu.Lock()
+	defer r.store.txMu.Unlock()
+	return r.commit(fn)
+}
+
+func (r *PlayerRepository) commit(fn func(d *data) error) error {
+	r.store.mu.RLock()
+	next := r.store.current.clone()
+	r.store.mu.RUnlock()
+
+	if err := fn(next); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	r.store.current = next
+	r.store.mu.Unlock()
+	return nil
+}
+
+// WithTx implements postgres.PlayerRepository. Units of work run one at a time against a
+// private copy of the data, which replaces the shared data only if fn succeeds.
+// Options are accepted for compatibility; every unit of work is serializable.
+func (r *PlayerRepository) WithTx(ctx context.Context, fn func(repos postgres.Repositories) error, opts ...postgres.TxOption) error {
+	if r.tx != nil {
+		return fn(postgres.Repositories{Players: r})
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.txMu.Lock()
+	defer r.store.txMu.Unlock()
+	return r.commit(func(d *data) error {
+		return fn(postgres.Repositories{Players: &PlayerRepository{store: r.store, tx: d}})
+	})
+}
+
+// GetTeam implements postgres.PlayerRepository.
+func (r *PlayerRepository) GetTeam(ctx context.Context, teamId int) (model.Team, error) {
+	var team model.Team
+	err := r.read(ctx, func(d *data) error {
+		team = d.teams[teamId]
+		return nil
+	})
+	return team, err
+}
+
+// GetGame implements postgres.PlayerRepository.
+func (r *PlayerRepository) GetGame(ctx context.Context, gameId int) (model.Game, error) {
+	var game model.Game
+	err := r.read(ctx, func(d *data) error {
+		game = d.games[gameId]
+		return nil
+	})
+	return game, err
+}
+
+// GetPlayer implements postgres.PlayerRepository.
+func (r *PlayerRepository) GetPlayer(ctx context.Context, playerId int) (model.Player, error) {
+	var player model.Player
+	err := r.read(ctx, func(d *data) error {
+		player = d.players[playerId]
+		return nil
+	})
+	return player, err
+}
+
+// GetTeamByName implements postgres.PlayerRepository.
+func (r *PlayerRepository) GetTeamByName(ctx context.Context, name string) (model.Team, error) {
+	var team model.Team
+	err := r.read(ctx, func(d *data) error {
+		for _, existing := range d.teams {
+			if existing.Name == name {
+				team = existing
+			}
+		}
+		return nil
+	})
+	return team, err
+}
+
+// GetPlayerByExternalID implements postgres.PlayerRepository.
+func (r *PlayerRepository) GetPlayerByExternalID(ctx context.Context, externalID string) (model.Player, error) {
+	var player model.Player
+	err := r.read(ctx, func(d *data) error {
+		for _, existing := range d.players {
+			if existing.ExternalID == externalID {
+				player = existing
+			}
+		}
+		return nil
+	})
+	return player, err
+}
+
+// GetPlayersByName implements postgres.PlayerRepository.
+func (r *PlayerRepository) GetPlayersByName(ctx context.Context, name string) ([]model.Player, error) {
+	var players []model.Player
+	err := r.read(ctx, func(d *data) error {
+		for _, existing := range d.players {
+			if existing.Name == name {
+				players = append(players, existing)
+			}
+		}
+		return nil
+	})
+	slices.SortFunc(players, func(a, b model.Player) int { return cmp.Compare(a.Id, b.Id) })
+	return players, err
+}
+
+// GetGameByTeamsOnDate implements postgres.PlayerRepository.
+func (r *PlayerRepository) GetGameByTeamsOnDate(ctx context.Context, date time.Time, teamID int, opponentID int) (model.Game, error) {
+	var game model.Game
+	date = dateOnly(date)
+	err := r.read(ctx, func(d *data) error {
+		for _, existing := range d.games {
+			teams := []int{existing.TeamAID, existing.TeamBID}
+			if !existing.Date.Equal(date) || !slices.Equal(teams, []int{teamID, opponentID}) && !slices.Equal(teams, []int{opponentID, teamID}) {
+				continue
+			}
+			if game.Id == 0 || existing.Id < game.Id {
+				game = existing
+			}
+		}
+		return nil
+	})
+	return game, err
+}
+
+// GetPlayerTeamOnDate implements postgres.PlayerRepository.
+func (r *PlayerRepository) GetPlayerTeamOnDate(ctx context.Context, playerId int, date time.Time) (int, error) {
+	var teamID int
+	date = dateOnly(date)
+	err := r.read(ctx, func(d *data) error {
+		var latest time.Time
+		for _, entry := range d.roster {
+			if entry.PlayerID != playerId || entry.StartDate.After(date) {
+				continue
+			}
+			if entry.EndDate != nil && entry.EndDate.Before(date) {
+				continue
+			}
+			if teamID == 0 || entry.StartDate.After(latest) {
+				teamID, latest = entry.TeamID, entry.StartDate
+			}
+		}
+		return nil
+	})
+	return teamID, err
+}
+
+// GetGameStats implements postgres.PlayerRepository.
+func (r *PlayerRepository) GetGameStats(ctx context.Context, gameId int) ([]model.PlayerGameStats, error) {
+	return r.selectStats(ctx, func(d *data, s model.PlayerGameStats) bool {
+		return s.GameID == gameId
+	})
+}
+
+// GetTeamPlayersBySeason implements postgres.PlayerRepository.
+func (r *PlayerRepository) GetTeamPlayersBySeason(ctx context.Context, teamID int, season int) ([]model.PlayerGameStats, error) {
+	return r.selectStats(ctx, func(d *data, s model.PlayerGameStats) bool {
+		return s.TeamID == teamID && d.games[s.GameID].SeasonID == season
+	})
+}
+
+// GetPlayerGamesBySeason implements postgres.PlayerRepository.
+func (r *PlayerRepository) GetPlayerGamesBySeason(ctx context.Context, playerID int, season int) ([]model.PlayerGameStats, error) {
+	return r.selectStats(ctx, func(d *data, s model.PlayerGameStats) bool {
+		return s.PlayerID == playerID && d.games[s.GameID].SeasonID == season
+	})
+}
+
+// GetStatLines implements postgres.PlayerRepository.
+func (r *PlayerRepository) GetStatLines(ctx context.Context, filter model.StatLineFilter) ([]model.PlayerGameStats, error) {
+	return r.selectStats(ctx, func(d *data, s model.PlayerGameStats) bool {
+		return (filter.Season == 0 || d.games[s.GameID].SeasonID == filter.Season) &&
+			(filter.TeamID == 0 || s.TeamID == filter.TeamID) &&
+			(filter.PlayerID == 0 || s.PlayerID == filter.PlayerID)
+	})
+}
+
+// selectStats returns the matching stat lines with player names, ordered by game and player.
+func (r *PlayerRepository) selectStats(ctx context.Context, match func(d *data, s model.PlayerGameStats) bool) ([]model.PlayerGameStats, error) {
+	var statsList []model.PlayerGameStats
+	err := r.read(ctx, func(d *data) error {
+		for _, stats := range d.stats {
+			if !match(d, stats) {
+				continue
+			}
+			player, ok := d.players[stats.PlayerID]
+			if !ok {
+				continue
+			}
+			stats.PlayerName = player.Name
+			stats.PlusMinus = d.plusMinus(stats.GameID, stats.PlayerID)
+			statsList = append(statsList, stats)
+		}
+		return nil
+	})
+	slices.SortFunc(statsList, func(a, b model.PlayerGameStats) int {
+		return cmp.Or(cmp.Compare(a.GameID, b.GameID), cmp.Compare(a.PlayerID, b.PlayerID))
+	})
+	return statsList, err
+}
+
+// plusMinus adds up the stints of a player in a game.
+func (d *data) plusMinus(gameId int, playerId int) int {
+	plusMinus := 0
+	for key, stints := range d.stints {
+		if key.gameID != gameId {
+			continue
+		}
+		for _, stint := range stints {
+			if slices.Contains(stint.PlayerIDs, playerId) {
+				plusMinus += stint.PointsFor - stint.PointsAgainst
+			}
+		}
+	}
+	return plusMinus
+}
+
+// checkStatLine enforces the foreign keys of a stat line.
+func (d *data) checkStatLine(game model.PlayerGameStats) error {
+	if _, ok := d.players[game.PlayerID]; !ok {
+		return fmt.Errorf("failed to save stat line: player %d does not exist", game.PlayerID)
+	}
+	if _, ok := d.games[game.GameID]; !ok {
+		return fmt.Errorf("failed to save stat line: game %d does not exist", game.GameID)
+	}
+	return nil
+}
+
+// LogPlayerGame implements postgres.PlayerRepository.
+func (r *PlayerRepository) LogPlayerGame(ctx context.Context, game model.PlayerGameStats) error {
+	return r.write(ctx, func(d *data) error {
+		if err := d.checkStatLine(game); err != nil {
+			return err
+		}
+		key := statKey{game.PlayerID, game.GameID}
+		if _, ok := d.stats[key]; ok {
+			return fmt.Errorf("stat line for player %d in game %d: %w", game.PlayerID, game.GameID, postgres.ErrDuplicate)
+		}
+		game.PlayerName, game.PlusMinus = "", 0
+		d.stats[key] = game
+		return nil
+	})
+}
+
+// UpsertPlayerGame implements postgres.PlayerRepository.
+func (r *PlayerRepository) UpsertPlayerGame(ctx context.Context, game model.PlayerGameStats) error {
+	return r.write(ctx, func(d *data) error {
+		if err := d.checkStatLine(game); err != nil {
+			return err
+		}
+		game.PlayerName, game.PlusMinus = "", 0
+		d.stats[statKey{game.PlayerID, game.GameID}] = game
+		return nil
+	})
+}
+
+// DeletePlayerGame implements postgres.PlayerRepository.
+func (r *PlayerRepository) DeletePlayerGame(ctx context.Context, gameId int, playerId int) error {
+	return r.write(ctx, func(d *data) error {
+		delete(d.stats, statKey{playerId, gameId})
+		return nil
+	})
+}
+
+// GetPlays implements postgres.PlayerRepository.
+func (r *PlayerRepository) GetPlays(ctx context.Context, gameId int) ([]model.Play, error) {
+	var plays []model.Play
+	err := r.read(ctx, func(d *data) error {
+		for key, play := range d.plays {
+			if key.gameID == gameId {
+				plays = append(plays, play)
+			}
+		}
+		return nil
+	})
+	slices.SortFunc(plays, func(a, b model.Play) int { return cmp.Compare(a.Sequence, b.Sequence) })
+	return plays, err
+}
+
+// checkPlay enforces the foreign keys of a play.
+func (d *data) checkPlay(play model.Play) error {
+	if _, ok := d.games[play.GameID]; !ok {
+		return fmt.Errorf("failed to save play: game %d does not exist", play.GameID)
+	}
+	for _, id := range []int{play.PlayerID, play.ReplacedPlayerID} {
+		if _, ok := d.players[id]; id != 0 && !ok {
+			return fmt.Errorf("failed to save play: player %d does not exist", id)
+		}
+	}
+	return nil
+}
+
+// LogPlay implements postgres.PlayerRepository.
+func (r *PlayerRepository) LogPlay(ctx context.Context, play model.Play) error {
+	return r.write(ctx, func(d *data) error {
+		if err := d.checkPlay(play); err != nil {
+			return err
+		}
+		key := playKey{play.GameID, play.Sequence}
+		if _, ok := d.plays[key]; ok {
+			return fmt.Errorf("play %d of game %d: %w", play.Sequence, play.GameID, postgres.ErrDuplicate)
+		}
+		d.plays[key] = play
+		return nil
+	})
+}
+
+// UpsertPlay implements postgres.PlayerRepository.
+func (r *PlayerRepository) UpsertPlay(ctx context.Context, play model.Play) error {
+	return r.write(ctx, func(d *data) error {
+		if err := d.checkPlay(play); err != nil {
+			return err
+		}
+		d.plays[playKey{play.GameID, play.Sequence}] = play
+		return nil
+	})
+}
+
+// DeletePlay implements postgres.PlayerRepository.
+func (r *PlayerRepository) DeletePlay(ctx context.Context, gameId int, sequence int) error {
+	return r.write(ctx, func(d *data) error {
+		delete(d.plays, playKey{gameId, sequence})
+		return nil
+	})
+}
+
+// ReplaceStints implements postgres.PlayerRepository.
+func (r *PlayerRepository) ReplaceStints(ctx context.Context, gameId int, teamId int, stints []model.Stint) error {
+	return r.write(ctx, func(d *data) error {
+		if _, ok := d.games[gameId]; !ok {
+			return fmt.Errorf("failed to replace stints: game %d does not exist", gameId)
+		}
+		if _, ok := d.teams[teamId]; !ok {
+			return fmt.Errorf("failed to replace stints: team %d does not exist", teamId)
+		}
+		stored := make([]model.Stint, len(stints))
+		for i, stint := range stints {
+			for _, id := range stint.PlayerIDs {
+				if _, ok := d.players[id]; !ok {
+					return fmt.Errorf("failed to replace stints: player %d does not exist", id)
+				}
+			}
+			stint.GameID, stint.TeamID = gameId, teamId
+			stint.PlayerIDs = slices.Sorted(slices.Values(stint.PlayerIDs))
+			stored[i] = stint
+		}
+		key := stintKey{gameId, teamId}
+		if len(stored) == 0 {
+			delete(d.stints, key)
+			return nil
+		}
+		d.stints[key] = stored
+		return nil
+	})
+}
+
+// GetStints implements postgres.PlayerRepository.
+func (r *PlayerRepository) GetStints(ctx context.Context, gameId int) ([]model.Stint, error) {
+	return r.selectStints(ctx, func(d *data, key stintKey) bool { return key.gameID == gameId })
+}
+
+// GetTeamStintsBySeason implements postgres.PlayerRepository.
+func (r *PlayerRepository) GetTeamStintsBySeason(ctx context.Context, teamID int, season int) ([]model.Stint, error) {
+	return r.selectStints(ctx, func(d *data, key stintKey) bool {
+		return key.teamID == teamID && d.games[key.gameID].SeasonID == season
+	})
+}
+
+// selectStints returns the stints of the matching games and teams, ordered by game,
+// team and the order they were stored in.
+func (r *PlayerRepository) selectStints(ctx context.Context, match func(d *data, key stintKey) bool) ([]model.Stint, error) {
+	var keys []stintKey
+	var stints []model.Stint
+	err := r.read(ctx, func(d *data) error {
+		for key := range d.stints {
+			if match(d, key) {
+				keys = append(keys, key)
+			}
+		}
+		slices.SortFunc(keys, func(a, b stintKey) int {
+			return cmp.Or(cmp.Compare(a.gameID, b.gameID), cmp.Compare(a.teamID, b.teamID))
+		})
+		for _, key := range keys {
+			for _, stint := range d.stints[key] {
+				stint.PlayerIDs = slices.Clone(stint.PlayerIDs)
+				stints = append(stints, stint)
+			}
+		}
+		return nil
+	})
+	return stints, err
+}
+
+// GetIdempotencyKey implements postgres.PlayerRepository.
+func (r *PlayerRepository) GetIdempotencyKey(ctx context.Context, key string) (model.IdempotencyKey, error) {
+	var record model.IdempotencyKey
+	err := r.read(ctx, func(d *data) error {
+		record = d.idempotency[key]
+		return nil
+	})
+	return record, err
+}
+
+// SaveIdempotencyKey implements postgres.PlayerRepository.
+func (r *PlayerRepository) SaveIdempotencyKey(ctx context.Context, record model.IdempotencyKey) error {
+	return r.write(ctx, func(d *data) error {
+		if _, ok := d.idempotency[record.Key]; ok {
+			return fmt.Errorf("idempotency key %q: %w", record.Key, postgres.ErrDuplicate)
+		}
+		d.idempotency[record.Key] = record
+		return nil
+	})
+}
+
+// SaveAPIKey implements postgres.PlayerRepository.
+func (r *PlayerRepository) SaveAPIKey(ctx context.Context, key model.APIKey) (model.APIKey, error) {
+	err := r.write(ctx, func(d *data) error {
+		for _, existing := range d.apiKeys {
+			if existing.Hash == key.Hash {
+				return fmt.Errorf("api key %s: %w", key.Prefix, postgres.ErrDuplicate)
+			}
+		}
+		key.ID = d.assignID("api_key", 0)
+		d.apiKeys[key.ID] = key
+		return nil
+	})
+	if err != nil {
+		return model.APIKey{}, err
+	}
+	return key, nil
+}
+
+// GetAPIKey implements postgres.PlayerRepository.
+func (r *PlayerRepository) GetAPIKey(ctx context.Context, id int) (model.APIKey, error) {
+	var key model.APIKey
+	err := r.read(ctx, func(d *data) error {
+		key = d.apiKeys[id]
+		return nil
+	})
+	return key, err
+}
+
+// GetAPIKeyByHash implements postgres.PlayerRepository.
+func (r *PlayerRepository) GetAPIKeyByHash(ctx context.Context, hash string) (model.APIKey, error) {
+	var key model.APIKey
+	err := r.read(ctx, func(d *data) error {
+		for _, existing := range d.apiKeys {
+			if existing.Hash == hash {
+				key = existing
+			}
+		}
+		return nil
+	})
+	return key, err
+}
+
+// ListAPIKeys implements postgres.PlayerRepository.
+func (r *PlayerRepository) ListAPIKeys(ctx context.Context) ([]model.APIKey, error) {
+	var keys []model.APIKey
+	err := r.read(ctx, func(d *data) error {
+		for _, key := range d.apiKeys {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	slices.SortFunc(keys, func(a, b model.APIKey) int { return cmp.Compare(a.ID, b.ID) })
+	return keys, err
+}
+
+// RevokeAPIKey implements postgres.PlayerRepository.
+func (r *PlayerRepository) RevokeAPIKey(ctx context.Context, id int, revokedAt time.Time) (model.APIKey, error) {
+	var key model.APIKey
+	err := r.write(ctx, func(d *data) error {
+		existing, ok := d.apiKeys[id]
+		if !ok {
+			return nil
+		}
+		if existing.RevokedAt.IsZero() {
+			existing.RevokedAt = revokedAt
+			d.apiKeys[id] = existing
+		}
+		key = existing
+		return nil
+	})
+	return key, err
+}
+
+// SaveTeam implements postgres.PlayerRepository.
+func (r *PlayerRepository) SaveTeam(ctx context.Context, team model.Team) (model.Team, error) {
+	err := r.write(ctx, func(d *data) error {
+		for _, existing := range d.teams {
+			if existing.Name == team.Name && existing.Id != team.Id {
+				return fmt.Errorf("team %q: %w", team.Name, postgres.ErrDuplicate)
+			}
+		}
+		team.Id = d.assignID("team", team.Id)
+		d.teams[team.Id] = team
+		return nil
+	})
+	if err != nil {
+		return model.Team{}, err
+	}
+	return team, nil
+}
+
+// SavePlayer implements postgres.PlayerRepository.
+func (r *PlayerRepository) SavePlayer(ctx context.Context, player model.Player) (model.Player, error) {
+	err := r.write(ctx, func(d *data) error {
+		if _, ok := d.teams[player.CurrentTeamID]; !ok {
+			return fmt.Errorf("failed to save player: team %d does not exist", player.CurrentTeamID)
+		}
+		for _, existing := range d.players {
+			if player.ExternalID != "" && existing.ExternalID == player.ExternalID && existing.Id != player.Id {
+				return fmt.Errorf("player with external ID %q: %w", player.ExternalID, postgres.ErrDuplicate)
+			}
+		}
+		player.Id = d.assignID("player", player.Id)
+		player.Games = nil
+		d.players[player.Id] = player
+		return nil
+	})
+	if err != nil {
+		return model.Player{}, err
+	}
+	return player, nil
+}
+
+// SaveGame implements postgres.PlayerRepository.
+func (r *PlayerRepository) SaveGame(ctx context.Context, game model.Game) (model.Game, error) {
+	err := r.write(ctx, func(d *data) error {
+		game.Id = d.assignID("game", game.Id)
+		game.Date = dateOnly(game.Date)
+		d.games[game.Id] = game
+		return nil
+	})
+	if err != nil {
+		return model.Game{}, err
+	}
+	return game, nil
+}
+
+// SaveRosterEntry implements postgres.PlayerRepository.
+func (r *PlayerRepository) SaveRosterEntry(ctx context.Context, entry model.RosterEntry) error {
+	return r.write(ctx, func(d *data) error {
+		if _, ok := d.players[entry.PlayerID]; !ok {
+			return fmt.Errorf("failed to save roster entry: player %d does not exist", entry.PlayerID)
+		}
+		if _, ok := d.teams[entry.TeamID]; !ok {
+			return fmt.Errorf("failed to save roster entry: team %d does not exist", entry.TeamID)
+		}
+		entry.StartDate = dateOnly(entry.StartDate)
+		if entry.EndDate != nil {
+			end := dateOnly(*entry.EndDate)
+			entry.EndDate = &end
+		}
+		d.roster[rosterKey{entry.PlayerID, entry.TeamID, entry.StartDate}] = entry
+		return nil
+	})
+}
+
+// dateOnly drops the time of day, like a Postgres DATE column does.
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
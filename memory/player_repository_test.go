@@ -0,0 +1,15 @@
+package memory_test
+
+import (
+	"testing"
+
+	"nba/memory"
+	"nba/postgres"
+	"nba/postgres/repotest"
+)
+
+func TestPlayerRepository(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) postgres.PlayerRepository {
+		return memory.NewPlayerRepository()
+	})
+}
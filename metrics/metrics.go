@@ -0,0 +1,147 @@
+// Package metrics exports Prometheus metrics for RPCs, the database and domain events.
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const namespace = "nba"
+
+// Metrics holds every collector on a registry of its own. It implements service.Recorder.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	rpcRequests  *prometheus.CounterVec
+	rpcDuration  *prometheus.HistogramVec
+	queryLatency *prometheus.HistogramVec
+
+	statLinesLogged      prometheus.Counter
+	validationRejections *prometheus.CounterVec
+	cacheLookups         *prometheus.CounterVec
+	rateLimited          *prometheus.CounterVec
+}
+
+// New creates the metrics, along with the Go runtime and process collectors.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		rpcRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "grpc_requests_total",
+			Help:      "RPCs handled, by method and status code.",
+		}, []string{"method", "code"}),
+		rpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "grpc_request_duration_seconds",
+			Help:      "Time spent handling RPCs, by method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "code"}),
+		queryLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "db_query_duration_seconds",
+			Help:      "Time spent in repository operations, by operation.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+		}, []string{"operation"}),
+		statLinesLogged: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "stat_lines_logged_total",
+			Help:      "Player stat lines written, whether logged, derived from plays, changed by stints or imported.",
+		}),
+		validationRejections: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "validation_rejections_total",
+			Help:      "Stat line rule violations, by rule.",
+		}, []string{"rule"}),
+		cacheLookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_lookups_total",
+			Help:      "Season average cache lookups, by kind and result (hit or miss).",
+		}, []string{"kind", "result"}),
+		rateLimited: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rate_limited_total",
+			Help:      "RPCs rejected for exceeding a limit, by method and reason (rate or quota).",
+		}, []string{"method", "reason"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.rpcRequests, m.rpcDuration, m.queryLatency,
+		m.statLinesLogged, m.validationRejections, m.cacheLookups, m.rateLimited,
+	)
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// RegisterDB exports the connection pool statistics of db, as reported by sql.DB.Stats.
+func (m *Metrics) RegisterDB(name string, db *sql.DB) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// ObserveQuery records the duration of a repository operation. It fits postgres.Options.ObserveQuery.
+func (m *Metrics) ObserveQuery(operation string, duration time.Duration) {
+	m.queryLatency.WithLabelValues(operation).Observe(duration.Seconds())
+}
+
+// UnaryServerInterceptor counts and times unary RPCs.
+func (m *Metrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		m.observeRPC(info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor counts streaming RPCs and times them until the stream ends.
+func (m *Metrics) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		m.observeRPC(info.FullMethod, start, err)
+		return err
+	}
+}
+
+func (m *Metrics) observeRPC(method string, start time.Time, err error) {
+	code := status.Code(err).String()
+	m.rpcRequests.WithLabelValues(method, code).Inc()
+	m.rpcDuration.WithLabelValues(method, code).Observe(time.Since(start).Seconds())
+}
+
+// StatLineLogged implements service.Recorder.
+func (m *Metrics) StatLineLogged() {
+	m.statLinesLogged.Inc()
+}
+
+// ValidationRejected implements service.Recorder.
+func (m *Metrics) ValidationRejected(rule string) {
+	m.validationRejections.WithLabelValues(rule).Inc()
+}
+
+// CacheLookup implements service.Recorder.
+func (m *Metrics) CacheLookup(kind string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	m.cacheLookups.WithLabelValues(kind, result).Inc()
+}
+
+// RateLimited implements ratelimit.Recorder.
+func (m *Metrics) RateLimited(method, reason string) {
+	m.rateLimited.WithLabelValues(method, reason).Inc()
+}
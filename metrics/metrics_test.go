@@ -0,0 +1,72 @@
+package metrics_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"nba/metrics"
+)
+
+// scrape returns the metrics exposition as text.
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("scrape status = %d", rec.Code)
+	}
+	return rec.Body.String()
+}
+
+func TestMetrics(t *testing.T) {
+	m := metrics.New()
+	unary := m.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/pb.PlayerGameService/LogPlayerGame"}
+	ok := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+	invalid := func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.InvalidArgument, "invalid stat line")
+	}
+	unary(context.Background(), nil, info, ok)
+	unary(context.Background(), nil, info, ok)
+	unary(context.Background(), nil, info, invalid)
+
+	stream := m.StreamServerInterceptor()
+	stream(nil, nil, &grpc.StreamServerInfo{FullMethod: "/pb.PlayerGameService/Watch"}, func(srv any, ss grpc.ServerStream) error {
+		return nil
+	})
+
+	m.ObserveQuery("GetGame", 3*time.Millisecond)
+	m.StatLineLogged()
+	m.ValidationRejected("personal_foul_limit")
+	m.ValidationRejected("personal_foul_limit")
+	m.CacheLookup("player", true)
+	m.CacheLookup("player", false)
+	m.RateLimited("/pb.PlayerGameService/GetPlayer", "rate")
+
+	body := scrape(t, m)
+	for _, want := range []string{
+		`nba_grpc_requests_total{code="OK",method="/pb.PlayerGameService/LogPlayerGame"} 2`,
+		`nba_grpc_requests_total{code="InvalidArgument",method="/pb.PlayerGameService/LogPlayerGame"} 1`,
+		`nba_grpc_requests_total{code="OK",method="/pb.PlayerGameService/Watch"} 1`,
+		`nba_grpc_request_duration_seconds_count{code="OK",method="/pb.PlayerGameService/LogPlayerGame"} 2`,
+		`nba_db_query_duration_seconds_count{operation="GetGame"} 1`,
+		`nba_stat_lines_logged_total 1`,
+		`nba_validation_rejections_total{rule="personal_foul_limit"} 2`,
+		`nba_cache_lookups_total{kind="player",result="hit"} 1`,
+		`nba_rate_limited_total{method="/pb.PlayerGameService/GetPlayer",reason="rate"} 1`,
+		`nba_cache_lookups_total{kind="player",result="miss"} 1`,
+		`go_goroutines`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics are missing %s", want)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package middleware_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"nba/middleware"
+)
+
+func TestChainUnary(t *testing.T) {
+	var calls []string
+	record := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			calls = append(calls, name+" "+info.FullMethod)
+			return handler(ctx, req)
+		}
+	}
+	reject := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return nil, status.Error(codes.PermissionDenied, "denied")
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/pb.PlayerGameService/GetPlayer"}
+	handler := func(ctx context.Context, req any) (any, error) {
+		calls = append(calls, "handler")
+		return req, nil
+	}
+
+	resp, err := middleware.ChainUnary(record("first"), record("second"))(context.Background(), "req", info, handler)
+	if resp != "req" || err != nil {
+		t.Fatalf("chain = %v, %v, want the handler's response", resp, err)
+	}
+	want := []string{"first " + info.FullMethod, "second " + info.FullMethod, "handler"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Fatalf("calls = %v, want %v", calls, want)
+	}
+
+	calls = nil
+	_, err = middleware.ChainUnary(record("first"), reject, record("never"))(context.Background(), "req", info, handler)
+	if status.Code(err) != codes.PermissionDenied || len(calls) != 1 {
+		t.Fatalf("chain = %v after %v, want PermissionDenied from the second interceptor", err, calls)
+	}
+}
+
+func TestChainStream(t *testing.T) {
+	var calls []string
+	record := func(name string) grpc.StreamServerInterceptor {
+		return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			calls = append(calls, name+" "+info.FullMethod)
+			return handler(srv, ss)
+		}
+	}
+	reject := func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return status.Error(codes.PermissionDenied, "denied")
+	}
+	info := &grpc.StreamServerInfo{FullMethod: "/pb.PlayerGameService/WatchGame", IsServerStream: true}
+	handler := func(srv any, ss grpc.ServerStream) error {
+		calls = append(calls, "handler")
+		return nil
+	}
+
+	if err := middleware.ChainStream(record("first"), record("second"))(nil, nil, info, handler); err != nil {
+		t.Fatalf("chain = %v, want the handler's result", err)
+	}
+	want := []string{"first " + info.FullMethod, "second " + info.FullMethod, "handler"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Fatalf("calls = %v, want %v", calls, want)
+	}
+
+	calls = nil
+	err := middleware.ChainStream(record("first"), reject, record("never"))(nil, nil, info, handler)
+	if status.Code(err) != codes.PermissionDenied || len(calls) != 1 {
+		t.Fatalf("chain = %v after %v, want PermissionDenied from the second interceptor", err, calls)
+	}
+}
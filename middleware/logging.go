@@ -0,0 +1,203 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"nba/tracing"
+)
+
+// RequestIDHeader is the metadata key, and HTTP header through the gateway, carrying the
+// id of a request. Requests without one are given a new id, sent back in the response headers.
+const RequestIDHeader = "x-request-id"
+
+const redacted = "[REDACTED]"
+
+// redactedFields are request fields never written to the log, besides those marked debug_redact.
+var redactedFields = map[protoreflect.Name]bool{
+	"password": true,
+	"secret":   true,
+	"token":    true,
+	"api_key":  true,
+}
+
+// LoggingOptions configures the request logging interceptors.
+type LoggingOptions struct {
+	// SampleEvery logs only one in every n successful calls of a full method name, such as
+	// "/pb.PlayerGameService/GetPlayerGameSeasonStats". Failed calls are always logged.
+	SampleEvery map[string]int
+}
+
+type requestLogger struct {
+	logger *zap.SugaredLogger
+	every  map[string]int
+	counts map[string]*atomic.Uint64
+}
+
+func newRequestLogger(logger *zap.SugaredLogger, opts LoggingOptions) *requestLogger {
+	// Stack traces would only ever point at the interceptor
+	logger = logger.WithOptions(zap.AddStacktrace(zapcore.FatalLevel))
+	l := &requestLogger{logger: logger, every: opts.SampleEvery, counts: make(map[string]*atomic.Uint64)}
+	for method := range opts.SampleEvery {
+		l.counts[method] = new(atomic.Uint64)
+	}
+	return l
+}
+
+// UnaryLogging writes one structured entry per unary call, with the method, peer, duration,
+// status code, request id and the request with its secrets redacted.
+func UnaryLogging(logger *zap.SugaredLogger, opts LoggingOptions) grpc.UnaryServerInterceptor {
+	l := newRequestLogger(logger, opts)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		ctx, requestID := withRequestID(ctx)
+		resp, err := handler(ctx, req)
+		l.log(ctx, info.FullMethod, requestID, req, start, err)
+		return resp, err
+	}
+}
+
+// StreamLogging writes one structured entry per stream, once it ends.
+func StreamLogging(logger *zap.SugaredLogger, opts LoggingOptions) grpc.StreamServerInterceptor {
+	l := newRequestLogger(logger, opts)
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx, requestID := withRequestID(ss.Context())
+		err := handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+		l.log(ctx, info.FullMethod, requestID, nil, start, err)
+		return err
+	}
+}
+
+// contextStream is a server stream whose handler sees ctx.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// RequestID returns the id of the request being handled in ctx.
+func RequestID(ctx context.Context) string {
+	if ids := metadata.ValueFromIncomingContext(ctx, RequestIDHeader); len(ids) > 0 {
+		return ids[0]
+	}
+	return ""
+}
+
+// withRequestID returns ctx carrying the request id sent by the client, or a new one,
+// and sends the id back in the response headers.
+func withRequestID(ctx context.Context) (context.Context, string) {
+	id := RequestID(ctx)
+	if id == "" {
+		b := make([]byte, 8)
+		rand.Read(b)
+		id = hex.EncodeToString(b)
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		md = md.Copy()
+		md.Set(RequestIDHeader, id)
+		ctx = metadata.NewIncomingContext(ctx, md)
+	}
+	grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, id))
+	return ctx, id
+}
+
+func (l *requestLogger) log(ctx context.Context, method, requestID string, req any, start time.Time, err error) {
+	code := status.Code(err)
+	fields := []any{
+		"method", method,
+		"code", code.String(),
+		"duration", time.Since(start),
+		"request_id", requestID,
+	}
+
+	if n, ok := l.every[method]; ok && n > 1 {
+		if code == codes.OK && (l.counts[method].Add(1)-1)%uint64(n) != 0 {
+			return
+		}
+		fields = append(fields, "sample_every", n)
+	}
+
+	if p, ok := peer.FromContext(ctx); ok {
+		fields = append(fields, "peer", p.Addr.String())
+	}
+	if forwarded := metadata.ValueFromIncomingContext(ctx, "x-forwarded-for"); len(forwarded) > 0 {
+		fields = append(fields, "forwarded_for", forwarded[0])
+	}
+	if msg, ok := req.(proto.Message); ok {
+		fields = append(fields, "request", redactedPayload(msg))
+	}
+	if err != nil {
+		fields = append(fields, "error", status.Convert(err).Message())
+	}
+
+	tracing.Logger(ctx, l.logger).Logw(levelFor(code), "Handled gRPC request", fields...)
+}
+
+// levelFor logs failures caused by the server as errors and those caused by the client as warnings.
+func levelFor(code codes.Code) zapcore.Level {
+	switch code {
+	case codes.OK:
+		return zapcore.InfoLevel
+	case codes.Unknown, codes.Internal, codes.Unavailable, codes.DataLoss, codes.Unimplemented, codes.DeadlineExceeded:
+		return zapcore.ErrorLevel
+	default:
+		return zapcore.WarnLevel
+	}
+}
+
+// redactedPayload returns msg as a JSON object with its sensitive fields replaced.
+func redactedPayload(msg proto.Message) any {
+	msg = proto.Clone(msg)
+	redact(msg.ProtoReflect())
+	b, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	if err != nil {
+		return nil
+	}
+	var payload map[string]any
+	if err := json.Unmarshal(b, &payload); err != nil {
+		return nil
+	}
+	return payload
+}
+
+func redact(m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		opts, _ := fd.Options().(*descriptorpb.FieldOptions)
+		switch {
+		case redactedFields[fd.Name()] || opts.GetDebugRedact():
+			if fd.Kind() == protoreflect.StringKind && !fd.IsList() && !fd.IsMap() {
+				m.Set(fd, protoreflect.ValueOfString(redacted))
+			} else {
+				m.Clear(fd)
+			}
+		case fd.Message() != nil && fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				redact(list.Get(i).Message())
+			}
+		case fd.Message() != nil && !fd.IsMap():
+			redact(v.Message())
+		}
+		return true
+	})
+}
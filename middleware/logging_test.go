@@ -0,0 +1,174 @@
+package middleware_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"nba/middleware"
+	"nba/pb"
+)
+
+func TestUnaryLogging(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	interceptor := middleware.UnaryLogging(zap.New(core).Sugar(), middleware.LoggingOptions{})
+	info := &grpc.UnaryServerInfo{FullMethod: "/pb.PlayerGameService/LogPlayerGame"}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(middleware.RequestIDHeader, "req-1"))
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 5000}})
+	req := &pb.LogPlayerGameRequest{PlayerId: 1, GameId: 2, Points: 30}
+	_, err := interceptor(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+		if id := middleware.RequestID(ctx); id != "req-1" {
+			t.Errorf("handler saw request id %q, want req-1", id)
+		}
+		return nil, status.Error(codes.NotFound, "game not found")
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("err = %v, want the handler error", err)
+	}
+
+	if logs.Len() != 1 {
+		t.Fatalf("logged %d entries, want 1", logs.Len())
+	}
+	entry := logs.All()[0]
+	fields := entry.ContextMap()
+	if entry.Level != zapcore.WarnLevel {
+		t.Errorf("level = %v, want warn for a client error", entry.Level)
+	}
+	for key, want := range map[string]any{
+		"method":     info.FullMethod,
+		"code":       "NotFound",
+		"request_id": "req-1",
+		"peer":       "10.0.0.1:5000",
+		"error":      "game not found",
+	} {
+		if fields[key] != want {
+			t.Errorf("%s = %v, want %v", key, fields[key], want)
+		}
+	}
+	if payload, ok := fields["request"].(map[string]any); !ok || payload["points"] != float64(30) {
+		t.Errorf("request = %v, want the request payload", fields["request"])
+	}
+}
+
+func TestUnaryLoggingRequestID(t *testing.T) {
+	interceptor := middleware.UnaryLogging(zap.NewNop().Sugar(), middleware.LoggingOptions{})
+	info := &grpc.UnaryServerInfo{FullMethod: "/pb.PlayerGameService/GetPlayer"}
+
+	// Requests without an id are given one
+	var first, second string
+	interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		first = middleware.RequestID(ctx)
+		return nil, nil
+	})
+	interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		second = middleware.RequestID(ctx)
+		return nil, nil
+	})
+	if first == "" || first == second {
+		t.Fatalf("request ids = %q and %q, want two distinct ids", first, second)
+	}
+}
+
+func TestUnaryLoggingSampling(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	method := "/pb.PlayerGameService/GetPlayerGameSeasonStats"
+	interceptor := middleware.UnaryLogging(zap.New(core).Sugar(), middleware.LoggingOptions{
+		SampleEvery: map[string]int{method: 5},
+	})
+
+	call := func(method string, err error) {
+		interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req any) (any, error) {
+			return nil, err
+		})
+	}
+	for i := 0; i < 10; i++ {
+		call(method, nil)
+	}
+	call(method, status.Error(codes.Internal, "internal error"))
+	call("/pb.PlayerGameService/LogPlayerGame", nil)
+
+	// Two of the ten successful calls, every failure and every call of other methods
+	if logs.Len() != 4 {
+		t.Fatalf("logged %d entries, want 4", logs.Len())
+	}
+	if failed := logs.All()[2]; failed.Level != zapcore.ErrorLevel || failed.ContextMap()["sample_every"] != int64(5) {
+		t.Errorf("failure logged as %v with fields %v", failed.Level, failed.ContextMap())
+	}
+}
+
+func TestUnaryLoggingRedactsSecrets(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	interceptor := middleware.UnaryLogging(zap.New(core).Sugar(), middleware.LoggingOptions{})
+
+	req := credentialsMessage(t)
+	interceptor(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: "/test.Auth/Login"}, func(ctx context.Context, req any) (any, error) {
+		return nil, nil
+	})
+
+	payload := logs.All()[0].ContextMap()["request"].(map[string]any)
+	if payload["user"] != "admin" || payload["password"] != "[REDACTED]" {
+		t.Errorf("request = %v, want the password redacted", payload)
+	}
+	if nested := payload["nested"].(map[string]any); nested["token"] != "[REDACTED]" {
+		t.Errorf("nested = %v, want the token redacted", nested)
+	}
+	if password := req.ProtoReflect().Get(req.ProtoReflect().Descriptor().Fields().ByName("password")).String(); password != "hunter2" {
+		t.Errorf("the request itself was redacted")
+	}
+}
+
+// credentialsMessage builds a message with secrets at the top level and in a nested message.
+func credentialsMessage(t *testing.T) proto.Message {
+	t.Helper()
+	str := descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+	msg := descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+	optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("test/credentials.proto"),
+		Package: proto.String("test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Credentials"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("user"), Number: proto.Int32(1), Type: str, Label: optional, JsonName: proto.String("user")},
+					{Name: proto.String("password"), Number: proto.Int32(2), Type: str, Label: optional, JsonName: proto.String("password")},
+					{Name: proto.String("nested"), Number: proto.Int32(3), Type: msg, Label: optional, TypeName: proto.String(".test.Session"), JsonName: proto.String("nested")},
+				},
+			},
+			{
+				Name: proto.String("Session"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("token"), Number: proto.Int32(1), Type: str, Label: optional, JsonName: proto.String("token")},
+				},
+			},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	credentials := dynamicpb.NewMessage(file.Messages().ByName("Credentials"))
+	fields := credentials.Descriptor().Fields()
+	credentials.Set(fields.ByName("user"), protoreflect.ValueOfString("admin"))
+	credentials.Set(fields.ByName("password"), protoreflect.ValueOfString("hunter2"))
+	session := dynamicpb.NewMessage(file.Messages().ByName("Session"))
+	session.Set(session.Descriptor().Fields().ByName("token"), protoreflect.ValueOfString("secret-token"))
+	credentials.Set(fields.ByName("nested"), protoreflect.ValueOfMessage(session))
+	return credentials
+}
@@ -0,0 +1,56 @@
+// Package middleware holds the gRPC interceptors shared by every service.
+package middleware
+
+import (
+	"context"
+	"runtime/debug"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"nba/tracing"
+)
+
+// UnaryRecovery turns a panicking unary handler into an INTERNAL status.
+// The panic and its stack trace are logged, never sent to the client.
+func UnaryRecovery(logger *zap.SugaredLogger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ctx, logger, info.FullMethod, r)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamRecovery turns a panicking streaming handler into an INTERNAL status.
+func StreamRecovery(logger *zap.SugaredLogger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(streamContext(ss), logger, info.FullMethod, r)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+func recovered(ctx context.Context, logger *zap.SugaredLogger, method string, r any) error {
+	tracing.Logger(ctx, logger).Errorw("Recovered from panic in gRPC handler",
+		"method", method,
+		"panic", r,
+		"stack", string(debug.Stack()),
+	)
+	return status.Error(codes.Internal, "internal error")
+}
+
+// streamContext returns the context of ss, if there is one to log the trace of.
+func streamContext(ss grpc.ServerStream) context.Context {
+	if ss == nil {
+		return context.Background()
+	}
+	return ss.Context()
+}
@@ -0,0 +1,54 @@
+package middleware_test
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"nba/middleware"
+)
+
+func TestUnaryRecovery(t *testing.T) {
+	core, logs := observer.New(zap.ErrorLevel)
+	interceptor := middleware.UnaryRecovery(zap.New(core).Sugar())
+	info := &grpc.UnaryServerInfo{FullMethod: "/nba.PlayerGameService/GetPlayer"}
+
+	resp, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		var stats *struct{ Points int }
+		return stats.Points, nil
+	})
+	if resp != nil {
+		t.Errorf("resp = %v, want nil", resp)
+	}
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("err = %v, want code %v", err, codes.Internal)
+	}
+	if logs.Len() != 1 || logs.All()[0].ContextMap()["method"] != info.FullMethod {
+		t.Fatalf("logged %v, want one entry for %s", logs.All(), info.FullMethod)
+	}
+
+	// Handlers that do not panic are untouched
+	resp, err = interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	})
+	if resp != "ok" || err != nil {
+		t.Fatalf("interceptor() = %v, %v, want ok, nil", resp, err)
+	}
+}
+
+func TestStreamRecovery(t *testing.T) {
+	interceptor := middleware.StreamRecovery(zap.NewNop().Sugar())
+	info := &grpc.StreamServerInfo{FullMethod: "/nba.PlayerGameService/Watch"}
+
+	err := interceptor(nil, nil, info, func(srv any, stream grpc.ServerStream) error {
+		panic("boom")
+	})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("err = %v, want code %v", err, codes.Internal)
+	}
+}
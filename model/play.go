@@ -0,0 +1,86 @@
+package model
+
+import "time"
+
+// PlayType tells what happened in a play.
+type PlayType string
+
+const (
+	PlayShotMade     PlayType = "shot_made"
+	PlayShotMissed   PlayType = "shot_missed"
+	PlayRebound      PlayType = "rebound"
+	PlayAssist       PlayType = "assist"
+	PlaySteal        PlayType = "steal"
+	PlayBlock        PlayType = "block"
+	PlayTurnover     PlayType = "turnover"
+	PlayFoul         PlayType = "foul"
+	PlaySubstitution PlayType = "substitution"
+	PlayPeriodStart  PlayType = "period_start"
+	PlayPeriodEnd    PlayType = "period_end"
+)
+
+// ShotType is the kind of a shot, which sets the points it is worth.
+type ShotType string
+
+const (
+	ShotTwoPointer   ShotType = "two_pointer"
+	ShotThreePointer ShotType = "three_pointer"
+	ShotFreeThrow    ShotType = "free_throw"
+)
+
+// Play is one event of the play-by-play of a game.
+type Play struct {
+	GameID int
+	// Sequence orders the plays of a game, from 1. It is assigned when the play is recorded.
+	Sequence int
+	Period   int
+	// Elapsed is the game time since the start of the period.
+	Elapsed time.Duration
+	Type    PlayType
+	// PlayerID made the play; for substitutions it is the player coming in, or 0 if
+	// a player only goes out. Period starts and ends have no player.
+	PlayerID int
+	// ShotType is set for made and missed shots.
+	ShotType ShotType
+	// ReplacedPlayerID is the player going out in a substitution, or 0 if a player
+	// only comes in, like the starters of the game.
+	ReplacedPlayerID int
+}
+
+// Stint is a stretch of a period during which the players of a team on the court did
+// not change. Stints are derived from the play-by-play, or recorded as such for games
+// scored without one.
+type Stint struct {
+	GameID int
+	TeamID int
+	Period int
+	// Start and End are the game time since the start of the period.
+	Start time.Duration
+	End   time.Duration
+	// PlayerIDs are the players on the court, in ascending order.
+	PlayerIDs     []int
+	PointsFor     int
+	PointsAgainst int
+}
+
+// LineupStats sums the stints a group of players of a team played together.
+type LineupStats struct {
+	PlayerIDs     []int
+	PlayerNames   []string
+	Minutes       float32
+	PointsFor     int
+	PointsAgainst int
+	// NetRating is the point differential per 48 minutes on the court. Stints do not
+	// count possessions, so it is not the per 100 possessions rating.
+	NetRating float32
+}
+
+// TeamLineupStats holds the five-man lineups and the two-man combinations of a team
+// over a season, each ordered by minutes played.
+type TeamLineupStats struct {
+	TeamID   int
+	TeamName string
+	Season   int
+	Lineups  []LineupStats
+	Pairs    []LineupStats
+}
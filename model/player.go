@@ -1,10 +1,14 @@
 package model
 
+import "time"
+
 type Player struct {
 	Id            int
 	Name          string
 	CurrentTeamID int
-	Games         []PlayerGameStats
+	// ExternalID identifies the player in the source stats were imported from, if any.
+	ExternalID string
+	Games      []PlayerGameStats
 }
 
 type Season struct {
@@ -12,10 +16,24 @@ type Season struct {
 	Year string
 }
 type Game struct {
-	Id       int
-	TeamAID  int
-	TeamBID  int
-	SeasonID int
+	Id              int
+	Date            time.Time
+	TeamAID         int
+	TeamBID         int
+	TeamAScore      *int
+	TeamBScore      *int
+	SeasonID        int
+	League          string
+	OvertimePeriods int
+}
+
+// RosterEntry records that a player belonged to a team from StartDate until EndDate.
+// A nil EndDate means the player is still on the team.
+type RosterEntry struct {
+	PlayerID  int
+	TeamID    int
+	StartDate time.Time
+	EndDate   *time.Time
 }
 type Team struct {
 	Id   int
@@ -26,6 +44,7 @@ type PlayerGameStats struct {
 	PlayerID      int
 	PlayerName    string
 	GameID        int
+	TeamID        int
 	Points        int
 	Assists       int
 	Rebounds      int
@@ -34,11 +53,22 @@ type PlayerGameStats struct {
 	Turnovers     int
 	Fouls         int
 	MinutesPlayed float32
+	// PlusMinus is the point differential of the game's stints the player was on the
+	// court for. Repositories compute it from the stints when reading and ignore it when writing.
+	PlusMinus int
+}
+
+// StatLineFilter selects stat lines by season, team and player. Zero fields match any.
+type StatLineFilter struct {
+	Season   int
+	TeamID   int
+	PlayerID int
 }
 type PlayerSeasonAverage struct {
 	PlayerID             int
 	PlayerName           string
 	Season               int
+	GamesPlayed          int // 0 for a season without games, leaving every average 0
 	PointsPerGame        float32
 	AssistsPerGame       float32
 	ReboundsPerGame      float32
@@ -47,12 +77,14 @@ type PlayerSeasonAverage struct {
 	TurnoversPerGame     float32
 	FoulsPerGame         float32
 	MinutesPlayedPerGame float32
+	PlusMinusPerGame     float32
 }
 
 type TeamSeasoAverage struct {
 	TeamName             string
 	TeamID               int
 	Season               int
+	GamesPlayed          int // distinct games the team's players were logged in
 	PointsPerGame        float32
 	AssistsPerGame       float32
 	ReboundsPerGame      float32
@@ -96,4 +128,77 @@ type LogPlayerGameRequest struct {
 	Turnovers     int
 	Fouls         int
 	MinutesPlayed float32
+	// IdempotencyKey makes retries of the same request safe. Optional.
+	IdempotencyKey string
+	// Upsert replaces an existing stat line instead of rejecting the duplicate.
+	Upsert bool
+}
+
+// IdempotencyKey remembers a request that was applied under a client supplied key.
+type IdempotencyKey struct {
+	Key         string
+	RequestHash string
+	CreatedAt   time.Time
+}
+
+// APIKey grants a role to the clients presenting the key. Only the hash of the key is stored.
+type APIKey struct {
+	ID   int
+	Name string
+	// Prefix is the start of the key, enough to tell keys apart without revealing them.
+	Prefix string
+	// Hash is the hex encoded SHA-256 of the key.
+	Hash      string
+	Role      string
+	CreatedAt time.Time
+	// RevokedAt is zero while the key is active.
+	RevokedAt time.Time
+}
+
+type CheckStatus string
+
+const (
+	CheckPassed  CheckStatus = "passed"
+	CheckFailed  CheckStatus = "failed"
+	CheckSkipped CheckStatus = "skipped"
+)
+
+type GameCheck struct {
+	Name    string
+	Status  CheckStatus
+	Message string
+}
+
+type GameValidation struct {
+	GameID int
+	Valid  bool
+	Checks []GameCheck
+}
+
+// GameEventKind tells what changed in a game.
+type GameEventKind string
+
+const (
+	StatLineLogged    GameEventKind = "stat_line_logged"
+	StatLineCorrected GameEventKind = "stat_line_corrected"
+	ScoreChanged      GameEventKind = "score_changed"
+)
+
+// GameEvent is a change to a game, pushed to the clients watching the game or player.
+type GameEvent struct {
+	Kind   GameEventKind
+	GameID int
+	// Line is the stored stat line, for StatLineLogged and StatLineCorrected.
+	Line PlayerGameStats
+	// Score is the running score after the change, for ScoreChanged.
+	Score      GameScore
+	OccurredAt time.Time
+}
+
+// GameScore is the sum of the points logged for each team of a game.
+type GameScore struct {
+	TeamAID     int
+	TeamAPoints int
+	TeamBID     int
+	TeamBPoints int
 }
@@ -0,0 +1,191 @@
+package multiplex
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/http2"
+	"google.golang.org/grpc"
+)
+
+const (
+	grpcWebContentType     = "application/grpc-web"
+	grpcWebTextContentType = "application/grpc-web-text"
+	// trailerFlag marks the frame of a gRPC-Web response that carries the trailers.
+	trailerFlag = 0x80
+)
+
+// grpcWeb translates gRPC-Web calls into gRPC calls of a gRPC server. Messages are framed
+// the same in both protocols, so only the headers and the trailers, which gRPC-Web sends
+// as a last frame of the body, need translating. In the text encoding, the default of
+// grpc-web clients and the only one they stream responses in, bodies are also base64
+// encoded. Browsers make unary and server-streaming calls only.
+type grpcWeb struct {
+	grpcServer *grpc.Server
+	allowed    []string
+}
+
+// isRequest reports whether r is a gRPC-Web call.
+func (g *grpcWeb) isRequest(r *http.Request) bool {
+	return r.Method == http.MethodPost && strings.HasPrefix(r.Header.Get("Content-Type"), grpcWebContentType)
+}
+
+// isPreflight reports whether r is the CORS preflight of a gRPC-Web call.
+func (g *grpcWeb) isPreflight(r *http.Request) bool {
+	if r.Method != http.MethodOptions || r.Header.Get("Access-Control-Request-Method") != http.MethodPost {
+		return false
+	}
+	for _, header := range strings.Split(r.Header.Get("Access-Control-Request-Headers"), ",") {
+		if strings.EqualFold(strings.TrimSpace(header), "x-grpc-web") {
+			return true
+		}
+	}
+	return false
+}
+
+// allowOrigin returns whether browsers on a page from origin may call gRPC-Web.
+func (g *grpcWeb) allowOrigin(origin string) bool {
+	return slices.Contains(g.allowed, "*") || slices.Contains(g.allowed, origin)
+}
+
+// servePreflight answers the CORS preflight r, allowing the call only from an allowed origin.
+func (g *grpcWeb) servePreflight(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Vary", "Origin")
+	if origin := r.Header.Get("Origin"); g.allowOrigin(origin) {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Set("Access-Control-Allow-Methods", http.MethodPost)
+		w.Header().Set("Access-Control-Allow-Headers", r.Header.Get("Access-Control-Request-Headers"))
+		w.Header().Set("Access-Control-Max-Age", "600")
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ServeHTTP implements http.Handler.
+func (g *grpcWeb) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	contentType := r.Header.Get("Content-Type")
+	text := strings.HasPrefix(contentType, grpcWebTextContentType)
+	if origin := r.Header.Get("Origin"); origin != "" && g.allowOrigin(origin) {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Set("Access-Control-Expose-Headers", "Grpc-Status, Grpc-Message, Grpc-Status-Details-Bin")
+	}
+	w.Header().Add("Vary", "Origin")
+
+	// The gRPC server only serves HTTP/2, whichever protocol the browser speaks
+	call := r.Clone(r.Context())
+	call.Proto, call.ProtoMajor, call.ProtoMinor = "HTTP/2.0", 2, 0
+	subtype := strings.TrimPrefix(contentType, grpcWebContentType)
+	if text {
+		subtype = strings.TrimPrefix(contentType, grpcWebTextContentType)
+		call.Body = struct {
+			io.Reader
+			io.Closer
+		}{base64.NewDecoder(base64.StdEncoding, r.Body), r.Body}
+	}
+	call.Header.Set("Content-Type", "application/grpc"+subtype)
+	call.Header.Set("Te", "trailers")
+	call.Header.Del("Content-Length")
+	call.ContentLength = -1
+
+	tw := &trailerWriter{w: w, contentType: contentType, text: text, header: http.Header{}}
+	g.grpcServer.ServeHTTP(tw, call)
+	tw.finish()
+}
+
+// trailerWriter sends the response of a gRPC call as gRPC-Web, writing the trailers
+// the gRPC server sets after the headers into a trailer frame.
+type trailerWriter struct {
+	w           http.ResponseWriter
+	contentType string
+	// text base64 encodes what is written on every flush, each chunk padded on its own.
+	text    bool
+	pending bytes.Buffer
+	header  http.Header
+	// code is the status of the response, 0 until the headers are written.
+	code int
+}
+
+// Header implements http.ResponseWriter.
+func (tw *trailerWriter) Header() http.Header {
+	return tw.header
+}
+
+// WriteHeader implements http.ResponseWriter.
+func (tw *trailerWriter) WriteHeader(code int) {
+	if tw.code != 0 {
+		return
+	}
+	tw.code = code
+	trailers := tw.trailerKeys()
+	for k, vv := range tw.header {
+		if k == "Trailer" || trailers[k] || strings.HasPrefix(k, http2.TrailerPrefix) {
+			continue
+		}
+		tw.w.Header()[k] = vv
+	}
+	// Requests the gRPC server turns away get a plain HTTP error
+	if code == http.StatusOK {
+		tw.w.Header().Set("Content-Type", tw.contentType)
+	}
+	tw.w.WriteHeader(code)
+}
+
+// Write implements http.ResponseWriter.
+func (tw *trailerWriter) Write(b []byte) (int, error) {
+	tw.WriteHeader(http.StatusOK)
+	if tw.text && tw.code == http.StatusOK {
+		return tw.pending.Write(b)
+	}
+	return tw.w.Write(b)
+}
+
+// Flush implements http.Flusher, sending streamed messages as they are written.
+func (tw *trailerWriter) Flush() {
+	tw.WriteHeader(http.StatusOK)
+	if tw.pending.Len() > 0 {
+		tw.w.Write([]byte(base64.StdEncoding.EncodeToString(tw.pending.Bytes())))
+		tw.pending.Reset()
+	}
+	if f, ok := tw.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// trailerKeys returns the trailers the gRPC server declared.
+func (tw *trailerWriter) trailerKeys() map[string]bool {
+	keys := map[string]bool{}
+	for _, v := range tw.header.Values("Trailer") {
+		for _, k := range strings.Split(v, ",") {
+			keys[http.CanonicalHeaderKey(strings.TrimSpace(k))] = true
+		}
+	}
+	return keys
+}
+
+// finish writes the trailers of the call as the last frame of the body.
+func (tw *trailerWriter) finish() {
+	tw.WriteHeader(http.StatusOK)
+	if tw.code != http.StatusOK {
+		return
+	}
+	trailers := tw.trailerKeys()
+	var block strings.Builder
+	for k, vv := range tw.header {
+		name, undeclared := strings.CutPrefix(k, http2.TrailerPrefix)
+		if !undeclared && !trailers[k] {
+			continue
+		}
+		for _, v := range vv {
+			block.WriteString(strings.ToLower(name) + ": " + v + "\r\n")
+		}
+	}
+	frame := make([]byte, 5, 5+block.Len())
+	frame[0] = trailerFlag
+	binary.BigEndian.PutUint32(frame[1:], uint32(block.Len()))
+	tw.Write(append(frame, block.String()...))
+	tw.Flush()
+}
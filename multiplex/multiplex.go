@@ -0,0 +1,128 @@
+// Package multiplex serves native gRPC, gRPC-Web and the REST gateway on one HTTP
+// listener, for deployments that can only expose a single port.
+package multiplex
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+)
+
+// Options configures which requests reach the gRPC server.
+type Options struct {
+	// NativeGRPC serves gRPC over HTTP/2 next to the gateway.
+	NativeGRPC bool
+	// AllowedOrigins are the origins browsers may call gRPC-Web from, "*" allowing any.
+	// Pages served from the listener itself need no entry.
+	AllowedOrigins []string
+}
+
+// Handler routes gRPC and gRPC-Web requests to a gRPC server and every other request
+// to the gateway.
+type Handler struct {
+	grpcServer *grpc.Server
+	web        *grpcWeb
+	rest       http.Handler
+	opts       Options
+
+	mu       sync.Mutex
+	calls    int
+	draining bool
+}
+
+// New returns a Handler serving grpcServer and rest.
+func New(grpcServer *grpc.Server, rest http.Handler, opts Options) *Handler {
+	return &Handler{
+		grpcServer: grpcServer,
+		web:        &grpcWeb{grpcServer: grpcServer, allowed: opts.AllowedOrigins},
+		rest:       rest,
+		opts:       opts,
+	}
+}
+
+// IsGRPC reports whether r is a native gRPC call.
+func IsGRPC(r *http.Request) bool {
+	return r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") &&
+		!strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc-web")
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var serve http.Handler
+	switch {
+	case h.opts.NativeGRPC && IsGRPC(r):
+		serve = h.grpcServer
+	case h.web.isRequest(r):
+		serve = h.web
+	case h.web.isPreflight(r):
+		h.web.servePreflight(w, r)
+		return
+	default:
+		h.rest.ServeHTTP(w, r)
+		return
+	}
+	if !h.begin() {
+		http.Error(w, "server is shutting down", http.StatusServiceUnavailable)
+		return
+	}
+	defer h.end()
+	serve.ServeHTTP(w, r)
+}
+
+func (h *Handler) begin() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.draining {
+		return false
+	}
+	h.calls++
+	return true
+}
+
+func (h *Handler) end() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.calls--
+}
+
+// Drain turns new gRPC and gRPC-Web calls away and waits until the calls in flight
+// have finished or ctx is done. The gRPC server cannot stop gracefully while it still
+// serves calls passed on by a Handler.
+func (h *Handler) Drain(ctx context.Context) error {
+	h.mu.Lock()
+	h.draining = true
+	h.mu.Unlock()
+
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		h.mu.Lock()
+		calls := h.calls
+		h.mu.Unlock()
+		if calls == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// ServeH2C lets srv serve HTTP/2 without TLS, as plaintext gRPC clients require, and
+// sends HTTP/2 clients a GOAWAY when srv shuts down.
+func ServeH2C(srv *http.Server) error {
+	h2s := &http2.Server{}
+	if err := http2.ConfigureServer(srv, h2s); err != nil {
+		return err
+	}
+	srv.Handler = h2c.NewHandler(srv.Handler, h2s)
+	return nil
+}
@@ -0,0 +1,271 @@
+package multiplex_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"nba/multiplex"
+)
+
+// newServer serves a gRPC health server and a REST handler answering "rest" on one
+// plaintext listener.
+func newServer(t *testing.T, opts multiplex.Options) (*httptest.Server, *multiplex.Handler) {
+	t.Helper()
+	grpcServer := grpc.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+	rest := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "rest")
+	})
+	handler := multiplex.New(grpcServer, rest, opts)
+	srv := httptest.NewUnstartedServer(handler)
+	if err := multiplex.ServeH2C(srv.Config); err != nil {
+		t.Fatalf("ServeH2C: %v", err)
+	}
+	srv.Start()
+	t.Cleanup(srv.Close)
+	return srv, handler
+}
+
+// checkHealth calls Health.Check through gRPC.
+func checkHealth(t *testing.T, srv *httptest.Server) error {
+	t.Helper()
+	conn, err := grpc.NewClient(strings.TrimPrefix(srv.URL, "http://"), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer conn.Close()
+	_, err = healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	return err
+}
+
+// checkHealthWeb calls Health.Check through gRPC-Web from origin and returns the response.
+func checkHealthWeb(t *testing.T, srv *httptest.Server, origin string) (*http.Response, string) {
+	t.Helper()
+	// An empty message in a single uncompressed frame
+	body := bytes.NewReader(make([]byte, 5))
+	req, _ := http.NewRequest(http.MethodPost, srv.URL+"/grpc.health.v1.Health/Check", body)
+	req.Header.Set("Content-Type", "application/grpc-web+proto")
+	req.Header.Set("X-Grpc-Web", "1")
+	req.Header.Set("Origin", origin)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("gRPC-Web call: %v", err)
+	}
+	defer resp.Body.Close()
+	out, _ := io.ReadAll(resp.Body)
+	return resp, string(out)
+}
+
+func TestHandler(t *testing.T) {
+	srv, _ := newServer(t, multiplex.Options{NativeGRPC: true, AllowedOrigins: []string{"https://stats.example"}})
+
+	if err := checkHealth(t, srv); err != nil {
+		t.Fatalf("gRPC Check = %v, want it served on the shared listener", err)
+	}
+
+	resp, body := checkHealthWeb(t, srv, "https://stats.example")
+	if resp.StatusCode != http.StatusOK || !strings.Contains(body, "grpc-status: 0") {
+		t.Fatalf("gRPC-Web Check = %s %q, want a successful call", resp.Status, body)
+	}
+	if got := resp.Header.Get("Access-Control-Allow-Origin"); got != "https://stats.example" {
+		t.Fatalf("Access-Control-Allow-Origin = %q, want the allowed origin", got)
+	}
+	if resp, _ := checkHealthWeb(t, srv, "https://elsewhere.example"); resp.Header.Get("Access-Control-Allow-Origin") != "" {
+		t.Fatal("gRPC-Web response allows an unlisted origin")
+	}
+
+	resp, err := http.Get(srv.URL + "/api/v1/player_game/1")
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	defer resp.Body.Close()
+	if out, _ := io.ReadAll(resp.Body); string(out) != "rest" {
+		t.Fatalf("GET = %q, want the REST handler", out)
+	}
+}
+
+func TestHandlerWithoutNativeGRPC(t *testing.T) {
+	srv, _ := newServer(t, multiplex.Options{})
+
+	if err := checkHealth(t, srv); status.Code(err) == codes.OK {
+		t.Fatal("gRPC Check succeeded, want native gRPC left to the REST handler")
+	}
+	if resp, body := checkHealthWeb(t, srv, ""); resp.StatusCode != http.StatusOK || !strings.Contains(body, "grpc-status: 0") {
+		t.Fatalf("gRPC-Web Check = %s %q, want gRPC-Web served without native gRPC", resp.Status, body)
+	}
+}
+
+func TestGRPCWebErrorStatus(t *testing.T) {
+	srv, _ := newServer(t, multiplex.Options{})
+
+	// Health.Check of an unknown service fails before any message is sent
+	frame := []byte{0, 0, 0, 0, 9, 10, 7, 'u', 'n', 'k', 'n', 'o', 'w', 'n'}
+	req, _ := http.NewRequest(http.MethodPost, srv.URL+"/grpc.health.v1.Health/Check", bytes.NewReader(frame))
+	req.Header.Set("Content-Type", "application/grpc-web+proto")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("gRPC-Web call: %v", err)
+	}
+	defer resp.Body.Close()
+	out, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || len(out) < 5 || out[0] != 0x80 || !strings.Contains(string(out), "grpc-status: 5") {
+		t.Fatalf("gRPC-Web Check = %s %q, want NotFound in a trailer frame", resp.Status, out)
+	}
+	if resp.Header.Get("Grpc-Status") != "" {
+		t.Fatal("gRPC-Web response sends the status as a header, want it only in the trailer frame")
+	}
+}
+
+func TestGRPCWebServerStreaming(t *testing.T) {
+	srv, _ := newServer(t, multiplex.Options{})
+
+	// Health.Watch streams the status of the server until the call is cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL+"/grpc.health.v1.Health/Watch", bytes.NewReader(make([]byte, 5)))
+	req.Header.Set("Content-Type", "application/grpc-web+proto")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("gRPC-Web call: %v", err)
+	}
+	defer resp.Body.Close()
+	if got := resp.Header.Get("Content-Type"); got != "application/grpc-web+proto" {
+		t.Fatalf("Content-Type = %q, want application/grpc-web+proto", got)
+	}
+
+	// The first message arrives while the call goes on
+	body := bufio.NewReader(resp.Body)
+	header := make([]byte, 5)
+	if _, err := io.ReadFull(body, header); err != nil || header[0] != 0 {
+		t.Fatalf("first frame header = %v, %v, want a message", header, err)
+	}
+	message := make([]byte, binary.BigEndian.Uint32(header[1:]))
+	if _, err := io.ReadFull(body, message); err != nil {
+		t.Fatalf("read message: %v", err)
+	}
+	var watched healthpb.HealthCheckResponse
+	if err := proto.Unmarshal(message, &watched); err != nil || watched.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("Watch message = %v, %v, want SERVING", &watched, err)
+	}
+}
+
+// readTextFrame reads a frame from a gRPC-Web text body. Every chunk of the body is
+// padded on its own, so it is decoded a group of four characters at a time.
+func readTextFrame(t *testing.T, body io.Reader) (flag byte, payload []byte) {
+	t.Helper()
+	var frame []byte
+	group := make([]byte, 4)
+	for len(frame) < 5 || len(frame) < 5+int(binary.BigEndian.Uint32(frame[1:5])) {
+		if _, err := io.ReadFull(body, group); err != nil {
+			t.Fatalf("read frame: %v", err)
+		}
+		decoded, err := base64.StdEncoding.DecodeString(string(group))
+		if err != nil {
+			t.Fatalf("decode %q: %v", group, err)
+		}
+		frame = append(frame, decoded...)
+	}
+	return frame[0], frame[5:]
+}
+
+func TestGRPCWebTextServerStreaming(t *testing.T) {
+	srv, _ := newServer(t, multiplex.Options{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	body := strings.NewReader(base64.StdEncoding.EncodeToString(make([]byte, 5)))
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL+"/grpc.health.v1.Health/Watch", body)
+	req.Header.Set("Content-Type", "application/grpc-web-text")
+	req.Header.Set("X-Grpc-Web", "1")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("gRPC-Web call: %v", err)
+	}
+	defer resp.Body.Close()
+	if got := resp.Header.Get("Content-Type"); resp.StatusCode != http.StatusOK || got != "application/grpc-web-text" {
+		t.Fatalf("response = %s %q, want application/grpc-web-text", resp.Status, got)
+	}
+
+	// The first message arrives while the call goes on
+	flag, message := readTextFrame(t, resp.Body)
+	var watched healthpb.HealthCheckResponse
+	if err := proto.Unmarshal(message, &watched); flag != 0 || err != nil || watched.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("Watch frame = %d %v, %v, want a SERVING message", flag, &watched, err)
+	}
+}
+
+func TestGRPCWebTextTrailers(t *testing.T) {
+	srv, _ := newServer(t, multiplex.Options{})
+
+	body := strings.NewReader(base64.StdEncoding.EncodeToString(make([]byte, 5)))
+	req, _ := http.NewRequest(http.MethodPost, srv.URL+"/grpc.health.v1.Health/Check", body)
+	req.Header.Set("Content-Type", "application/grpc-web-text+proto")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("gRPC-Web call: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if flag, _ := readTextFrame(t, resp.Body); flag != 0 {
+		t.Fatalf("first frame flag = %d, want a message", flag)
+	}
+	flag, trailers := readTextFrame(t, resp.Body)
+	if flag != 0x80 || !strings.Contains(string(trailers), "grpc-status: 0") {
+		t.Fatalf("last frame = %d %q, want the trailers of a successful call", flag, trailers)
+	}
+}
+
+func TestGRPCWebPreflight(t *testing.T) {
+	srv, _ := newServer(t, multiplex.Options{AllowedOrigins: []string{"https://stats.example"}})
+
+	preflight := func(origin string) *http.Response {
+		req, _ := http.NewRequest(http.MethodOptions, srv.URL+"/grpc.health.v1.Health/Check", nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		req.Header.Set("Access-Control-Request-Headers", "content-type,x-grpc-web")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("preflight: %v", err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	resp := preflight("https://stats.example")
+	if resp.Header.Get("Access-Control-Allow-Origin") != "https://stats.example" || resp.Header.Get("Access-Control-Allow-Headers") != "content-type,x-grpc-web" {
+		t.Fatalf("preflight headers = %v, want the call allowed", resp.Header)
+	}
+	if resp := preflight("https://elsewhere.example"); resp.Header.Get("Access-Control-Allow-Origin") != "" {
+		t.Fatal("preflight allows an unlisted origin")
+	}
+}
+
+func TestDrain(t *testing.T) {
+	srv, handler := newServer(t, multiplex.Options{NativeGRPC: true})
+
+	if err := handler.Drain(context.Background()); err != nil {
+		t.Fatalf("Drain = %v, want no calls to wait for", err)
+	}
+	if err := checkHealth(t, srv); status.Code(err) != codes.Unavailable {
+		t.Fatalf("gRPC Check after Drain = %v, want Unavailable", err)
+	}
+	if resp, _ := checkHealthWeb(t, srv, ""); resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("gRPC-Web Check after Drain = %s, want 503", resp.Status)
+	}
+}
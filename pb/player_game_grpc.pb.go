@@ -23,16 +23,66 @@ const (
 	PlayerGameService_LogPlayerGame_FullMethodName            = "/pb.PlayerGameService/LogPlayerGame"
 	PlayerGameService_GetPlayerGameSeasonStats_FullMethodName = "/pb.PlayerGameService/GetPlayerGameSeasonStats"
 	PlayerGameService_GetTeamSeasonStats_FullMethodName       = "/pb.PlayerGameService/GetTeamSeasonStats"
+	PlayerGameService_ValidateGame_FullMethodName             = "/pb.PlayerGameService/ValidateGame"
+	PlayerGameService_WatchGame_FullMethodName                = "/pb.PlayerGameService/WatchGame"
+	PlayerGameService_WatchPlayer_FullMethodName              = "/pb.PlayerGameService/WatchPlayer"
+	PlayerGameService_RecordPlays_FullMethodName              = "/pb.PlayerGameService/RecordPlays"
+	PlayerGameService_ListPlays_FullMethodName                = "/pb.PlayerGameService/ListPlays"
+	PlayerGameService_EditPlay_FullMethodName                 = "/pb.PlayerGameService/EditPlay"
+	PlayerGameService_DeletePlay_FullMethodName               = "/pb.PlayerGameService/DeletePlay"
+	PlayerGameService_RecordStints_FullMethodName             = "/pb.PlayerGameService/RecordStints"
+	PlayerGameService_GetLineupStats_FullMethodName           = "/pb.PlayerGameService/GetLineupStats"
+	PlayerGameService_ImportStats_FullMethodName              = "/pb.PlayerGameService/ImportStats"
+	PlayerGameService_ExportStats_FullMethodName              = "/pb.PlayerGameService/ExportStats"
 )
 
 // PlayerGameServiceClient is the client API for PlayerGameService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PlayerGameService records the stat lines of players and serves their season averages.
+// Reads require the reader role and writes the scorekeeper role.
 type PlayerGameServiceClient interface {
+	// GetPlayer looks up a player.
 	GetPlayer(ctx context.Context, in *GetPlayerRequest, opts ...grpc.CallOption) (*GetPlayerResponse, error)
+	// LogPlayerGame records the stat line of a player in a game.
 	LogPlayerGame(ctx context.Context, in *LogPlayerGameRequest, opts ...grpc.CallOption) (*LogGameResponse, error)
+	// GetPlayerGameSeasonStats returns the per game averages of a player over a season.
 	GetPlayerGameSeasonStats(ctx context.Context, in *GetPlayerGameSeasonStatsRequest, opts ...grpc.CallOption) (*PlayerGameSeasonStatsResponse, error)
+	// GetTeamSeasonStats returns the per game averages of a team over a season.
 	GetTeamSeasonStats(ctx context.Context, in *GetTeamsSeasonStatsRequest, opts ...grpc.CallOption) (*TeamsSeasonStatsResponse, error)
+	// ValidateGame checks that the stat lines of a game belong to rostered players and add
+	// up to the final score.
+	ValidateGame(ctx context.Context, in *ValidateGameRequest, opts ...grpc.CallOption) (*ValidateGameResponse, error)
+	// WatchGame streams the stat lines and score changes of a game as they are committed.
+	// Through the gateway the events arrive as Server-Sent Events.
+	WatchGame(ctx context.Context, in *WatchGameRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GameEvent], error)
+	// WatchPlayer streams the stat lines of a player as they are committed.
+	// Through the gateway the events arrive as Server-Sent Events.
+	WatchPlayer(ctx context.Context, in *WatchPlayerRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GameEvent], error)
+	// RecordPlays appends plays to the play-by-play of a game and re-derives the stat
+	// lines of its players from it, replacing lines logged with LogPlayerGame.
+	RecordPlays(ctx context.Context, in *RecordPlaysRequest, opts ...grpc.CallOption) (*RecordPlaysResponse, error)
+	// ListPlays returns the play-by-play of a game in order.
+	ListPlays(ctx context.Context, in *ListPlaysRequest, opts ...grpc.CallOption) (*ListPlaysResponse, error)
+	// EditPlay replaces a recorded play and re-derives the stat lines it changes.
+	EditPlay(ctx context.Context, in *EditPlayRequest, opts ...grpc.CallOption) (*EditPlayResponse, error)
+	// DeletePlay removes a recorded play and re-derives the stat lines it changes.
+	DeletePlay(ctx context.Context, in *DeletePlayRequest, opts ...grpc.CallOption) (*DeletePlayResponse, error)
+	// RecordStints replaces the stints of a team in a game scored without play-by-play;
+	// games with play-by-play derive their stints from the plays.
+	RecordStints(ctx context.Context, in *RecordStintsRequest, opts ...grpc.CallOption) (*RecordStintsResponse, error)
+	// GetLineupStats returns the five-man lineups and two-man combinations of a team over
+	// a season, each ordered by minutes played.
+	GetLineupStats(ctx context.Context, in *GetLineupStatsRequest, opts ...grpc.CallOption) (*GetLineupStatsResponse, error)
+	// ImportStats bulk loads historical stat lines streamed as CSV or JSON Lines, creating
+	// the teams, players and games they name. It is only served over gRPC. When it fails,
+	// the ImportStatsResponse of the batches committed so far is a detail of the error.
+	ImportStats(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportStatsRequest, ImportStatsResponse], error)
+	// ExportStats streams the stat lines, season averages or box scores of a season, team
+	// or player as a CSV, JSON Lines or Parquet file, in chunks. Through the gateway the
+	// file is the response body.
+	ExportStats(ctx context.Context, in *ExportStatsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportStatsChunk], error)
 }
 
 type playerGameServiceClient struct {
@@ -83,14 +133,193 @@ func (c *playerGameServiceClient) GetTeamSeasonStats(ctx context.Context, in *Ge
 	return out, nil
 }
 
+func (c *playerGameServiceClient) ValidateGame(ctx context.Context, in *ValidateGameRequest, opts ...grpc.CallOption) (*ValidateGameResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateGameResponse)
+	err := c.cc.Invoke(ctx, PlayerGameService_ValidateGame_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerGameServiceClient) WatchGame(ctx context.Context, in *WatchGameRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GameEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PlayerGameService_ServiceDesc.Streams[0], PlayerGameService_WatchGame_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchGameRequest, GameEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PlayerGameService_WatchGameClient = grpc.ServerStreamingClient[GameEvent]
+
+func (c *playerGameServiceClient) WatchPlayer(ctx context.Context, in *WatchPlayerRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GameEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PlayerGameService_ServiceDesc.Streams[1], PlayerGameService_WatchPlayer_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchPlayerRequest, GameEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PlayerGameService_WatchPlayerClient = grpc.ServerStreamingClient[GameEvent]
+
+func (c *playerGameServiceClient) RecordPlays(ctx context.Context, in *RecordPlaysRequest, opts ...grpc.CallOption) (*RecordPlaysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordPlaysResponse)
+	err := c.cc.Invoke(ctx, PlayerGameService_RecordPlays_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerGameServiceClient) ListPlays(ctx context.Context, in *ListPlaysRequest, opts ...grpc.CallOption) (*ListPlaysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPlaysResponse)
+	err := c.cc.Invoke(ctx, PlayerGameService_ListPlays_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerGameServiceClient) EditPlay(ctx context.Context, in *EditPlayRequest, opts ...grpc.CallOption) (*EditPlayResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EditPlayResponse)
+	err := c.cc.Invoke(ctx, PlayerGameService_EditPlay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerGameServiceClient) DeletePlay(ctx context.Context, in *DeletePlayRequest, opts ...grpc.CallOption) (*DeletePlayResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeletePlayResponse)
+	err := c.cc.Invoke(ctx, PlayerGameService_DeletePlay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerGameServiceClient) RecordStints(ctx context.Context, in *RecordStintsRequest, opts ...grpc.CallOption) (*RecordStintsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordStintsResponse)
+	err := c.cc.Invoke(ctx, PlayerGameService_RecordStints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerGameServiceClient) GetLineupStats(ctx context.Context, in *GetLineupStatsRequest, opts ...grpc.CallOption) (*GetLineupStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLineupStatsResponse)
+	err := c.cc.Invoke(ctx, PlayerGameService_GetLineupStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerGameServiceClient) ImportStats(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportStatsRequest, ImportStatsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PlayerGameService_ServiceDesc.Streams[2], PlayerGameService_ImportStats_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportStatsRequest, ImportStatsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PlayerGameService_ImportStatsClient = grpc.ClientStreamingClient[ImportStatsRequest, ImportStatsResponse]
+
+func (c *playerGameServiceClient) ExportStats(ctx context.Context, in *ExportStatsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportStatsChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PlayerGameService_ServiceDesc.Streams[3], PlayerGameService_ExportStats_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportStatsRequest, ExportStatsChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PlayerGameService_ExportStatsClient = grpc.ServerStreamingClient[ExportStatsChunk]
+
 // PlayerGameServiceServer is the server API for PlayerGameService service.
 // All implementations must embed UnimplementedPlayerGameServiceServer
 // for forward compatibility.
+//
+// PlayerGameService records the stat lines of players and serves their season averages.
+// Reads require the reader role and writes the scorekeeper role.
 type PlayerGameServiceServer interface {
+	// GetPlayer looks up a player.
 	GetPlayer(context.Context, *GetPlayerRequest) (*GetPlayerResponse, error)
+	// LogPlayerGame records the stat line of a player in a game.
 	LogPlayerGame(context.Context, *LogPlayerGameRequest) (*LogGameResponse, error)
+	// GetPlayerGameSeasonStats returns the per game averages of a player over a season.
 	GetPlayerGameSeasonStats(context.Context, *GetPlayerGameSeasonStatsRequest) (*PlayerGameSeasonStatsResponse, error)
+	// GetTeamSeasonStats returns the per game averages of a team over a season.
 	GetTeamSeasonStats(context.Context, *GetTeamsSeasonStatsRequest) (*TeamsSeasonStatsResponse, error)
+	// ValidateGame checks that the stat lines of a game belong to rostered players and add
+	// up to the final score.
+	ValidateGame(context.Context, *ValidateGameRequest) (*ValidateGameResponse, error)
+	// WatchGame streams the stat lines and score changes of a game as they are committed.
+	// Through the gateway the events arrive as Server-Sent Events.
+	WatchGame(*WatchGameRequest, grpc.ServerStreamingServer[GameEvent]) error
+	// WatchPlayer streams the stat lines of a player as they are committed.
+	// Through the gateway the events arrive as Server-Sent Events.
+	WatchPlayer(*WatchPlayerRequest, grpc.ServerStreamingServer[GameEvent]) error
+	// RecordPlays appends plays to the play-by-play of a game and re-derives the stat
+	// lines of its players from it, replacing lines logged with LogPlayerGame.
+	RecordPlays(context.Context, *RecordPlaysRequest) (*RecordPlaysResponse, error)
+	// ListPlays returns the play-by-play of a game in order.
+	ListPlays(context.Context, *ListPlaysRequest) (*ListPlaysResponse, error)
+	// EditPlay replaces a recorded play and re-derives the stat lines it changes.
+	EditPlay(context.Context, *EditPlayRequest) (*EditPlayResponse, error)
+	// DeletePlay removes a recorded play and re-derives the stat lines it changes.
+	DeletePlay(context.Context, *DeletePlayRequest) (*DeletePlayResponse, error)
+	// RecordStints replaces the stints of a team in a game scored without play-by-play;
+	// games with play-by-play derive their stints from the plays.
+	RecordStints(context.Context, *RecordStintsRequest) (*RecordStintsResponse, error)
+	// GetLineupStats returns the five-man lineups and two-man combinations of a team over
+	// a season, each ordered by minutes played.
+	GetLineupStats(context.Context, *GetLineupStatsRequest) (*GetLineupStatsResponse, error)
+	// ImportStats bulk loads historical stat lines streamed as CSV or JSON Lines, creating
+	// the teams, players and games they name. It is only served over gRPC. When it fails,
+	// the ImportStatsResponse of the batches committed so far is a detail of the error.
+	ImportStats(grpc.ClientStreamingServer[ImportStatsRequest, ImportStatsResponse]) error
+	// ExportStats streams the stat lines, season averages or box scores of a season, team
+	// or player as a CSV, JSON Lines or Parquet file, in chunks. Through the gateway the
+	// file is the response body.
+	ExportStats(*ExportStatsRequest, grpc.ServerStreamingServer[ExportStatsChunk]) error
 	mustEmbedUnimplementedPlayerGameServiceServer()
 }
 
@@ -113,6 +342,39 @@ func (UnimplementedPlayerGameServiceServer) GetPlayerGameSeasonStats(context.Con
 func (UnimplementedPlayerGameServiceServer) GetTeamSeasonStats(context.Context, *GetTeamsSeasonStatsRequest) (*TeamsSeasonStatsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTeamSeasonStats not implemented")
 }
+func (UnimplementedPlayerGameServiceServer) ValidateGame(context.Context, *ValidateGameRequest) (*ValidateGameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateGame not implemented")
+}
+func (UnimplementedPlayerGameServiceServer) WatchGame(*WatchGameRequest, grpc.ServerStreamingServer[GameEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchGame not implemented")
+}
+func (UnimplementedPlayerGameServiceServer) WatchPlayer(*WatchPlayerRequest, grpc.ServerStreamingServer[GameEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchPlayer not implemented")
+}
+func (UnimplementedPlayerGameServiceServer) RecordPlays(context.Context, *RecordPlaysRequest) (*RecordPlaysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordPlays not implemented")
+}
+func (UnimplementedPlayerGameServiceServer) ListPlays(context.Context, *ListPlaysRequest) (*ListPlaysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPlays not implemented")
+}
+func (UnimplementedPlayerGameServiceServer) EditPlay(context.Context, *EditPlayRequest) (*EditPlayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditPlay not implemented")
+}
+func (UnimplementedPlayerGameServiceServer) DeletePlay(context.Context, *DeletePlayRequest) (*DeletePlayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePlay not implemented")
+}
+func (UnimplementedPlayerGameServiceServer) RecordStints(context.Context, *RecordStintsRequest) (*RecordStintsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordStints not implemented")
+}
+func (UnimplementedPlayerGameServiceServer) GetLineupStats(context.Context, *GetLineupStatsRequest) (*GetLineupStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLineupStats not implemented")
+}
+func (UnimplementedPlayerGameServiceServer) ImportStats(grpc.ClientStreamingServer[ImportStatsRequest, ImportStatsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportStats not implemented")
+}
+func (UnimplementedPlayerGameServiceServer) ExportStats(*ExportStatsRequest, grpc.ServerStreamingServer[ExportStatsChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportStats not implemented")
+}
 func (UnimplementedPlayerGameServiceServer) mustEmbedUnimplementedPlayerGameServiceServer() {}
 func (UnimplementedPlayerGameServiceServer) testEmbeddedByValue()                           {}
 
@@ -206,6 +468,172 @@ func _PlayerGameService_GetTeamSeasonStats_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PlayerGameService_ValidateGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateGameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerGameServiceServer).ValidateGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerGameService_ValidateGame_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerGameServiceServer).ValidateGame(ctx, req.(*ValidateGameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlayerGameService_WatchGame_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchGameRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PlayerGameServiceServer).WatchGame(m, &grpc.GenericServerStream[WatchGameRequest, GameEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PlayerGameService_WatchGameServer = grpc.ServerStreamingServer[GameEvent]
+
+func _PlayerGameService_WatchPlayer_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchPlayerRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PlayerGameServiceServer).WatchPlayer(m, &grpc.GenericServerStream[WatchPlayerRequest, GameEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PlayerGameService_WatchPlayerServer = grpc.ServerStreamingServer[GameEvent]
+
+func _PlayerGameService_RecordPlays_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordPlaysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerGameServiceServer).RecordPlays(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerGameService_RecordPlays_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerGameServiceServer).RecordPlays(ctx, req.(*RecordPlaysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlayerGameService_ListPlays_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPlaysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerGameServiceServer).ListPlays(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerGameService_ListPlays_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerGameServiceServer).ListPlays(ctx, req.(*ListPlaysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlayerGameService_EditPlay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditPlayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerGameServiceServer).EditPlay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerGameService_EditPlay_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerGameServiceServer).EditPlay(ctx, req.(*EditPlayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlayerGameService_DeletePlay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePlayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerGameServiceServer).DeletePlay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerGameService_DeletePlay_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerGameServiceServer).DeletePlay(ctx, req.(*DeletePlayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlayerGameService_RecordStints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordStintsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerGameServiceServer).RecordStints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerGameService_RecordStints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerGameServiceServer).RecordStints(ctx, req.(*RecordStintsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlayerGameService_GetLineupStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLineupStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerGameServiceServer).GetLineupStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerGameService_GetLineupStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerGameServiceServer).GetLineupStats(ctx, req.(*GetLineupStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlayerGameService_ImportStats_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PlayerGameServiceServer).ImportStats(&grpc.GenericServerStream[ImportStatsRequest, ImportStatsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PlayerGameService_ImportStatsServer = grpc.ClientStreamingServer[ImportStatsRequest, ImportStatsResponse]
+
+func _PlayerGameService_ExportStats_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportStatsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PlayerGameServiceServer).ExportStats(m, &grpc.GenericServerStream[ExportStatsRequest, ExportStatsChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PlayerGameService_ExportStatsServer = grpc.ServerStreamingServer[ExportStatsChunk]
+
 // PlayerGameService_ServiceDesc is the grpc.ServiceDesc for PlayerGameService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,6 +657,283 @@ var PlayerGameService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTeamSeasonStats",
 			Handler:    _PlayerGameService_GetTeamSeasonStats_Handler,
 		},
+		{
+			MethodName: "ValidateGame",
+			Handler:    _PlayerGameService_ValidateGame_Handler,
+		},
+		{
+			MethodName: "RecordPlays",
+			Handler:    _PlayerGameService_RecordPlays_Handler,
+		},
+		{
+			MethodName: "ListPlays",
+			Handler:    _PlayerGameService_ListPlays_Handler,
+		},
+		{
+			MethodName: "EditPlay",
+			Handler:    _PlayerGameService_EditPlay_Handler,
+		},
+		{
+			MethodName: "DeletePlay",
+			Handler:    _PlayerGameService_DeletePlay_Handler,
+		},
+		{
+			MethodName: "RecordStints",
+			Handler:    _PlayerGameService_RecordStints_Handler,
+		},
+		{
+			MethodName: "GetLineupStats",
+			Handler:    _PlayerGameService_GetLineupStats_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchGame",
+			Handler:       _PlayerGameService_WatchGame_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchPlayer",
+			Handler:       _PlayerGameService_WatchPlayer_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportStats",
+			Handler:       _PlayerGameService_ImportStats_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportStats",
+			Handler:       _PlayerGameService_ExportStats_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "player_game.proto",
+}
+
+const (
+	AdminService_CreateAPIKey_FullMethodName  = "/pb.AdminService/CreateAPIKey"
+	AdminService_ListAPIKeys_FullMethodName   = "/pb.AdminService/ListAPIKeys"
+	AdminService_RevokeAPIKey_FullMethodName  = "/pb.AdminService/RevokeAPIKey"
+	AdminService_GetQuotaUsage_FullMethodName = "/pb.AdminService/GetQuotaUsage"
+)
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AdminService manages the credentials of the service. Every RPC requires the admin role.
+type AdminServiceClient interface {
+	// CreateAPIKey creates an API key; the key is only returned in this response.
+	CreateAPIKey(ctx context.Context, in *CreateAPIKeyRequest, opts ...grpc.CallOption) (*CreateAPIKeyResponse, error)
+	// ListAPIKeys lists the stored API keys, revoked ones included.
+	ListAPIKeys(ctx context.Context, in *ListAPIKeysRequest, opts ...grpc.CallOption) (*ListAPIKeysResponse, error)
+	// RevokeAPIKey revokes an API key; callers using it are rejected from then on.
+	RevokeAPIKey(ctx context.Context, in *RevokeAPIKeyRequest, opts ...grpc.CallOption) (*RevokeAPIKeyResponse, error)
+	// GetQuotaUsage returns the daily quota consumption of an API key.
+	GetQuotaUsage(ctx context.Context, in *GetQuotaUsageRequest, opts ...grpc.CallOption) (*GetQuotaUsageResponse, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) CreateAPIKey(ctx context.Context, in *CreateAPIKeyRequest, opts ...grpc.CallOption) (*CreateAPIKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateAPIKeyResponse)
+	err := c.cc.Invoke(ctx, AdminService_CreateAPIKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListAPIKeys(ctx context.Context, in *ListAPIKeysRequest, opts ...grpc.CallOption) (*ListAPIKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAPIKeysResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListAPIKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RevokeAPIKey(ctx context.Context, in *RevokeAPIKeyRequest, opts ...grpc.CallOption) (*RevokeAPIKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeAPIKeyResponse)
+	err := c.cc.Invoke(ctx, AdminService_RevokeAPIKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetQuotaUsage(ctx context.Context, in *GetQuotaUsageRequest, opts ...grpc.CallOption) (*GetQuotaUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetQuotaUsageResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetQuotaUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+// All implementations must embed UnimplementedAdminServiceServer
+// for forward compatibility.
+//
+// AdminService manages the credentials of the service. Every RPC requires the admin role.
+type AdminServiceServer interface {
+	// CreateAPIKey creates an API key; the key is only returned in this response.
+	CreateAPIKey(context.Context, *CreateAPIKeyRequest) (*CreateAPIKeyResponse, error)
+	// ListAPIKeys lists the stored API keys, revoked ones included.
+	ListAPIKeys(context.Context, *ListAPIKeysRequest) (*ListAPIKeysResponse, error)
+	// RevokeAPIKey revokes an API key; callers using it are rejected from then on.
+	RevokeAPIKey(context.Context, *RevokeAPIKeyRequest) (*RevokeAPIKeyResponse, error)
+	// GetQuotaUsage returns the daily quota consumption of an API key.
+	GetQuotaUsage(context.Context, *GetQuotaUsageRequest) (*GetQuotaUsageResponse, error)
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+// UnimplementedAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAdminServiceServer struct{}
+
+func (UnimplementedAdminServiceServer) CreateAPIKey(context.Context, *CreateAPIKeyRequest) (*CreateAPIKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAPIKey not implemented")
+}
+func (UnimplementedAdminServiceServer) ListAPIKeys(context.Context, *ListAPIKeysRequest) (*ListAPIKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAPIKeys not implemented")
+}
+func (UnimplementedAdminServiceServer) RevokeAPIKey(context.Context, *RevokeAPIKeyRequest) (*RevokeAPIKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAPIKey not implemented")
+}
+func (UnimplementedAdminServiceServer) GetQuotaUsage(context.Context, *GetQuotaUsageRequest) (*GetQuotaUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuotaUsage not implemented")
+}
+func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
+func (UnimplementedAdminServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServiceServer will
+// result in compilation errors.
+type UnsafeAdminServiceServer interface {
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+func RegisterAdminServiceServer(s grpc.ServiceRegistrar, srv AdminServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AdminService_ServiceDesc, srv)
+}
+
+func _AdminService_CreateAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).CreateAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_CreateAPIKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).CreateAPIKey(ctx, req.(*CreateAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListAPIKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAPIKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListAPIKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListAPIKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListAPIKeys(ctx, req.(*ListAPIKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RevokeAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RevokeAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_RevokeAPIKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RevokeAPIKey(ctx, req.(*RevokeAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetQuotaUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuotaUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetQuotaUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetQuotaUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetQuotaUsage(ctx, req.(*GetQuotaUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateAPIKey",
+			Handler:    _AdminService_CreateAPIKey_Handler,
+		},
+		{
+			MethodName: "ListAPIKeys",
+			Handler:    _AdminService_ListAPIKeys_Handler,
+		},
+		{
+			MethodName: "RevokeAPIKey",
+			Handler:    _AdminService_RevokeAPIKey_Handler,
+		},
+		{
+			MethodName: "GetQuotaUsage",
+			Handler:    _AdminService_GetQuotaUsage_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "player_game.proto",
@@ -0,0 +1,312 @@
+// Package plays derives the stat lines of a game from its play-by-play.
+package plays
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+
+	"nba/model"
+)
+
+// CourtSize is how many players of a team are on the court at once.
+const CourtSize = 5
+
+// Error is returned for a play that is malformed or does not fit the plays before it.
+type Error struct {
+	Sequence int
+	Message  string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("play %d: %s", e.Sequence, e.Message)
+}
+
+func errorf(play model.Play, format string, args ...any) error {
+	return &Error{Sequence: play.Sequence, Message: fmt.Sprintf(format, args...)}
+}
+
+// Points returns what a made shot of type t is worth, or 0 for an unknown type.
+func Points(t model.ShotType) int {
+	switch t {
+	case model.ShotFreeThrow:
+		return 1
+	case model.ShotTwoPointer:
+		return 2
+	case model.ShotThreePointer:
+		return 3
+	}
+	return 0
+}
+
+// Check validates a single play on its own.
+func Check(play model.Play) error {
+	if play.Period < 1 {
+		return errorf(play, "period must be at least 1")
+	}
+	if play.Elapsed < 0 {
+		return errorf(play, "elapsed time must not be negative")
+	}
+	if play.PlayerID < 0 || play.ReplacedPlayerID < 0 {
+		return errorf(play, "player IDs must be positive integers")
+	}
+	if play.ShotType != "" && play.Type != model.PlayShotMade && play.Type != model.PlayShotMissed {
+		return errorf(play, "only shots have a shot type")
+	}
+	if play.ReplacedPlayerID != 0 && play.Type != model.PlaySubstitution {
+		return errorf(play, "only substitutions replace a player")
+	}
+
+	switch play.Type {
+	case model.PlayShotMade, model.PlayShotMissed:
+		if Points(play.ShotType) == 0 {
+			return errorf(play, "unknown shot type %q", play.ShotType)
+		}
+		fallthrough
+	case model.PlayRebound, model.PlayAssist, model.PlaySteal, model.PlayBlock, model.PlayTurnover, model.PlayFoul:
+		if play.PlayerID == 0 {
+			return errorf(play, "%s needs a player", play.Type)
+		}
+	case model.PlaySubstitution:
+		if play.PlayerID == 0 && play.ReplacedPlayerID == 0 {
+			return errorf(play, "substitution needs a player coming in or going out")
+		}
+		if play.PlayerID == play.ReplacedPlayerID {
+			return errorf(play, "player %d cannot replace themselves", play.PlayerID)
+		}
+	case model.PlayPeriodStart, model.PlayPeriodEnd:
+		if play.PlayerID != 0 {
+			return errorf(play, "%s has no player", play.Type)
+		}
+	default:
+		return errorf(play, "unknown play type %q", play.Type)
+	}
+	return nil
+}
+
+// Players returns the players taking part in plays, in the order they first appear.
+func Players(plays []model.Play) []int {
+	var players []int
+	for _, play := range plays {
+		for _, id := range []int{play.PlayerID, play.ReplacedPlayerID} {
+			if id != 0 && !slices.Contains(players, id) {
+				players = append(players, id)
+			}
+		}
+	}
+	return players
+}
+
+// Derive replays the plays of a game, in sequence order, into the stat line of every
+// player taking part, ordered by player, and the stints of each team, ordered by team
+// and time. teams maps each player to their team.
+//
+// Minutes run while a player is on the court during a period. Players come in and go
+// out by substitutions; the starters come in without anyone going out, and the players
+// on the court when a period ends start the next one. A period that is still running
+// counts up to its last play, so the lines of a live game stay current. A stint ends
+// whenever its team substitutes or the period ends; plus-minus adds up the stints of
+// each player.
+func Derive(gameID int, plays []model.Play, teams map[int]int) ([]model.PlayerGameStats, []model.Stint, error) {
+	r := &replay{
+		gameID:  gameID,
+		teams:   teams,
+		lines:   make(map[int]*model.PlayerGameStats),
+		onCourt: make(map[int]time.Duration),
+		minutes: make(map[int]time.Duration),
+		open:    make(map[int]*model.Stint),
+	}
+	for i, play := range plays {
+		if err := r.apply(play, i == 0); err != nil {
+			return nil, nil, err
+		}
+	}
+	if r.running {
+		r.clockOut(r.last.Elapsed)
+		r.closeStints(r.last.Elapsed)
+	}
+
+	slices.SortFunc(r.stints, func(a, b model.Stint) int {
+		return cmp.Or(cmp.Compare(a.TeamID, b.TeamID), cmp.Compare(a.Period, b.Period), cmp.Compare(a.Start, b.Start))
+	})
+	for _, stint := range r.stints {
+		for _, id := range stint.PlayerIDs {
+			r.lines[id].PlusMinus += stint.PointsFor - stint.PointsAgainst
+		}
+	}
+	lines := make([]model.PlayerGameStats, 0, len(r.lines))
+	for id, line := range r.lines {
+		line.GameID = gameID
+		line.MinutesPlayed = float32(r.minutes[id].Minutes())
+		lines = append(lines, *line)
+	}
+	slices.SortFunc(lines, func(a, b model.PlayerGameStats) int { return cmp.Compare(a.PlayerID, b.PlayerID) })
+	return lines, r.stints, nil
+}
+
+// replay is the state of a game while its plays are applied.
+type replay struct {
+	gameID int
+	teams  map[int]int
+	lines  map[int]*model.PlayerGameStats
+	// onCourt holds the players on the court, with the time they started playing in
+	// the running period
+	onCourt map[int]time.Duration
+	minutes map[int]time.Duration
+	period  int // the running or last period
+	running bool
+	last    model.Play
+	// open holds the stint of each team on the court while a period runs
+	open   map[int]*model.Stint
+	stints []model.Stint
+}
+
+func (r *replay) apply(play model.Play, first bool) error {
+	if err := Check(play); err != nil {
+		return err
+	}
+	if !first && (play.Period < r.last.Period || play.Period == r.last.Period && play.Elapsed < r.last.Elapsed) {
+		return errorf(play, "happens before play %d", r.last.Sequence)
+	}
+	if r.running && play.Period != r.period {
+		return errorf(play, "is in period %d, but period %d has not ended", play.Period, r.period)
+	}
+	r.last = play
+
+	for _, id := range []int{play.PlayerID, play.ReplacedPlayerID} {
+		if id != 0 && r.lines[id] == nil {
+			r.lines[id] = &model.PlayerGameStats{PlayerID: id, TeamID: r.teams[id]}
+		}
+	}
+	line := r.lines[play.PlayerID]
+
+	switch play.Type {
+	case model.PlayPeriodStart:
+		if play.Period <= r.period {
+			return errorf(play, "period %d has already started", play.Period)
+		}
+		r.period, r.running = play.Period, true
+		for id := range r.onCourt {
+			r.onCourt[id] = play.Elapsed
+			r.openStint(r.teams[id], play.Elapsed)
+		}
+	case model.PlayPeriodEnd:
+		if !r.running {
+			return errorf(play, "period %d has not started", play.Period)
+		}
+		r.clockOut(play.Elapsed)
+		r.closeStints(play.Elapsed)
+		r.running = false
+	case model.PlaySubstitution:
+		changed := []int{r.teams[play.PlayerID], r.teams[play.ReplacedPlayerID]}
+		if r.running {
+			for _, teamID := range changed {
+				r.closeStint(teamID, play.Elapsed)
+			}
+		}
+		if out := play.ReplacedPlayerID; out != 0 {
+			since, ok := r.onCourt[out]
+			if !ok {
+				return errorf(play, "player %d is not on the court", out)
+			}
+			if r.running {
+				r.minutes[out] += play.Elapsed - since
+			}
+			delete(r.onCourt, out)
+		}
+		if in := play.PlayerID; in != 0 {
+			if _, ok := r.onCourt[in]; ok {
+				return errorf(play, "player %d is already on the court", in)
+			}
+			r.onCourt[in] = play.Elapsed
+			if r.onCourtFor(r.teams[in]) > CourtSize {
+				return errorf(play, "team %d has more than %d players on the court", r.teams[in], CourtSize)
+			}
+		}
+		if r.running {
+			for _, teamID := range changed {
+				r.openStint(teamID, play.Elapsed)
+			}
+		}
+	case model.PlayShotMade:
+		points := Points(play.ShotType)
+		line.Points += points
+		for teamID, stint := range r.open {
+			if teamID == line.TeamID {
+				stint.PointsFor += points
+			} else {
+				stint.PointsAgainst += points
+			}
+		}
+	case model.PlayRebound:
+		line.Rebounds++
+	case model.PlayAssist:
+		line.Assists++
+	case model.PlaySteal:
+		line.Steals++
+	case model.PlayBlock:
+		line.Blocks++
+	case model.PlayTurnover:
+		line.Turnovers++
+	case model.PlayFoul:
+		line.Fouls++
+	}
+	return nil
+}
+
+// clockOut adds the time up to at to the minutes of the players on the court.
+func (r *replay) clockOut(at time.Duration) {
+	for id, since := range r.onCourt {
+		r.minutes[id] += at - since
+	}
+}
+
+// openStint starts a stint of the players of a team on the court, if there are any
+// and it has none running.
+func (r *replay) openStint(teamID int, at time.Duration) {
+	if _, ok := r.open[teamID]; ok {
+		return
+	}
+	var players []int
+	for id := range r.onCourt {
+		if r.teams[id] == teamID {
+			players = append(players, id)
+		}
+	}
+	if len(players) == 0 {
+		return
+	}
+	slices.Sort(players)
+	r.open[teamID] = &model.Stint{GameID: r.gameID, TeamID: teamID, Period: r.period, Start: at, PlayerIDs: players}
+}
+
+// closeStint ends the running stint of a team. Stints in which no time ran and nobody
+// scored, like those between substitutions made at once, are dropped.
+func (r *replay) closeStint(teamID int, at time.Duration) {
+	stint, ok := r.open[teamID]
+	if !ok {
+		return
+	}
+	delete(r.open, teamID)
+	stint.End = at
+	if stint.End > stint.Start || stint.PointsFor > 0 || stint.PointsAgainst > 0 {
+		r.stints = append(r.stints, *stint)
+	}
+}
+
+func (r *replay) closeStints(at time.Duration) {
+	for teamID := range r.open {
+		r.closeStint(teamID, at)
+	}
+}
+
+func (r *replay) onCourtFor(teamID int) int {
+	n := 0
+	for id := range r.onCourt {
+		if r.teams[id] == teamID {
+			n++
+		}
+	}
+	return n
+}
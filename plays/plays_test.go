@@ -0,0 +1,144 @@
+package plays_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"nba/model"
+	"nba/plays"
+)
+
+func at(period int, minutes int, play model.Play) model.Play {
+	play.Period = period
+	play.Elapsed = time.Duration(minutes) * time.Minute
+	return play
+}
+
+// sequence numbers plays from 1, as they are recorded.
+func sequence(ps ...model.Play) []model.Play {
+	for i := range ps {
+		ps[i].GameID = 1
+		ps[i].Sequence = i + 1
+	}
+	return ps
+}
+
+func TestDerive(t *testing.T) {
+	teams := map[int]int{7: 1, 8: 1, 9: 2}
+	ps := sequence(
+		at(1, 0, model.Play{Type: model.PlaySubstitution, PlayerID: 7}),
+		at(1, 0, model.Play{Type: model.PlaySubstitution, PlayerID: 9}),
+		at(1, 0, model.Play{Type: model.PlayPeriodStart}),
+		at(1, 2, model.Play{Type: model.PlayShotMade, PlayerID: 7, ShotType: model.ShotThreePointer}),
+		at(1, 3, model.Play{Type: model.PlayShotMissed, PlayerID: 9, ShotType: model.ShotTwoPointer}),
+		at(1, 3, model.Play{Type: model.PlayRebound, PlayerID: 7}),
+		at(1, 4, model.Play{Type: model.PlayFoul, PlayerID: 9}),
+		at(1, 4, model.Play{Type: model.PlayShotMade, PlayerID: 7, ShotType: model.ShotFreeThrow}),
+		at(1, 5, model.Play{Type: model.PlaySubstitution, PlayerID: 8, ReplacedPlayerID: 7}),
+		at(1, 6, model.Play{Type: model.PlayShotMade, PlayerID: 9, ShotType: model.ShotTwoPointer}),
+		at(1, 6, model.Play{Type: model.PlayAssist, PlayerID: 9}),
+		at(1, 12, model.Play{Type: model.PlayPeriodEnd}),
+		// 8 and 9 start the second period, which is still running
+		at(2, 0, model.Play{Type: model.PlayPeriodStart}),
+		at(2, 3, model.Play{Type: model.PlayTurnover, PlayerID: 8}),
+		at(2, 4, model.Play{Type: model.PlaySteal, PlayerID: 9}),
+	)
+
+	lines, stints, err := plays.Derive(1, ps, teams)
+	if err != nil {
+		t.Fatalf("Derive: %v", err)
+	}
+	want := []model.PlayerGameStats{
+		{PlayerID: 7, GameID: 1, TeamID: 1, Points: 4, Rebounds: 1, MinutesPlayed: 5, PlusMinus: 4},
+		{PlayerID: 8, GameID: 1, TeamID: 1, Turnovers: 1, MinutesPlayed: 11, PlusMinus: -2},
+		{PlayerID: 9, GameID: 1, TeamID: 2, Points: 2, Assists: 1, Steals: 1, Fouls: 1, MinutesPlayed: 16, PlusMinus: -2},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("lines = %+v, want %+v", lines, want)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d = %+v, want %+v", i, lines[i], want[i])
+		}
+	}
+
+	// Team 1 changes its lineup at 5:00; team 2 keeps its lineup until the end of the period
+	wantStints := []model.Stint{
+		{GameID: 1, TeamID: 1, Period: 1, Start: 0, End: 5 * time.Minute, PlayerIDs: []int{7}, PointsFor: 4},
+		{GameID: 1, TeamID: 1, Period: 1, Start: 5 * time.Minute, End: 12 * time.Minute, PlayerIDs: []int{8}, PointsAgainst: 2},
+		{GameID: 1, TeamID: 1, Period: 2, Start: 0, End: 4 * time.Minute, PlayerIDs: []int{8}},
+		{GameID: 1, TeamID: 2, Period: 1, Start: 0, End: 12 * time.Minute, PlayerIDs: []int{9}, PointsFor: 2, PointsAgainst: 4},
+		{GameID: 1, TeamID: 2, Period: 2, Start: 0, End: 4 * time.Minute, PlayerIDs: []int{9}},
+	}
+	if !reflect.DeepEqual(stints, wantStints) {
+		t.Errorf("stints = %+v, want %+v", stints, wantStints)
+	}
+}
+
+func TestDeriveDropsEmptyStints(t *testing.T) {
+	teams := map[int]int{1: 1, 2: 1, 3: 1}
+	_, stints, err := plays.Derive(1, sequence(
+		at(1, 0, model.Play{Type: model.PlaySubstitution, PlayerID: 1}),
+		at(1, 0, model.Play{Type: model.PlayPeriodStart}),
+		// Two substitutions at once leave a stint of no time in between
+		at(1, 6, model.Play{Type: model.PlaySubstitution, PlayerID: 2}),
+		at(1, 6, model.Play{Type: model.PlaySubstitution, PlayerID: 3, ReplacedPlayerID: 1}),
+		at(1, 12, model.Play{Type: model.PlayPeriodEnd}),
+	), teams)
+	if err != nil {
+		t.Fatalf("Derive: %v", err)
+	}
+	if len(stints) != 2 || !reflect.DeepEqual(stints[0].PlayerIDs, []int{1}) || !reflect.DeepEqual(stints[1].PlayerIDs, []int{2, 3}) {
+		t.Fatalf("stints = %+v, want [1] then [2 3]", stints)
+	}
+}
+
+func TestDeriveRejects(t *testing.T) {
+	teams := map[int]int{1: 1, 2: 1, 3: 1, 4: 1, 5: 1, 6: 1, 7: 2}
+	starters := func(ids ...int) []model.Play {
+		var ps []model.Play
+		for _, id := range ids {
+			ps = append(ps, at(1, 0, model.Play{Type: model.PlaySubstitution, PlayerID: id}))
+		}
+		return append(ps, at(1, 0, model.Play{Type: model.PlayPeriodStart}))
+	}
+	tests := []struct {
+		name     string
+		plays    []model.Play
+		sequence int
+	}{
+		{"unknown type", []model.Play{at(1, 0, model.Play{Type: "dunk", PlayerID: 1})}, 1},
+		{"unknown shot type", []model.Play{at(1, 0, model.Play{Type: model.PlayShotMade, PlayerID: 1, ShotType: "four_pointer"})}, 1},
+		{"stat without player", []model.Play{at(1, 0, model.Play{Type: model.PlayRebound})}, 1},
+		{"no period", []model.Play{{Type: model.PlayPeriodStart}}, 1},
+		{"out of order", append(starters(1), at(1, 5, model.Play{Type: model.PlayFoul, PlayerID: 1}), at(1, 4, model.Play{Type: model.PlayFoul, PlayerID: 1})), 4},
+		{"period still running", append(starters(1), at(2, 0, model.Play{Type: model.PlayPeriodStart})), 3},
+		{"period not started", []model.Play{at(1, 12, model.Play{Type: model.PlayPeriodEnd})}, 1},
+		{"substituted player not on court", append(starters(1), at(1, 1, model.Play{Type: model.PlaySubstitution, PlayerID: 2, ReplacedPlayerID: 3})), 3},
+		{"player already on court", append(starters(1), at(1, 1, model.Play{Type: model.PlaySubstitution, PlayerID: 1})), 3},
+		{"too many players", starters(1, 2, 3, 4, 5, 6), 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := plays.Derive(1, sequence(tt.plays...), teams)
+			var playErr *plays.Error
+			if !errors.As(err, &playErr) || playErr.Sequence != tt.sequence {
+				t.Fatalf("Derive error = %v, want an error on play %d", err, tt.sequence)
+			}
+		})
+	}
+}
+
+func TestPlayers(t *testing.T) {
+	got := plays.Players([]model.Play{
+		{Type: model.PlaySubstitution, PlayerID: 7},
+		{Type: model.PlayPeriodStart},
+		{Type: model.PlaySubstitution, PlayerID: 8, ReplacedPlayerID: 9},
+		{Type: model.PlayFoul, PlayerID: 7},
+	})
+	if len(got) != 3 || got[0] != 7 || got[1] != 8 || got[2] != 9 {
+		t.Fatalf("Players = %v, want [7 8 9]", got)
+	}
+}
@@ -0,0 +1,38 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// Dialect describes how a SQL database differs from Postgres, so the same repository
+// can run on it. Queries stick to SQL both databases understand, including $n placeholders.
+type Dialect struct {
+	Name string
+	// IsUniqueViolation reports whether err is a unique or primary key violation
+	IsUniqueViolation func(err error) bool
+	// IsRetryable reports whether a failed transaction can safely be run again
+	IsRetryable func(err error) bool
+	// SyncSequenceSQL returns the statement that moves the ID sequence of a table past
+	// explicitly inserted IDs. Nil for databases that don't need it.
+	SyncSequenceSQL func(table string) string
+}
+
+// PostgresDialect is the dialect of the Postgres repository.
+var PostgresDialect = &Dialect{
+	Name: "postgres",
+	IsUniqueViolation: func(err error) bool {
+		var pqErr *pq.Error
+		return errors.As(err, &pqErr) && pqErr.Code == "23505"
+	},
+	// Serialization failures and deadlocks
+	IsRetryable: func(err error) bool {
+		var pqErr *pq.Error
+		return errors.As(err, &pqErr) && (pqErr.Code == "40001" || pqErr.Code == "40P01")
+	},
+	SyncSequenceSQL: func(table string) string {
+		return fmt.Sprintf("SELECT setval(pg_get_serial_sequence('%[1]s', 'id'), (SELECT COALESCE(MAX(id), 1) FROM %[1]s))", table)
+	},
+}
@@ -0,0 +1,233 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Migration is a single, ordered schema change.
+type Migration struct {
+	Version     int
+	Description string
+	SQL         string
+}
+
+// Migrations is the schema history of the database. Statements are written so they can
+// also be applied on top of databases created before the schema was versioned.
+var Migrations = []Migration{
+	{
+		Version:     1,
+		Description: "create team, game, player and player_game_stats",
+		SQL: `
+		CREATE TABLE IF NOT EXISTS team (
+			id SERIAL PRIMARY KEY,
+			name VARCHAR(100) UNIQUE NOT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS game (
+			id SERIAL PRIMARY KEY,
+			date DATE NOT NULL,
+			season INT NOT NULL,
+			team_a_id INT NOT NULL,
+			team_b_id INT NOT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS player (
+			id SERIAL PRIMARY KEY,
+			name VARCHAR(100) NOT NULL,
+			current_team_id INT NOT NULL,
+			CONSTRAINT fk_team FOREIGN KEY (current_team_id) REFERENCES team (id) ON DELETE CASCADE
+		);
+
+		CREATE TABLE IF NOT EXISTS player_game_stats (
+			player_id INT NOT NULL,
+			game_id INT NOT NULL,
+			points INT,
+			assists INT,
+			rebounds INT,
+			steals INT,
+			blocks INT,
+			turnovers INT,
+			fouls INT,
+			minutes_played FLOAT,
+			PRIMARY KEY (player_id, game_id),
+			CONSTRAINT fk_player FOREIGN KEY (player_id) REFERENCES player (id) ON DELETE CASCADE,
+			CONSTRAINT fk_game FOREIGN KEY (game_id) REFERENCES game (id) ON DELETE CASCADE
+		);`,
+	},
+	{
+		Version:     2,
+		Description: "track league, overtimes and final score of games",
+		SQL: `
+		ALTER TABLE game
+			ADD COLUMN IF NOT EXISTS team_a_score INT,
+			ADD COLUMN IF NOT EXISTS team_b_score INT,
+			ADD COLUMN IF NOT EXISTS league VARCHAR(50) NOT NULL DEFAULT 'nba',
+			ADD COLUMN IF NOT EXISTS overtime_periods INT NOT NULL DEFAULT 0;`,
+	},
+	{
+		Version:     3,
+		Description: "add rosters and the team of each stat line",
+		SQL: `
+		ALTER TABLE player_game_stats
+			ADD COLUMN IF NOT EXISTS team_id INT;
+
+		CREATE TABLE IF NOT EXISTS roster (
+			player_id INT NOT NULL,
+			team_id INT NOT NULL,
+			start_date DATE NOT NULL,
+			end_date DATE,
+			PRIMARY KEY (player_id, team_id, start_date),
+			CONSTRAINT fk_player FOREIGN KEY (player_id) REFERENCES player (id) ON DELETE CASCADE,
+			CONSTRAINT fk_team FOREIGN KEY (team_id) REFERENCES team (id) ON DELETE CASCADE
+		);`,
+	},
+	{
+		Version:     4,
+		Description: "add idempotency keys",
+		SQL: `
+		CREATE TABLE IF NOT EXISTS idempotency_key (
+			key VARCHAR(255) PRIMARY KEY,
+			request_hash CHAR(64) NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		);`,
+	},
+	{
+		Version:     5,
+		Description: "add api keys",
+		SQL: `
+		CREATE TABLE IF NOT EXISTS api_key (
+			id SERIAL PRIMARY KEY,
+			name VARCHAR(100) NOT NULL,
+			prefix VARCHAR(16) NOT NULL,
+			hash CHAR(64) UNIQUE NOT NULL,
+			role VARCHAR(20) NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+			revoked_at TIMESTAMPTZ
+		);`,
+	},
+	{
+		Version:     6,
+		Description: "add plays",
+		SQL: `
+		CREATE TABLE IF NOT EXISTS play (
+			game_id INT NOT NULL,
+			sequence INT NOT NULL,
+			period INT NOT NULL,
+			elapsed_ms BIGINT NOT NULL,
+			type VARCHAR(20) NOT NULL,
+			player_id INT,
+			shot_type VARCHAR(20),
+			replaced_player_id INT,
+			PRIMARY KEY (game_id, sequence),
+			CONSTRAINT fk_game FOREIGN KEY (game_id) REFERENCES game (id) ON DELETE CASCADE,
+			CONSTRAINT fk_player FOREIGN KEY (player_id) REFERENCES player (id) ON DELETE CASCADE,
+			CONSTRAINT fk_replaced_player FOREIGN KEY (replaced_player_id) REFERENCES player (id) ON DELETE CASCADE
+		);`,
+	},
+	{
+		Version:     7,
+		Description: "add stints",
+		SQL: `
+		CREATE TABLE IF NOT EXISTS stint (
+			game_id INT NOT NULL,
+			team_id INT NOT NULL,
+			number INT NOT NULL,
+			period INT NOT NULL,
+			start_ms BIGINT NOT NULL,
+			end_ms BIGINT NOT NULL,
+			points_for INT NOT NULL,
+			points_against INT NOT NULL,
+			PRIMARY KEY (game_id, team_id, number),
+			CONSTRAINT fk_game FOREIGN KEY (game_id) REFERENCES game (id) ON DELETE CASCADE,
+			CONSTRAINT fk_team FOREIGN KEY (team_id) REFERENCES team (id) ON DELETE CASCADE
+		);
+
+		CREATE TABLE IF NOT EXISTS stint_player (
+			game_id INT NOT NULL,
+			team_id INT NOT NULL,
+			number INT NOT NULL,
+			player_id INT NOT NULL,
+			PRIMARY KEY (game_id, team_id, number, player_id),
+			CONSTRAINT fk_stint FOREIGN KEY (game_id, team_id, number) REFERENCES stint (game_id, team_id, number) ON DELETE CASCADE,
+			CONSTRAINT fk_player FOREIGN KEY (player_id) REFERENCES player (id) ON DELETE CASCADE
+		);
+
+		CREATE INDEX IF NOT EXISTS stint_player_player_id ON stint_player (player_id, game_id);`,
+	},
+	{
+		Version:     8,
+		Description: "add external player ids for imports",
+		SQL: `
+		ALTER TABLE player
+			ADD COLUMN IF NOT EXISTS external_id VARCHAR(100);
+
+		CREATE UNIQUE INDEX IF NOT EXISTS player_external_id ON player (external_id);
+		CREATE INDEX IF NOT EXISTS player_name ON player (name);
+		CREATE INDEX IF NOT EXISTS game_date ON game (date);`,
+	},
+}
+
+// Migrate applies every Postgres migration that has not been applied yet.
+func Migrate(ctx context.Context, db *sql.DB) error {
+	return ApplyMigrations(ctx, db, Migrations)
+}
+
+// ApplyMigrations applies every migration newer than the schema version, each in its own
+// transaction. The bookkeeping is plain SQL, so other databases can reuse it with their own migrations.
+func ApplyMigrations(ctx context.Context, db *sql.DB, migrations []Migration) error {
+	_, err := db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INT PRIMARY KEY,
+			description TEXT NOT NULL,
+			applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	current, err := SchemaVersion(ctx, db)
+	if err != nil {
+		return err
+	}
+	for _, m := range migrations {
+		if m.Version <= current {
+			continue
+		}
+		if err := applyMigration(ctx, db, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SchemaVersion returns the version of the last applied migration, or 0 if there is none.
+func SchemaVersion(ctx context.Context, db *sql.DB) (int, error) {
+	var version int
+	err := db.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version, nil
+}
+
+func applyMigration(ctx context.Context, db *sql.DB, m Migration) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, m.SQL); err != nil {
+		return fmt.Errorf("migration %d (%s) failed: %w", m.Version, m.Description, err)
+	}
+	_, err = tx.ExecContext(ctx,
+		"INSERT INTO schema_migrations (version, description) VALUES ($1, $2)",
+		m.Version, m.Description,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record migration %d: %w", m.Version, err)
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,549 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: player_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=player_repository.go -destination=mocks/player_repository.go -package=mocks -exclude_interfaces=querier
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	model "nba/model"
+	postgres "nba/postgres"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPlayerRepository is a mock of PlayerRepository interface.
+type MockPlayerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPlayerRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPlayerRepositoryMockRecorder is the mock recorder for MockPlayerRepository.
+type MockPlayerRepositoryMockRecorder struct {
+	mock *MockPlayerRepository
+}
+
+// NewMockPlayerRepository creates a new mock instance.
+func NewMockPlayerRepository(ctrl *gomock.Controller) *MockPlayerRepository {
+	mock := &MockPlayerRepository{ctrl: ctrl}
+	mock.recorder = &MockPlayerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPlayerRepository) EXPECT() *MockPlayerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeletePlay mocks base method.
+func (m *MockPlayerRepository) DeletePlay(ctx context.Context, gameId, sequence int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePlay", ctx, gameId, sequence)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePlay indicates an expected call of DeletePlay.
+func (mr *MockPlayerRepositoryMockRecorder) DeletePlay(ctx, gameId, sequence any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlay", reflect.TypeOf((*MockPlayerRepository)(nil).DeletePlay), ctx, gameId, sequence)
+}
+
+// DeletePlayerGame mocks base method.
+func (m *MockPlayerRepository) DeletePlayerGame(ctx context.Context, gameId, playerId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePlayerGame", ctx, gameId, playerId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePlayerGame indicates an expected call of DeletePlayerGame.
+func (mr *MockPlayerRepositoryMockRecorder) DeletePlayerGame(ctx, gameId, playerId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlayerGame", reflect.TypeOf((*MockPlayerRepository)(nil).DeletePlayerGame), ctx, gameId, playerId)
+}
+
+// GetAPIKey mocks base method.
+func (m *MockPlayerRepository) GetAPIKey(ctx context.Context, id int) (model.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKey", ctx, id)
+	ret0, _ := ret[0].(model.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKey indicates an expected call of GetAPIKey.
+func (mr *MockPlayerRepositoryMockRecorder) GetAPIKey(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKey", reflect.TypeOf((*MockPlayerRepository)(nil).GetAPIKey), ctx, id)
+}
+
+// GetAPIKeyByHash mocks base method.
+func (m *MockPlayerRepository) GetAPIKeyByHash(ctx context.Context, hash string) (model.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeyByHash", ctx, hash)
+	ret0, _ := ret[0].(model.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeyByHash indicates an expected call of GetAPIKeyByHash.
+func (mr *MockPlayerRepositoryMockRecorder) GetAPIKeyByHash(ctx, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeyByHash", reflect.TypeOf((*MockPlayerRepository)(nil).GetAPIKeyByHash), ctx, hash)
+}
+
+// GetGame mocks base method.
+func (m *MockPlayerRepository) GetGame(ctx context.Context, gameId int) (model.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGame", ctx, gameId)
+	ret0, _ := ret[0].(model.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGame indicates an expected call of GetGame.
+func (mr *MockPlayerRepositoryMockRecorder) GetGame(ctx, gameId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGame", reflect.TypeOf((*MockPlayerRepository)(nil).GetGame), ctx, gameId)
+}
+
+// GetGameByTeamsOnDate mocks base method.
+func (m *MockPlayerRepository) GetGameByTeamsOnDate(ctx context.Context, date time.Time, teamID, opponentID int) (model.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGameByTeamsOnDate", ctx, date, teamID, opponentID)
+	ret0, _ := ret[0].(model.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGameByTeamsOnDate indicates an expected call of GetGameByTeamsOnDate.
+func (mr *MockPlayerRepositoryMockRecorder) GetGameByTeamsOnDate(ctx, date, teamID, opponentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGameByTeamsOnDate", reflect.TypeOf((*MockPlayerRepository)(nil).GetGameByTeamsOnDate), ctx, date, teamID, opponentID)
+}
+
+// GetGameStats mocks base method.
+func (m *MockPlayerRepository) GetGameStats(ctx context.Context, gameId int) ([]model.PlayerGameStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGameStats", ctx, gameId)
+	ret0, _ := ret[0].([]model.PlayerGameStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGameStats indicates an expected call of GetGameStats.
+func (mr *MockPlayerRepositoryMockRecorder) GetGameStats(ctx, gameId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGameStats", reflect.TypeOf((*MockPlayerRepository)(nil).GetGameStats), ctx, gameId)
+}
+
+// GetIdempotencyKey mocks base method.
+func (m *MockPlayerRepository) GetIdempotencyKey(ctx context.Context, key string) (model.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdempotencyKey", ctx, key)
+	ret0, _ := ret[0].(model.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdempotencyKey indicates an expected call of GetIdempotencyKey.
+func (mr *MockPlayerRepositoryMockRecorder) GetIdempotencyKey(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdempotencyKey", reflect.TypeOf((*MockPlayerRepository)(nil).GetIdempotencyKey), ctx, key)
+}
+
+// GetPlayer mocks base method.
+func (m *MockPlayerRepository) GetPlayer(ctx context.Context, playerId int) (model.Player, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlayer", ctx, playerId)
+	ret0, _ := ret[0].(model.Player)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlayer indicates an expected call of GetPlayer.
+func (mr *MockPlayerRepositoryMockRecorder) GetPlayer(ctx, playerId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlayer", reflect.TypeOf((*MockPlayerRepository)(nil).GetPlayer), ctx, playerId)
+}
+
+// GetPlayerByExternalID mocks base method.
+func (m *MockPlayerRepository) GetPlayerByExternalID(ctx context.Context, externalID string) (model.Player, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlayerByExternalID", ctx, externalID)
+	ret0, _ := ret[0].(model.Player)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlayerByExternalID indicates an expected call of GetPlayerByExternalID.
+func (mr *MockPlayerRepositoryMockRecorder) GetPlayerByExternalID(ctx, externalID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlayerByExternalID", reflect.TypeOf((*MockPlayerRepository)(nil).GetPlayerByExternalID), ctx, externalID)
+}
+
+// GetPlayerGamesBySeason mocks base method.
+func (m *MockPlayerRepository) GetPlayerGamesBySeason(ctx context.Context, playerID, season int) ([]model.PlayerGameStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlayerGamesBySeason", ctx, playerID, season)
+	ret0, _ := ret[0].([]model.PlayerGameStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlayerGamesBySeason indicates an expected call of GetPlayerGamesBySeason.
+func (mr *MockPlayerRepositoryMockRecorder) GetPlayerGamesBySeason(ctx, playerID, season any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlayerGamesBySeason", reflect.TypeOf((*MockPlayerRepository)(nil).GetPlayerGamesBySeason), ctx, playerID, season)
+}
+
+// GetPlayerTeamOnDate mocks base method.
+func (m *MockPlayerRepository) GetPlayerTeamOnDate(ctx context.Context, playerId int, date time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlayerTeamOnDate", ctx, playerId, date)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlayerTeamOnDate indicates an expected call of GetPlayerTeamOnDate.
+func (mr *MockPlayerRepositoryMockRecorder) GetPlayerTeamOnDate(ctx, playerId, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlayerTeamOnDate", reflect.TypeOf((*MockPlayerRepository)(nil).GetPlayerTeamOnDate), ctx, playerId, date)
+}
+
+// GetPlayersByName mocks base method.
+func (m *MockPlayerRepository) GetPlayersByName(ctx context.Context, name string) ([]model.Player, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlayersByName", ctx, name)
+	ret0, _ := ret[0].([]model.Player)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlayersByName indicates an expected call of GetPlayersByName.
+func (mr *MockPlayerRepositoryMockRecorder) GetPlayersByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlayersByName", reflect.TypeOf((*MockPlayerRepository)(nil).GetPlayersByName), ctx, name)
+}
+
+// GetPlays mocks base method.
+func (m *MockPlayerRepository) GetPlays(ctx context.Context, gameId int) ([]model.Play, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlays", ctx, gameId)
+	ret0, _ := ret[0].([]model.Play)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlays indicates an expected call of GetPlays.
+func (mr *MockPlayerRepositoryMockRecorder) GetPlays(ctx, gameId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlays", reflect.TypeOf((*MockPlayerRepository)(nil).GetPlays), ctx, gameId)
+}
+
+// GetStatLines mocks base method.
+func (m *MockPlayerRepository) GetStatLines(ctx context.Context, filter model.StatLineFilter) ([]model.PlayerGameStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatLines", ctx, filter)
+	ret0, _ := ret[0].([]model.PlayerGameStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatLines indicates an expected call of GetStatLines.
+func (mr *MockPlayerRepositoryMockRecorder) GetStatLines(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatLines", reflect.TypeOf((*MockPlayerRepository)(nil).GetStatLines), ctx, filter)
+}
+
+// GetStints mocks base method.
+func (m *MockPlayerRepository) GetStints(ctx context.Context, gameId int) ([]model.Stint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStints", ctx, gameId)
+	ret0, _ := ret[0].([]model.Stint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStints indicates an expected call of GetStints.
+func (mr *MockPlayerRepositoryMockRecorder) GetStints(ctx, gameId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStints", reflect.TypeOf((*MockPlayerRepository)(nil).GetStints), ctx, gameId)
+}
+
+// GetTeam mocks base method.
+func (m *MockPlayerRepository) GetTeam(ctx context.Context, teamId int) (model.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeam", ctx, teamId)
+	ret0, _ := ret[0].(model.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeam indicates an expected call of GetTeam.
+func (mr *MockPlayerRepositoryMockRecorder) GetTeam(ctx, teamId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeam", reflect.TypeOf((*MockPlayerRepository)(nil).GetTeam), ctx, teamId)
+}
+
+// GetTeamByName mocks base method.
+func (m *MockPlayerRepository) GetTeamByName(ctx context.Context, name string) (model.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamByName", ctx, name)
+	ret0, _ := ret[0].(model.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamByName indicates an expected call of GetTeamByName.
+func (mr *MockPlayerRepositoryMockRecorder) GetTeamByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamByName", reflect.TypeOf((*MockPlayerRepository)(nil).GetTeamByName), ctx, name)
+}
+
+// GetTeamPlayersBySeason mocks base method.
+func (m *MockPlayerRepository) GetTeamPlayersBySeason(ctx context.Context, teamID, season int) ([]model.PlayerGameStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamPlayersBySeason", ctx, teamID, season)
+	ret0, _ := ret[0].([]model.PlayerGameStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamPlayersBySeason indicates an expected call of GetTeamPlayersBySeason.
+func (mr *MockPlayerRepositoryMockRecorder) GetTeamPlayersBySeason(ctx, teamID, season any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamPlayersBySeason", reflect.TypeOf((*MockPlayerRepository)(nil).GetTeamPlayersBySeason), ctx, teamID, season)
+}
+
+// GetTeamStintsBySeason mocks base method.
+func (m *MockPlayerRepository) GetTeamStintsBySeason(ctx context.Context, teamID, season int) ([]model.Stint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamStintsBySeason", ctx, teamID, season)
+	ret0, _ := ret[0].([]model.Stint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamStintsBySeason indicates an expected call of GetTeamStintsBySeason.
+func (mr *MockPlayerRepositoryMockRecorder) GetTeamStintsBySeason(ctx, teamID, season any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamStintsBySeason", reflect.TypeOf((*MockPlayerRepository)(nil).GetTeamStintsBySeason), ctx, teamID, season)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockPlayerRepository) ListAPIKeys(ctx context.Context) ([]model.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", ctx)
+	ret0, _ := ret[0].([]model.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockPlayerRepositoryMockRecorder) ListAPIKeys(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockPlayerRepository)(nil).ListAPIKeys), ctx)
+}
+
+// LogPlay mocks base method.
+func (m *MockPlayerRepository) LogPlay(ctx context.Context, play model.Play) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogPlay", ctx, play)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogPlay indicates an expected call of LogPlay.
+func (mr *MockPlayerRepositoryMockRecorder) LogPlay(ctx, play any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogPlay", reflect.TypeOf((*MockPlayerRepository)(nil).LogPlay), ctx, play)
+}
+
+// LogPlayerGame mocks base method.
+func (m *MockPlayerRepository) LogPlayerGame(ctx context.Context, game model.PlayerGameStats) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogPlayerGame", ctx, game)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogPlayerGame indicates an expected call of LogPlayerGame.
+func (mr *MockPlayerRepositoryMockRecorder) LogPlayerGame(ctx, game any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogPlayerGame", reflect.TypeOf((*MockPlayerRepository)(nil).LogPlayerGame), ctx, game)
+}
+
+// ReplaceStints mocks base method.
+func (m *MockPlayerRepository) ReplaceStints(ctx context.Context, gameId, teamId int, stints []model.Stint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceStints", ctx, gameId, teamId, stints)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceStints indicates an expected call of ReplaceStints.
+func (mr *MockPlayerRepositoryMockRecorder) ReplaceStints(ctx, gameId, teamId, stints any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceStints", reflect.TypeOf((*MockPlayerRepository)(nil).ReplaceStints), ctx, gameId, teamId, stints)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockPlayerRepository) RevokeAPIKey(ctx context.Context, id int, revokedAt time.Time) (model.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, id, revokedAt)
+	ret0, _ := ret[0].(model.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockPlayerRepositoryMockRecorder) RevokeAPIKey(ctx, id, revokedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockPlayerRepository)(nil).RevokeAPIKey), ctx, id, revokedAt)
+}
+
+// SaveAPIKey mocks base method.
+func (m *MockPlayerRepository) SaveAPIKey(ctx context.Context, key model.APIKey) (model.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAPIKey", ctx, key)
+	ret0, _ := ret[0].(model.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveAPIKey indicates an expected call of SaveAPIKey.
+func (mr *MockPlayerRepositoryMockRecorder) SaveAPIKey(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAPIKey", reflect.TypeOf((*MockPlayerRepository)(nil).SaveAPIKey), ctx, key)
+}
+
+// SaveGame mocks base method.
+func (m *MockPlayerRepository) SaveGame(ctx context.Context, game model.Game) (model.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveGame", ctx, game)
+	ret0, _ := ret[0].(model.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveGame indicates an expected call of SaveGame.
+func (mr *MockPlayerRepositoryMockRecorder) SaveGame(ctx, game any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveGame", reflect.TypeOf((*MockPlayerRepository)(nil).SaveGame), ctx, game)
+}
+
+// SaveIdempotencyKey mocks base method.
+func (m *MockPlayerRepository) SaveIdempotencyKey(ctx context.Context, record model.IdempotencyKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveIdempotencyKey", ctx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveIdempotencyKey indicates an expected call of SaveIdempotencyKey.
+func (mr *MockPlayerRepositoryMockRecorder) SaveIdempotencyKey(ctx, record any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveIdempotencyKey", reflect.TypeOf((*MockPlayerRepository)(nil).SaveIdempotencyKey), ctx, record)
+}
+
+// SavePlayer mocks base method.
+func (m *MockPlayerRepository) SavePlayer(ctx context.Context, player model.Player) (model.Player, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePlayer", ctx, player)
+	ret0, _ := ret[0].(model.Player)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SavePlayer indicates an expected call of SavePlayer.
+func (mr *MockPlayerRepositoryMockRecorder) SavePlayer(ctx, player any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePlayer", reflect.TypeOf((*MockPlayerRepository)(nil).SavePlayer), ctx, player)
+}
+
+// SaveRosterEntry mocks base method.
+func (m *MockPlayerRepository) SaveRosterEntry(ctx context.Context, entry model.RosterEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRosterEntry", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRosterEntry indicates an expected call of SaveRosterEntry.
+func (mr *MockPlayerRepositoryMockRecorder) SaveRosterEntry(ctx, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRosterEntry", reflect.TypeOf((*MockPlayerRepository)(nil).SaveRosterEntry), ctx, entry)
+}
+
+// SaveTeam mocks base method.
+func (m *MockPlayerRepository) SaveTeam(ctx context.Context, team model.Team) (model.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTeam", ctx, team)
+	ret0, _ := ret[0].(model.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveTeam indicates an expected call of SaveTeam.
+func (mr *MockPlayerRepositoryMockRecorder) SaveTeam(ctx, team any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTeam", reflect.TypeOf((*MockPlayerRepository)(nil).SaveTeam), ctx, team)
+}
+
+// UpsertPlay mocks base method.
+func (m *MockPlayerRepository) UpsertPlay(ctx context.Context, play model.Play) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertPlay", ctx, play)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertPlay indicates an expected call of UpsertPlay.
+func (mr *MockPlayerRepositoryMockRecorder) UpsertPlay(ctx, play any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertPlay", reflect.TypeOf((*MockPlayerRepository)(nil).UpsertPlay), ctx, play)
+}
+
+// UpsertPlayerGame mocks base method.
+func (m *MockPlayerRepository) UpsertPlayerGame(ctx context.Context, game model.PlayerGameStats) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertPlayerGame", ctx, game)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertPlayerGame indicates an expected call of UpsertPlayerGame.
+func (mr *MockPlayerRepositoryMockRecorder) UpsertPlayerGame(ctx, game any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertPlayerGame", reflect.TypeOf((*MockPlayerRepository)(nil).UpsertPlayerGame), ctx, game)
+}
+
+// WithTx mocks base method.
+func (m *MockPlayerRepository) WithTx(ctx context.Context, fn func(postgres.Repositories) error, opts ...postgres.TxOption) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, fn}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WithTx", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockPlayerRepositoryMockRecorder) WithTx(ctx, fn any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, fn}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockPlayerRepository)(nil).WithTx), varargs...)
+}
@@ -1,72 +1,401 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"nba/model"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("nba/postgres")
+
+// ErrDuplicate is returned when a write conflicts with an existing record.
+var ErrDuplicate = errors.New("record already exists")
+
+//go:generate mockgen -source=player_repository.go -destination=mocks/player_repository.go -package=mocks -exclude_interfaces=querier
+
 type PlayerRepository interface {
-	LogPlayerGame(game model.PlayerGameStats) error
-	GetPlayerGamesBySeason(playerID int, season int) ([]model.PlayerGameStats, error)
-	GetTeamPlayersBySeason(teamID int, season int) ([]model.PlayerGameStats, error)
-	GetPlayer(playerId int) (model.Player, error)
-	GetGame(gameId int) (model.Game, error)
-	GetTeam(teamId int) (model.Team, error)
+	LogPlayerGame(ctx context.Context, game model.PlayerGameStats) error
+	UpsertPlayerGame(ctx context.Context, game model.PlayerGameStats) error
+	GetPlayerGamesBySeason(ctx context.Context, playerID int, season int) ([]model.PlayerGameStats, error)
+	GetTeamPlayersBySeason(ctx context.Context, teamID int, season int) ([]model.PlayerGameStats, error)
+	GetPlayer(ctx context.Context, playerId int) (model.Player, error)
+	GetTeamByName(ctx context.Context, name string) (model.Team, error)
+	GetPlayerByExternalID(ctx context.Context, externalID string) (model.Player, error)
+	GetPlayersByName(ctx context.Context, name string) ([]model.Player, error)
+	GetGameByTeamsOnDate(ctx context.Context, date time.Time, teamID int, opponentID int) (model.Game, error)
+	GetGame(ctx context.Context, gameId int) (model.Game, error)
+	GetTeam(ctx context.Context, teamId int) (model.Team, error)
+	GetPlayerTeamOnDate(ctx context.Context, playerId int, date time.Time) (int, error)
+	GetGameStats(ctx context.Context, gameId int) ([]model.PlayerGameStats, error)
+	GetStatLines(ctx context.Context, filter model.StatLineFilter) ([]model.PlayerGameStats, error)
+	DeletePlayerGame(ctx context.Context, gameId int, playerId int) error
+	GetPlays(ctx context.Context, gameId int) ([]model.Play, error)
+	LogPlay(ctx context.Context, play model.Play) error
+	UpsertPlay(ctx context.Context, play model.Play) error
+	DeletePlay(ctx context.Context, gameId int, sequence int) error
+	ReplaceStints(ctx context.Context, gameId int, teamId int, stints []model.Stint) error
+	GetStints(ctx context.Context, gameId int) ([]model.Stint, error)
+	GetTeamStintsBySeason(ctx context.Context, teamID int, season int) ([]model.Stint, error)
+	GetIdempotencyKey(ctx context.Context, key string) (model.IdempotencyKey, error)
+	SaveIdempotencyKey(ctx context.Context, record model.IdempotencyKey) error
+	SaveAPIKey(ctx context.Context, key model.APIKey) (model.APIKey, error)
+	GetAPIKey(ctx context.Context, id int) (model.APIKey, error)
+	GetAPIKeyByHash(ctx context.Context, hash string) (model.APIKey, error)
+	ListAPIKeys(ctx context.Context) ([]model.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id int, revokedAt time.Time) (model.APIKey, error)
+	SaveTeam(ctx context.Context, team model.Team) (model.Team, error)
+	SavePlayer(ctx context.Context, player model.Player) (model.Player, error)
+	SaveGame(ctx context.Context, game model.Game) (model.Game, error)
+	SaveRosterEntry(ctx context.Context, entry model.RosterEntry) error
+	WithTx(ctx context.Context, fn func(repos Repositories) error, opts ...TxOption) error
+}
+
+// querier is implemented by both *sql.DB and *sql.Tx.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
 }
 
 type PlayerRepositoryStruct struct {
-	db *sql.DB
+	db       *sql.DB
+	q        querier // db, or the transaction inside WithTx
+	inTx     bool
+	dialect  *Dialect
+	timeouts Timeouts
+	tx       TxOptions
+	observe  func(operation string, duration time.Duration)
 }
 
 // GetTeam implements PlayerRepository.
-func (p *PlayerRepositoryStruct) GetTeam(teamId int) (model.Team, error) {
+func (p *PlayerRepositoryStruct) GetTeam(ctx context.Context, teamId int) (_ model.Team, err error) {
+	ctx, end := p.withTimeout(ctx, "GetTeam", false)
+	defer func() { end(err) }()
+
 	var team model.Team
-	err := p.db.QueryRow("SELECT * from team where id = $1", teamId).Scan(team).Error
+	err = p.q.QueryRowContext(ctx, "SELECT id, name FROM team WHERE id = $1", teamId).Scan(&team.Id, &team.Name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.Team{}, nil
+	}
 	if err != nil {
+		return model.Team{}, contextError(ctx, fmt.Errorf("failed to get team: %w", err))
+	}
+	return team, nil
+}
+
+// GetTeamByName implements PlayerRepository. It returns the zero team if no team has the name.
+func (p *PlayerRepositoryStruct) GetTeamByName(ctx context.Context, name string) (_ model.Team, err error) {
+	ctx, end := p.withTimeout(ctx, "GetTeamByName", false)
+	defer func() { end(err) }()
+
+	var team model.Team
+	err = p.q.QueryRowContext(ctx, "SELECT id, name FROM team WHERE name = $1", name).Scan(&team.Id, &team.Name)
+	if errors.Is(err, sql.ErrNoRows) {
 		return model.Team{}, nil
 	}
+	if err != nil {
+		return model.Team{}, contextError(ctx, fmt.Errorf("failed to get team: %w", err))
+	}
 	return team, nil
 }
 
 // GetGame implements PlayerRepository.
-func (p *PlayerRepositoryStruct) GetGame(gameId int) (model.Game, error) {
-	var game model.Game
-	err := p.db.QueryRow("SELECT * from game where id = $1", gameId).Scan(game).Error
+func (p *PlayerRepositoryStruct) GetGame(ctx context.Context, gameId int) (_ model.Game, err error) {
+	ctx, end := p.withTimeout(ctx, "GetGame", false)
+	defer func() { end(err) }()
+
+	game, err := scanGame(p.q.QueryRowContext(ctx, "SELECT "+gameColumns+" FROM game WHERE id = $1", gameId))
+	if err != nil {
+		return model.Game{}, contextError(ctx, fmt.Errorf("failed to get game: %w", err))
+	}
+	return game, nil
+}
+
+// GetGameByTeamsOnDate implements PlayerRepository. The teams may have played as
+// either team A or team B; it returns the zero game if they did not play on that date.
+func (p *PlayerRepositoryStruct) GetGameByTeamsOnDate(ctx context.Context, date time.Time, teamID int, opponentID int) (_ model.Game, err error) {
+	ctx, end := p.withTimeout(ctx, "GetGameByTeamsOnDate", false)
+	defer func() { end(err) }()
+
+	game, err := scanGame(p.q.QueryRowContext(ctx,
+		"SELECT "+gameColumns+" FROM game "+
+			"WHERE date = $1 AND ((team_a_id = $2 AND team_b_id = $3) OR (team_a_id = $3 AND team_b_id = $2)) "+
+			"ORDER BY id LIMIT 1",
+		dateParam(date), teamID, opponentID,
+	))
 	if err != nil {
+		return model.Game{}, contextError(ctx, fmt.Errorf("failed to get game: %w", err))
+	}
+	return game, nil
+}
+
+const gameColumns = "id, date, season, team_a_id, team_b_id, team_a_score, team_b_score, league, overtime_periods"
+
+// scanGame scans a row of gameColumns, returning the zero game if there is none.
+func scanGame(row *sql.Row) (model.Game, error) {
+	var game model.Game
+	var teamAScore, teamBScore sql.NullInt64
+	err := row.Scan(&game.Id, &game.Date, &game.SeasonID, &game.TeamAID, &game.TeamBID, &teamAScore, &teamBScore, &game.League, &game.OvertimePeriods)
+	if errors.Is(err, sql.ErrNoRows) {
 		return model.Game{}, nil
 	}
+	if err != nil {
+		return model.Game{}, err
+	}
+	if teamAScore.Valid {
+		score := int(teamAScore.Int64)
+		game.TeamAScore = &score
+	}
+	if teamBScore.Valid {
+		score := int(teamBScore.Int64)
+		game.TeamBScore = &score
+	}
 	return game, nil
 }
 
 // GetPlayer implements PlayerRepository.
-func (p *PlayerRepositoryStruct) GetPlayer(playerId int) (model.Player, error) {
-	var player model.Player
-	err := p.db.QueryRow("SELECT * from player where id = $1", playerId).Scan(player).Error
+func (p *PlayerRepositoryStruct) GetPlayer(ctx context.Context, playerId int) (_ model.Player, err error) {
+	ctx, end := p.withTimeout(ctx, "GetPlayer", false)
+	defer func() { end(err) }()
+
+	player, err := scanPlayer(p.q.QueryRowContext(ctx, "SELECT "+playerColumns+" FROM player WHERE id = $1", playerId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.Player{}, nil
+	}
 	if err != nil {
+		return model.Player{}, contextError(ctx, fmt.Errorf("failed to get player: %w", err))
+	}
+	return player, nil
+}
+
+// GetPlayerByExternalID implements PlayerRepository. It returns the zero player if
+// no player has the external ID.
+func (p *PlayerRepositoryStruct) GetPlayerByExternalID(ctx context.Context, externalID string) (_ model.Player, err error) {
+	ctx, end := p.withTimeout(ctx, "GetPlayerByExternalID", false)
+	defer func() { end(err) }()
+
+	player, err := scanPlayer(p.q.QueryRowContext(ctx, "SELECT "+playerColumns+" FROM player WHERE external_id = $1", externalID))
+	if errors.Is(err, sql.ErrNoRows) {
 		return model.Player{}, nil
 	}
+	if err != nil {
+		return model.Player{}, contextError(ctx, fmt.Errorf("failed to get player: %w", err))
+	}
 	return player, nil
 }
 
+// GetPlayersByName implements PlayerRepository. Players are ordered by ID.
+func (p *PlayerRepositoryStruct) GetPlayersByName(ctx context.Context, name string) (_ []model.Player, err error) {
+	ctx, end := p.withTimeout(ctx, "GetPlayersByName", false)
+	defer func() { end(err) }()
+
+	rows, err := p.q.QueryContext(ctx, "SELECT "+playerColumns+" FROM player WHERE name = $1 ORDER BY id", name)
+	if err != nil {
+		return nil, contextError(ctx, fmt.Errorf("failed to get players: %w", err))
+	}
+	defer rows.Close()
+
+	var players []model.Player
+	for rows.Next() {
+		player, err := scanPlayer(rows)
+		if err != nil {
+			return nil, contextError(ctx, fmt.Errorf("failed to get players: %w", err))
+		}
+		players = append(players, player)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, contextError(ctx, fmt.Errorf("failed to get players: %w", err))
+	}
+	return players, nil
+}
+
+const playerColumns = "id, name, current_team_id, external_id"
+
+func scanPlayer(row interface{ Scan(dest ...any) error }) (model.Player, error) {
+	var player model.Player
+	var externalID sql.NullString
+	err := row.Scan(&player.Id, &player.Name, &player.CurrentTeamID, &externalID)
+	player.ExternalID = externalID.String
+	return player, err
+}
+
+// GetPlayerTeamOnDate implements PlayerRepository.
+// It returns 0 if the player was not on any roster on that date.
+func (p *PlayerRepositoryStruct) GetPlayerTeamOnDate(ctx context.Context, playerId int, date time.Time) (_ int, err error) {
+	ctx, end := p.withTimeout(ctx, "GetPlayerTeamOnDate", false)
+	defer func() { end(err) }()
+
+	var teamID int
+	err = p.q.QueryRowContext(ctx,
+		"SELECT team_id FROM roster "+
+			"WHERE player_id = $1 AND start_date <= $2 AND (end_date IS NULL OR end_date >= $2) "+
+			"ORDER BY start_date DESC LIMIT 1",
+		playerId, dateParam(date),
+	).Scan(&teamID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, contextError(ctx, fmt.Errorf("failed to get roster: %w", err))
+	}
+	return teamID, nil
+}
+
+// plusMinusColumn computes the plus-minus of a stat line from the stints of its game.
+const plusMinusColumn = "COALESCE((SELECT SUM(stint.points_for - stint.points_against) FROM stint " +
+	"JOIN stint_player ON stint_player.game_id = stint.game_id AND stint_player.team_id = stint.team_id AND stint_player.number = stint.number " +
+	"WHERE stint.game_id = player_game_stats.game_id AND stint_player.player_id = player_game_stats.player_id), 0)"
+
+// GetGameStats implements PlayerRepository.
+func (p *PlayerRepositoryStruct) GetGameStats(ctx context.Context, gameId int) (_ []model.PlayerGameStats, err error) {
+	ctx, end := p.withTimeout(ctx, "GetGameStats", false)
+	defer func() { end(err) }()
+
+	rows, err := p.q.QueryContext(ctx,
+		"SELECT player_game_stats.player_id, player.name, player_game_stats.game_id, COALESCE(player_game_stats.team_id, 0), player_game_stats.points, player_game_stats.assists, player_game_stats.rebounds, player_game_stats.steals, player_game_stats.blocks, player_game_stats.turnovers, player_game_stats.fouls, player_game_stats.minutes_played, "+plusMinusColumn+" "+
+			"FROM player_game_stats "+
+			"JOIN player ON player_game_stats.player_id = player.id "+
+			"WHERE player_game_stats.game_id = $1 "+
+			"ORDER BY player_game_stats.player_id ASC",
+		gameId,
+	)
+	if err != nil {
+		return nil, contextError(ctx, fmt.Errorf("failed to query records: %w", err))
+	}
+	defer rows.Close()
+
+	var statsList []model.PlayerGameStats
+
+	for rows.Next() {
+		var stats model.PlayerGameStats
+		err := rows.Scan(
+			&stats.PlayerID,
+			&stats.PlayerName,
+			&stats.GameID,
+			&stats.TeamID,
+			&stats.Points,
+			&stats.Assists,
+			&stats.Rebounds,
+			&stats.Steals,
+			&stats.Blocks,
+			&stats.Turnovers,
+			&stats.Fouls,
+			&stats.MinutesPlayed,
+			&stats.PlusMinus,
+		)
+		if err != nil {
+			return nil, contextError(ctx, fmt.Errorf("failed to scan record: %w", err))
+		}
+		statsList = append(statsList, stats)
+	}
+
+	// Check for errors from iteration
+	if err = rows.Err(); err != nil {
+		return nil, contextError(ctx, fmt.Errorf("iteration error: %w", err))
+	}
+
+	return statsList, nil
+}
+
+// GetStatLines implements PlayerRepository. The lines are ordered by game and player.
+func (p *PlayerRepositoryStruct) GetStatLines(ctx context.Context, filter model.StatLineFilter) (_ []model.PlayerGameStats, err error) {
+	ctx, end := p.withTimeout(ctx, "GetStatLines", false)
+	defer func() { end(err) }()
+
+	var conditions []string
+	var args []any
+	for _, c := range []struct {
+		column string
+		value  int
+	}{
+		{"game.season", filter.Season},
+		{"player_game_stats.team_id", filter.TeamID},
+		{"player_game_stats.player_id", filter.PlayerID},
+	} {
+		if c.value != 0 {
+			args = append(args, c.value)
+			conditions = append(conditions, fmt.Sprintf("%s = $%d", c.column, len(args)))
+		}
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ") + " "
+	}
+
+	rows, err := p.q.QueryContext(ctx,
+		"SELECT player_game_stats.player_id, player.name, player_game_stats.game_id, COALESCE(player_game_stats.team_id, 0), player_game_stats.points, player_game_stats.assists, player_game_stats.rebounds, player_game_stats.steals, player_game_stats.blocks, player_game_stats.turnovers, player_game_stats.fouls, player_game_stats.minutes_played, "+plusMinusColumn+" "+
+			"FROM player_game_stats "+
+			"JOIN player ON player_game_stats.player_id = player.id "+
+			"JOIN game ON player_game_stats.game_id = game.id "+
+			where+
+			"ORDER BY player_game_stats.game_id ASC, player_game_stats.player_id ASC",
+		args...,
+	)
+	if err != nil {
+		return nil, contextError(ctx, fmt.Errorf("failed to query records: %w", err))
+	}
+	defer rows.Close()
+
+	var statsList []model.PlayerGameStats
+	for rows.Next() {
+		var stats model.PlayerGameStats
+		err := rows.Scan(
+			&stats.PlayerID,
+			&stats.PlayerName,
+			&stats.GameID,
+			&stats.TeamID,
+			&stats.Points,
+			&stats.Assists,
+			&stats.Rebounds,
+			&stats.Steals,
+			&stats.Blocks,
+			&stats.Turnovers,
+			&stats.Fouls,
+			&stats.MinutesPlayed,
+			&stats.PlusMinus,
+		)
+		if err != nil {
+			return nil, contextError(ctx, fmt.Errorf("failed to scan record: %w", err))
+		}
+		statsList = append(statsList, stats)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, contextError(ctx, fmt.Errorf("iteration error: %w", err))
+	}
+	return statsList, nil
+}
+
 // GetPlayerGames implements PlayerRepository.
-func (p *PlayerRepositoryStruct) GetPlayerGames(playerId int) ([]model.PlayerGameStats, error) {
+func (p *PlayerRepositoryStruct) GetPlayerGames(ctx context.Context, playerId int) ([]model.PlayerGameStats, error) {
 	panic("unimplemented")
 }
 
 // GetTeamPlayers implements PlayerRepository.
-func (p *PlayerRepositoryStruct) GetTeamPlayersBySeason(teamID int, season int) ([]model.PlayerGameStats, error) {
-	rows, err := p.db.Query(
-		"SELECT player_game_stats.player_id, player.name, game.id, player_game_stats.points, player_game_stats.assists, player_game_stats.rebounds, player_game_stats.steals, player_game_stats.blocks, player_game_stats.turnovers, player_game_stats.fouls, player_game_stats.minutes_played "+
+func (p *PlayerRepositoryStruct) GetTeamPlayersBySeason(ctx context.Context, teamID int, season int) (_ []model.PlayerGameStats, err error) {
+	ctx, end := p.withTimeout(ctx, "GetTeamPlayersBySeason", false)
+	defer func() { end(err) }()
+
+	rows, err := p.q.QueryContext(ctx,
+		"SELECT player_game_stats.player_id, player.name, game.id, player_game_stats.points, player_game_stats.assists, player_game_stats.rebounds, player_game_stats.steals, player_game_stats.blocks, player_game_stats.turnovers, player_game_stats.fouls, player_game_stats.minutes_played, "+plusMinusColumn+" "+
 			"FROM player_game_stats "+
 			"JOIN player ON player_game_stats.player_id = player.id "+
 			"JOIN game ON player_game_stats.game_id = game.id "+
-			"JOIN seasons ON game.season_id = seasons.id "+
-			"WHERE player_game_stats.team_id = $1 AND seasons.year = $2",
+			"WHERE player_game_stats.team_id = $1 AND game.season = $2 "+
+			"ORDER BY game.id ASC, player_game_stats.player_id ASC",
 		teamID, season,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query records: %w", err)
+		return nil, contextError(ctx, fmt.Errorf("failed to query records: %w", err))
 	}
 	defer rows.Close()
 
@@ -86,35 +415,38 @@ func (p *PlayerRepositoryStruct) GetTeamPlayersBySeason(teamID int, season int)
 			&stats.Turnovers,
 			&stats.Fouls,
 			&stats.MinutesPlayed,
+			&stats.PlusMinus,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan record: %w", err)
+			return nil, contextError(ctx, fmt.Errorf("failed to scan record: %w", err))
 		}
 		statsList = append(statsList, stats)
 	}
 
 	// Check for errors from iteration
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("iteration error: %w", err)
+		return nil, contextError(ctx, fmt.Errorf("iteration error: %w", err))
 	}
 
 	return statsList, nil
 }
 
 // GetPlayerGamesBySeason implements PlayerRepository.
-func (p *PlayerRepositoryStruct) GetPlayerGamesBySeason(playerID int, season int) ([]model.PlayerGameStats, error) {
-	rows, err := p.db.Query(
-		"SELECT player_game_stats.player_id, player.name, game.id, player_game_stats.points, player_game_stats.assists, player_game_stats.rebounds, player_game_stats.steals, player_game_stats.blocks, player_game_stats.turnovers, player_game_stats.fouls, player_game_stats.minutes_played "+
+func (p *PlayerRepositoryStruct) GetPlayerGamesBySeason(ctx context.Context, playerID int, season int) (_ []model.PlayerGameStats, err error) {
+	ctx, end := p.withTimeout(ctx, "GetPlayerGamesBySeason", false)
+	defer func() { end(err) }()
+
+	rows, err := p.q.QueryContext(ctx,
+		"SELECT player_game_stats.player_id, player.name, game.id, player_game_stats.points, player_game_stats.assists, player_game_stats.rebounds, player_game_stats.steals, player_game_stats.blocks, player_game_stats.turnovers, player_game_stats.fouls, player_game_stats.minutes_played, "+plusMinusColumn+" "+
 			"FROM player_game_stats "+
 			"JOIN player ON player_game_stats.player_id = player.id "+
 			"JOIN game ON player_game_stats.game_id = game.id "+
-			"JOIN seasons ON game.season_id = seasons.id "+
-			"WHERE player.id = $1 AND seasons.year = $2 "+
+			"WHERE player.id = $1 AND game.season = $2 "+
 			"ORDER BY game.id ASC", // Ordering remains as is, adjust if needed
 		playerID, season,
 	)
 	if err != nil {
-		return nil, err
+		return nil, contextError(ctx, err)
 	}
 	defer rows.Close()
 
@@ -134,36 +466,614 @@ func (p *PlayerRepositoryStruct) GetPlayerGamesBySeason(playerID int, season int
 			&stats.Turnovers,
 			&stats.Fouls,
 			&stats.MinutesPlayed,
+			&stats.PlusMinus,
 		)
 		if err != nil {
-			return nil, err
+			return nil, contextError(ctx, err)
 		}
 		statsList = append(statsList, stats)
 	}
 
 	// Check for errors from iteration
 	if err = rows.Err(); err != nil {
-		return nil, err
+		return nil, contextError(ctx, err)
 	}
 
 	return statsList, nil
 }
 
 // LogPlayerGame implements PlayerRepository.
-func (p *PlayerRepositoryStruct) LogPlayerGame(game model.PlayerGameStats) error {
-	_, err := p.db.Exec(
-		"INSERT INTO player_game_stats (player_id, game_id, points, assists, rebounds, steals, blocks, turnovers, fouls, minutes_played) "+
-			"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
-		game.PlayerID, game.GameID, game.Points, game.Assists, game.Rebounds, game.Steals, game.Blocks, game.Turnovers, game.Fouls, game.MinutesPlayed,
+func (p *PlayerRepositoryStruct) LogPlayerGame(ctx context.Context, game model.PlayerGameStats) (err error) {
+	ctx, end := p.withTimeout(ctx, "LogPlayerGame", true)
+	defer func() { end(err) }()
+
+	_, err = p.q.ExecContext(ctx,
+		"INSERT INTO player_game_stats (player_id, game_id, team_id, points, assists, rebounds, steals, blocks, turnovers, fouls, minutes_played) "+
+			"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)",
+		game.PlayerID, game.GameID, game.TeamID, game.Points, game.Assists, game.Rebounds, game.Steals, game.Blocks, game.Turnovers, game.Fouls, game.MinutesPlayed,
 	)
+	if p.dialect.IsUniqueViolation(err) {
+		return fmt.Errorf("stat line for player %d in game %d: %w", game.PlayerID, game.GameID, ErrDuplicate)
+	}
 	if err != nil {
-		return err
+		return contextError(ctx, err)
 	}
 	return nil
 }
 
-func NewPlayerRepository(db *sql.DB) PlayerRepository {
+// UpsertPlayerGame implements PlayerRepository.
+func (p *PlayerRepositoryStruct) UpsertPlayerGame(ctx context.Context, game model.PlayerGameStats) (err error) {
+	ctx, end := p.withTimeout(ctx, "UpsertPlayerGame", true)
+	defer func() { end(err) }()
+
+	_, err = p.q.ExecContext(ctx,
+		"INSERT INTO player_game_stats (player_id, game_id, team_id, points, assists, rebounds, steals, blocks, turnovers, fouls, minutes_played) "+
+			"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) "+
+			"ON CONFLICT (player_id, game_id) DO UPDATE SET "+
+			"team_id = EXCLUDED.team_id, points = EXCLUDED.points, assists = EXCLUDED.assists, rebounds = EXCLUDED.rebounds, "+
+			"steals = EXCLUDED.steals, blocks = EXCLUDED.blocks, turnovers = EXCLUDED.turnovers, fouls = EXCLUDED.fouls, "+
+			"minutes_played = EXCLUDED.minutes_played",
+		game.PlayerID, game.GameID, game.TeamID, game.Points, game.Assists, game.Rebounds, game.Steals, game.Blocks, game.Turnovers, game.Fouls, game.MinutesPlayed,
+	)
+	if err != nil {
+		return contextError(ctx, err)
+	}
+	return nil
+}
+
+// DeletePlayerGame implements PlayerRepository. Deleting a missing stat line is not an error.
+func (p *PlayerRepositoryStruct) DeletePlayerGame(ctx context.Context, gameId int, playerId int) (err error) {
+	ctx, end := p.withTimeout(ctx, "DeletePlayerGame", true)
+	defer func() { end(err) }()
+
+	_, err = p.q.ExecContext(ctx, "DELETE FROM player_game_stats WHERE game_id = $1 AND player_id = $2", gameId, playerId)
+	if err != nil {
+		return contextError(ctx, fmt.Errorf("failed to delete stat line: %w", err))
+	}
+	return nil
+}
+
+// GetPlays implements PlayerRepository. Plays are returned in sequence order.
+func (p *PlayerRepositoryStruct) GetPlays(ctx context.Context, gameId int) (_ []model.Play, err error) {
+	ctx, end := p.withTimeout(ctx, "GetPlays", false)
+	defer func() { end(err) }()
+
+	rows, err := p.q.QueryContext(ctx,
+		"SELECT game_id, sequence, period, elapsed_ms, type, COALESCE(player_id, 0), COALESCE(shot_type, ''), COALESCE(replaced_player_id, 0) "+
+			"FROM play WHERE game_id = $1 ORDER BY sequence",
+		gameId,
+	)
+	if err != nil {
+		return nil, contextError(ctx, fmt.Errorf("failed to get plays: %w", err))
+	}
+	defer rows.Close()
+
+	var plays []model.Play
+	for rows.Next() {
+		var play model.Play
+		var elapsedMS int64
+		err := rows.Scan(&play.GameID, &play.Sequence, &play.Period, &elapsedMS, &play.Type, &play.PlayerID, &play.ShotType, &play.ReplacedPlayerID)
+		if err != nil {
+			return nil, contextError(ctx, fmt.Errorf("failed to get plays: %w", err))
+		}
+		play.Elapsed = time.Duration(elapsedMS) * time.Millisecond
+		plays = append(plays, play)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, contextError(ctx, fmt.Errorf("failed to get plays: %w", err))
+	}
+	return plays, nil
+}
+
+// LogPlay implements PlayerRepository. It fails with ErrDuplicate if the game already
+// has a play with the same sequence number.
+func (p *PlayerRepositoryStruct) LogPlay(ctx context.Context, play model.Play) (err error) {
+	ctx, end := p.withTimeout(ctx, "LogPlay", true)
+	defer func() { end(err) }()
+
+	_, err = p.q.ExecContext(ctx,
+		"INSERT INTO play (game_id, sequence, period, elapsed_ms, type, player_id, shot_type, replaced_player_id) "+
+			"VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+		playArgs(play)...,
+	)
+	if p.dialect.IsUniqueViolation(err) {
+		return fmt.Errorf("play %d of game %d: %w", play.Sequence, play.GameID, ErrDuplicate)
+	}
+	if err != nil {
+		return contextError(ctx, fmt.Errorf("failed to save play: %w", err))
+	}
+	return nil
+}
+
+// UpsertPlay implements PlayerRepository. It replaces the play with the same sequence number.
+func (p *PlayerRepositoryStruct) UpsertPlay(ctx context.Context, play model.Play) (err error) {
+	ctx, end := p.withTimeout(ctx, "UpsertPlay", true)
+	defer func() { end(err) }()
+
+	_, err = p.q.ExecContext(ctx,
+		"INSERT INTO play (game_id, sequence, period, elapsed_ms, type, player_id, shot_type, replaced_player_id) "+
+			"VALUES ($1, $2, $3, $4, $5, $6, $7, $8) "+
+			"ON CONFLICT (game_id, sequence) DO UPDATE SET period = EXCLUDED.period, elapsed_ms = EXCLUDED.elapsed_ms, "+
+			"type = EXCLUDED.type, player_id = EXCLUDED.player_id, shot_type = EXCLUDED.shot_type, "+
+			"replaced_player_id = EXCLUDED.replaced_player_id",
+		playArgs(play)...,
+	)
+	if err != nil {
+		return contextError(ctx, fmt.Errorf("failed to save play: %w", err))
+	}
+	return nil
+}
+
+// DeletePlay implements PlayerRepository. Deleting a missing play is not an error.
+func (p *PlayerRepositoryStruct) DeletePlay(ctx context.Context, gameId int, sequence int) (err error) {
+	ctx, end := p.withTimeout(ctx, "DeletePlay", true)
+	defer func() { end(err) }()
+
+	_, err = p.q.ExecContext(ctx, "DELETE FROM play WHERE game_id = $1 AND sequence = $2", gameId, sequence)
+	if err != nil {
+		return contextError(ctx, fmt.Errorf("failed to delete play: %w", err))
+	}
+	return nil
+}
+
+// ReplaceStints implements PlayerRepository. The stints are replaced in a unit of work of
+// their own when not called inside one.
+func (p *PlayerRepositoryStruct) ReplaceStints(ctx context.Context, gameId int, teamId int, stints []model.Stint) error {
+	return p.WithTx(ctx, func(repos Repositories) error {
+		return repos.Players.(*PlayerRepositoryStruct).replaceStints(ctx, gameId, teamId, stints)
+	})
+}
+
+func (p *PlayerRepositoryStruct) replaceStints(ctx context.Context, gameId int, teamId int, stints []model.Stint) (err error) {
+	ctx, end := p.withTimeout(ctx, "ReplaceStints", true)
+	defer func() { end(err) }()
+
+	for _, table := range []string{"stint_player", "stint"} {
+		_, err := p.q.ExecContext(ctx, "DELETE FROM "+table+" WHERE game_id = $1 AND team_id = $2", gameId, teamId)
+		if err != nil {
+			return contextError(ctx, fmt.Errorf("failed to replace stints: %w", err))
+		}
+	}
+	for i, stint := range stints {
+		number := i + 1
+		_, err := p.q.ExecContext(ctx,
+			"INSERT INTO stint (game_id, team_id, number, period, start_ms, end_ms, points_for, points_against) "+
+				"VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+			gameId, teamId, number, stint.Period, stint.Start.Milliseconds(), stint.End.Milliseconds(), stint.PointsFor, stint.PointsAgainst,
+		)
+		if err != nil {
+			return contextError(ctx, fmt.Errorf("failed to replace stints: %w", err))
+		}
+		for _, playerId := range stint.PlayerIDs {
+			_, err := p.q.ExecContext(ctx,
+				"INSERT INTO stint_player (game_id, team_id, number, player_id) VALUES ($1, $2, $3, $4)",
+				gameId, teamId, number, playerId,
+			)
+			if err != nil {
+				return contextError(ctx, fmt.Errorf("failed to replace stints: %w", err))
+			}
+		}
+	}
+	return nil
+}
+
+// GetStints implements PlayerRepository. Stints are ordered by team and time.
+func (p *PlayerRepositoryStruct) GetStints(ctx context.Context, gameId int) (_ []model.Stint, err error) {
+	ctx, end := p.withTimeout(ctx, "GetStints", false)
+	defer func() { end(err) }()
+	return p.queryStints(ctx, "stint.game_id = $1", gameId)
+}
+
+// GetTeamStintsBySeason implements PlayerRepository. Stints are ordered by game and time.
+func (p *PlayerRepositoryStruct) GetTeamStintsBySeason(ctx context.Context, teamID int, season int) (_ []model.Stint, err error) {
+	ctx, end := p.withTimeout(ctx, "GetTeamStintsBySeason", false)
+	defer func() { end(err) }()
+	return p.queryStints(ctx, "stint.team_id = $1 AND game.season = $2", teamID, season)
+}
+
+// queryStints returns the stints matching where with their players, one row per player.
+func (p *PlayerRepositoryStruct) queryStints(ctx context.Context, where string, args ...any) ([]model.Stint, error) {
+	rows, err := p.q.QueryContext(ctx,
+		"SELECT stint.game_id, stint.team_id, stint.number, stint.period, stint.start_ms, stint.end_ms, stint.points_for, stint.points_against, stint_player.player_id "+
+			"FROM stint "+
+			"JOIN stint_player ON stint_player.game_id = stint.game_id AND stint_player.team_id = stint.team_id AND stint_player.number = stint.number "+
+			"JOIN game ON stint.game_id = game.id "+
+			"WHERE "+where+" "+
+			"ORDER BY stint.game_id, stint.team_id, stint.number, stint_player.player_id",
+		args...,
+	)
+	if err != nil {
+		return nil, contextError(ctx, fmt.Errorf("failed to get stints: %w", err))
+	}
+	defer rows.Close()
+
+	var stints []model.Stint
+	lastNumber := 0
+	for rows.Next() {
+		var stint model.Stint
+		var number, playerId int
+		var startMS, endMS int64
+		err := rows.Scan(&stint.GameID, &stint.TeamID, &number, &stint.Period, &startMS, &endMS, &stint.PointsFor, &stint.PointsAgainst, &playerId)
+		if err != nil {
+			return nil, contextError(ctx, fmt.Errorf("failed to get stints: %w", err))
+		}
+		// Rows of the same stint are adjacent
+		if n := len(stints); n > 0 && stints[n-1].GameID == stint.GameID && stints[n-1].TeamID == stint.TeamID && lastNumber == number {
+			stints[n-1].PlayerIDs = append(stints[n-1].PlayerIDs, playerId)
+			continue
+		}
+		stint.Start = time.Duration(startMS) * time.Millisecond
+		stint.End = time.Duration(endMS) * time.Millisecond
+		stint.PlayerIDs = []int{playerId}
+		stints = append(stints, stint)
+		lastNumber = number
+	}
+	if err := rows.Err(); err != nil {
+		return nil, contextError(ctx, fmt.Errorf("failed to get stints: %w", err))
+	}
+	return stints, nil
+}
+
+// playArgs returns the columns of a play in insert order. Missing players and shot
+// types are stored as NULL, so the foreign keys only apply to players that are set.
+func playArgs(play model.Play) []any {
+	return []any{
+		play.GameID, play.Sequence, play.Period, play.Elapsed.Milliseconds(), string(play.Type),
+		nullIfZero(play.PlayerID), nullIfZero(string(play.ShotType)), nullIfZero(play.ReplacedPlayerID),
+	}
+}
+
+func nullIfZero[T comparable](v T) any {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return v
+}
+
+// GetIdempotencyKey implements PlayerRepository.
+func (p *PlayerRepositoryStruct) GetIdempotencyKey(ctx context.Context, key string) (_ model.IdempotencyKey, err error) {
+	ctx, end := p.withTimeout(ctx, "GetIdempotencyKey", false)
+	defer func() { end(err) }()
+
+	var record model.IdempotencyKey
+	err = p.q.QueryRowContext(ctx, "SELECT key, request_hash, created_at FROM idempotency_key WHERE key = $1", key).
+		Scan(&record.Key, &record.RequestHash, &record.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.IdempotencyKey{}, nil
+	}
+	if err != nil {
+		return model.IdempotencyKey{}, contextError(ctx, fmt.Errorf("failed to get idempotency key: %w", err))
+	}
+	return record, nil
+}
+
+// SaveIdempotencyKey implements PlayerRepository.
+func (p *PlayerRepositoryStruct) SaveIdempotencyKey(ctx context.Context, record model.IdempotencyKey) (err error) {
+	ctx, end := p.withTimeout(ctx, "SaveIdempotencyKey", true)
+	defer func() { end(err) }()
+
+	_, err = p.q.ExecContext(ctx,
+		"INSERT INTO idempotency_key (key, request_hash, created_at) VALUES ($1, $2, $3)",
+		record.Key, record.RequestHash, record.CreatedAt,
+	)
+	if p.dialect.IsUniqueViolation(err) {
+		return fmt.Errorf("idempotency key %q: %w", record.Key, ErrDuplicate)
+	}
+	if err != nil {
+		return contextError(ctx, fmt.Errorf("failed to save idempotency key: %w", err))
+	}
+	return nil
+}
+
+// SaveAPIKey implements PlayerRepository. It creates the key and returns it with its ID.
+func (p *PlayerRepositoryStruct) SaveAPIKey(ctx context.Context, key model.APIKey) (_ model.APIKey, err error) {
+	ctx, end := p.withTimeout(ctx, "SaveAPIKey", true)
+	defer func() { end(err) }()
+
+	err = p.q.QueryRowContext(ctx,
+		"INSERT INTO api_key (name, prefix, hash, role, created_at) VALUES ($1, $2, $3, $4, $5) RETURNING id",
+		key.Name, key.Prefix, key.Hash, key.Role, key.CreatedAt,
+	).Scan(&key.ID)
+	if p.dialect.IsUniqueViolation(err) {
+		return model.APIKey{}, fmt.Errorf("api key %s: %w", key.Prefix, ErrDuplicate)
+	}
+	if err != nil {
+		return model.APIKey{}, contextError(ctx, fmt.Errorf("failed to save api key: %w", err))
+	}
+	return key, nil
+}
+
+const apiKeyColumns = "id, name, prefix, hash, role, created_at, revoked_at"
+
+// GetAPIKey implements PlayerRepository.
+func (p *PlayerRepositoryStruct) GetAPIKey(ctx context.Context, id int) (_ model.APIKey, err error) {
+	ctx, end := p.withTimeout(ctx, "GetAPIKey", false)
+	defer func() { end(err) }()
+
+	key, err := scanAPIKey(p.q.QueryRowContext(ctx, "SELECT "+apiKeyColumns+" FROM api_key WHERE id = $1", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.APIKey{}, nil
+	}
+	if err != nil {
+		return model.APIKey{}, contextError(ctx, fmt.Errorf("failed to get api key: %w", err))
+	}
+	return key, nil
+}
+
+// GetAPIKeyByHash implements PlayerRepository.
+func (p *PlayerRepositoryStruct) GetAPIKeyByHash(ctx context.Context, hash string) (_ model.APIKey, err error) {
+	ctx, end := p.withTimeout(ctx, "GetAPIKeyByHash", false)
+	defer func() { end(err) }()
+
+	key, err := scanAPIKey(p.q.QueryRowContext(ctx, "SELECT "+apiKeyColumns+" FROM api_key WHERE hash = $1", hash))
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.APIKey{}, nil
+	}
+	if err != nil {
+		return model.APIKey{}, contextError(ctx, fmt.Errorf("failed to get api key: %w", err))
+	}
+	return key, nil
+}
+
+// ListAPIKeys implements PlayerRepository.
+func (p *PlayerRepositoryStruct) ListAPIKeys(ctx context.Context) (_ []model.APIKey, err error) {
+	ctx, end := p.withTimeout(ctx, "ListAPIKeys", false)
+	defer func() { end(err) }()
+
+	rows, err := p.q.QueryContext(ctx, "SELECT "+apiKeyColumns+" FROM api_key ORDER BY id")
+	if err != nil {
+		return nil, contextError(ctx, fmt.Errorf("failed to list api keys: %w", err))
+	}
+	defer rows.Close()
+
+	var keys []model.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, contextError(ctx, fmt.Errorf("failed to list api keys: %w", err))
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, contextError(ctx, fmt.Errorf("failed to list api keys: %w", err))
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey implements PlayerRepository. It returns the revoked key, or the zero
+// value if there is no key with that ID. Revoking a key again keeps its first revocation time.
+func (p *PlayerRepositoryStruct) RevokeAPIKey(ctx context.Context, id int, revokedAt time.Time) (_ model.APIKey, err error) {
+	ctx, end := p.withTimeout(ctx, "RevokeAPIKey", true)
+	defer func() { end(err) }()
+
+	key, err := scanAPIKey(p.q.QueryRowContext(ctx,
+		"UPDATE api_key SET revoked_at = COALESCE(revoked_at, $1) WHERE id = $2 RETURNING "+apiKeyColumns,
+		revokedAt, id,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.APIKey{}, nil
+	}
+	if err != nil {
+		return model.APIKey{}, contextError(ctx, fmt.Errorf("failed to revoke api key: %w", err))
+	}
+	return key, nil
+}
+
+func scanAPIKey(row interface{ Scan(dest ...any) error }) (model.APIKey, error) {
+	var key model.APIKey
+	var revokedAt sql.NullTime
+	err := row.Scan(&key.ID, &key.Name, &key.Prefix, &key.Hash, &key.Role, &key.CreatedAt, &revokedAt)
+	key.RevokedAt = revokedAt.Time
+	return key, err
+}
+
+// SaveTeam implements PlayerRepository. A team without an ID is created,
+// otherwise the team with that ID is created or replaced.
+func (p *PlayerRepositoryStruct) SaveTeam(ctx context.Context, team model.Team) (_ model.Team, err error) {
+	ctx, end := p.withTimeout(ctx, "SaveTeam", true)
+	defer func() { end(err) }()
+
+	if team.Id == 0 {
+		err = p.q.QueryRowContext(ctx, "INSERT INTO team (name) VALUES ($1) RETURNING id", team.Name).Scan(&team.Id)
+	} else {
+		_, err = p.q.ExecContext(ctx,
+			"INSERT INTO team (id, name) VALUES ($1, $2) ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name",
+			team.Id, team.Name,
+		)
+		if err == nil {
+			err = p.syncSequence(ctx, "team")
+		}
+	}
+	if p.dialect.IsUniqueViolation(err) {
+		return model.Team{}, fmt.Errorf("team %q: %w", team.Name, ErrDuplicate)
+	}
+	if err != nil {
+		return model.Team{}, contextError(ctx, fmt.Errorf("failed to save team: %w", err))
+	}
+	return team, nil
+}
+
+// SavePlayer implements PlayerRepository. A player without an ID is created,
+// otherwise the player with that ID is created or replaced.
+func (p *PlayerRepositoryStruct) SavePlayer(ctx context.Context, player model.Player) (_ model.Player, err error) {
+	ctx, end := p.withTimeout(ctx, "SavePlayer", true)
+	defer func() { end(err) }()
+
+	if player.Id == 0 {
+		err = p.q.QueryRowContext(ctx,
+			"INSERT INTO player (name, current_team_id, external_id) VALUES ($1, $2, $3) RETURNING id",
+			player.Name, player.CurrentTeamID, nullIfZero(player.ExternalID),
+		).Scan(&player.Id)
+	} else {
+		_, err = p.q.ExecContext(ctx,
+			"INSERT INTO player (id, name, current_team_id, external_id) VALUES ($1, $2, $3, $4) "+
+				"ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name, current_team_id = EXCLUDED.current_team_id, "+
+				"external_id = EXCLUDED.external_id",
+			player.Id, player.Name, player.CurrentTeamID, nullIfZero(player.ExternalID),
+		)
+		if err == nil {
+			err = p.syncSequence(ctx, "player")
+		}
+	}
+	if p.dialect.IsUniqueViolation(err) {
+		return model.Player{}, fmt.Errorf("player with external ID %q: %w", player.ExternalID, ErrDuplicate)
+	}
+	if err != nil {
+		return model.Player{}, contextError(ctx, fmt.Errorf("failed to save player: %w", err))
+	}
+	return player, nil
+}
+
+// SaveGame implements PlayerRepository. A game without an ID is created,
+// otherwise the game with that ID is created or replaced.
+func (p *PlayerRepositoryStruct) SaveGame(ctx context.Context, game model.Game) (_ model.Game, err error) {
+	ctx, end := p.withTimeout(ctx, "SaveGame", true)
+	defer func() { end(err) }()
+
+	if game.Id == 0 {
+		err = p.q.QueryRowContext(ctx,
+			"INSERT INTO game (date, season, team_a_id, team_b_id, team_a_score, team_b_score, league, overtime_periods) "+
+				"VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id",
+			dateParam(game.Date), game.SeasonID, game.TeamAID, game.TeamBID, game.TeamAScore, game.TeamBScore, game.League, game.OvertimePeriods,
+		).Scan(&game.Id)
+	} else {
+		_, err = p.q.ExecContext(ctx,
+			"INSERT INTO game (id, date, season, team_a_id, team_b_id, team_a_score, team_b_score, league, overtime_periods) "+
+				"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) "+
+				"ON CONFLICT (id) DO UPDATE SET date = EXCLUDED.date, season = EXCLUDED.season, "+
+				"team_a_id = EXCLUDED.team_a_id, team_b_id = EXCLUDED.team_b_id, "+
+				"team_a_score = EXCLUDED.team_a_score, team_b_score = EXCLUDED.team_b_score, "+
+				"league = EXCLUDED.league, overtime_periods = EXCLUDED.overtime_periods",
+			game.Id, dateParam(game.Date), game.SeasonID, game.TeamAID, game.TeamBID, game.TeamAScore, game.TeamBScore, game.League, game.OvertimePeriods,
+		)
+		if err == nil {
+			err = p.syncSequence(ctx, "game")
+		}
+	}
+	if err != nil {
+		return model.Game{}, contextError(ctx, fmt.Errorf("failed to save game: %w", err))
+	}
+	return game, nil
+}
+
+// SaveRosterEntry implements PlayerRepository. An entry with the same player, team
+// and start date is replaced.
+func (p *PlayerRepositoryStruct) SaveRosterEntry(ctx context.Context, entry model.RosterEntry) (err error) {
+	ctx, end := p.withTimeout(ctx, "SaveRosterEntry", true)
+	defer func() { end(err) }()
+
+	var endDate any
+	if entry.EndDate != nil {
+		endDate = dateParam(*entry.EndDate)
+	}
+	_, err = p.q.ExecContext(ctx,
+		"INSERT INTO roster (player_id, team_id, start_date, end_date) VALUES ($1, $2, $3, $4) "+
+			"ON CONFLICT (player_id, team_id, start_date) DO UPDATE SET end_date = EXCLUDED.end_date",
+		entry.PlayerID, entry.TeamID, dateParam(entry.StartDate), endDate,
+	)
+	if err != nil {
+		return contextError(ctx, fmt.Errorf("failed to save roster entry: %w", err))
+	}
+	return nil
+}
+
+// syncSequence moves the ID sequence of table past rows inserted with explicit IDs.
+func (p *PlayerRepositoryStruct) syncSequence(ctx context.Context, table string) error {
+	if p.dialect.SyncSequenceSQL == nil {
+		return nil
+	}
+	_, err := p.q.ExecContext(ctx, p.dialect.SyncSequenceSQL(table))
+	return err
+}
+
+// dateParam passes a date as YYYY-MM-DD, which every supported database compares as a date.
+func dateParam(t time.Time) string {
+	return t.Format(time.DateOnly)
+}
+
+// Timeouts bounds how long repository operations may run. Operations overrides the
+// read or write timeout for a single method, keyed by method name. Zero means no limit.
+type Timeouts struct {
+	Read       time.Duration
+	Write      time.Duration
+	Operations map[string]time.Duration
+}
+
+// IsOperation reports whether name is a PlayerRepository method running under a
+// timeout, as Timeouts.Operations is keyed by. That is every method but WithTx.
+func IsOperation(name string) bool {
+	_, ok := reflect.TypeFor[PlayerRepository]().MethodByName(name)
+	return ok && name != "WithTx"
+}
+
+// Options configures a PlayerRepositoryStruct.
+type Options struct {
+	Timeouts Timeouts
+	// Tx holds the defaults of every WithTx call
+	Tx TxOptions
+	// Dialect adapts the repository to a database other than Postgres. Nil means Postgres.
+	Dialect *Dialect
+	// ObserveQuery, if set, is called with the duration of every operation, keyed by method name.
+	ObserveQuery func(operation string, duration time.Duration)
+}
+
+func NewPlayerRepository(db *sql.DB, opts Options) PlayerRepository {
+	dialect := opts.Dialect
+	if dialect == nil {
+		dialect = PostgresDialect
+	}
 	return &PlayerRepositoryStruct{
-		db: db,
+		db:       db,
+		q:        db,
+		dialect:  dialect,
+		timeouts: opts.Timeouts,
+		tx:       opts.Tx,
+		observe:  opts.ObserveQuery,
+	}
+}
+
+// withTimeout derives the context a single operation runs under, traced by a span of
+// its own. Calling end with the error the operation returns cancels the context, marks
+// the span failed if err is not nil and ends it, and reports the duration of the
+// operation to the query observer.
+func (p *PlayerRepositoryStruct) withTimeout(ctx context.Context, operation string, write bool) (_ context.Context, end func(err error)) {
+	timeout, ok := p.timeouts.Operations[operation]
+	if !ok {
+		timeout = p.timeouts.Read
+		if write {
+			timeout = p.timeouts.Write
+		}
+	}
+	ctx, span := tracer.Start(ctx, "PlayerRepository."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("db.system", p.dialect.Name), attribute.String("db.operation.name", operation)),
+	)
+	var cancel context.CancelFunc
+	if timeout <= 0 {
+		ctx, cancel = context.WithCancel(ctx)
+	} else {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	start := time.Now()
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		cancel()
+		span.End()
+		if p.observe != nil {
+			p.observe(operation, time.Since(start))
+		}
+	}
+}
+
+// contextError reports a cancelled or expired context instead of the driver error it caused,
+// so callers can tell it apart from a failing query with errors.Is.
+func contextError(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return fmt.Errorf("%w: %v", ctxErr, err)
 	}
+	return err
 }
@@ -0,0 +1,638 @@
+// Package repotest holds the contract every postgres.PlayerRepository implementation must satisfy.
+package repotest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"nba/model"
+	"nba/postgres"
+)
+
+// Factory returns an empty repository. It is called once per test.
+type Factory func(t *testing.T) postgres.PlayerRepository
+
+// Run runs the repository contract against the implementation returned by newRepo.
+func Run(t *testing.T, newRepo Factory) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, repo postgres.PlayerRepository)
+	}{
+		{"Teams", testTeams},
+		{"PlayersAndGames", testPlayersAndGames},
+		{"Lookups", testLookups},
+		{"LogPlayerGame", testLogPlayerGame},
+		{"SeasonQueries", testSeasonQueries},
+		{"Plays", testPlays},
+		{"Stints", testStints},
+		{"Roster", testRoster},
+		{"IdempotencyKeys", testIdempotencyKeys},
+		{"APIKeys", testAPIKeys},
+		{"WithTx", testWithTx},
+		{"CancelledContext", testCancelledContext},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newRepo(t))
+		})
+	}
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// fixture seeds two teams with one player each and one game between them.
+type fixture struct {
+	teamA, teamB     model.Team
+	playerA, playerB model.Player
+	game             model.Game
+}
+
+func seed(t *testing.T, repo postgres.PlayerRepository) fixture {
+	t.Helper()
+	ctx := context.Background()
+	var f fixture
+	var err error
+	if f.teamA, err = repo.SaveTeam(ctx, model.Team{Name: "Lakers"}); err != nil {
+		t.Fatalf("SaveTeam: %v", err)
+	}
+	if f.teamB, err = repo.SaveTeam(ctx, model.Team{Name: "Celtics"}); err != nil {
+		t.Fatalf("SaveTeam: %v", err)
+	}
+	if f.playerA, err = repo.SavePlayer(ctx, model.Player{Name: "John", CurrentTeamID: f.teamA.Id}); err != nil {
+		t.Fatalf("SavePlayer: %v", err)
+	}
+	if f.playerB, err = repo.SavePlayer(ctx, model.Player{Name: "Paul", CurrentTeamID: f.teamB.Id}); err != nil {
+		t.Fatalf("SavePlayer: %v", err)
+	}
+	f.game, err = repo.SaveGame(ctx, model.Game{
+		Date:     date(2024, 1, 1),
+		SeasonID: 2024,
+		TeamAID:  f.teamA.Id,
+		TeamBID:  f.teamB.Id,
+		League:   "nba",
+	})
+	if err != nil {
+		t.Fatalf("SaveGame: %v", err)
+	}
+	return f
+}
+
+func statLine(player model.Player, game model.Game, points int) model.PlayerGameStats {
+	return model.PlayerGameStats{
+		PlayerID:      player.Id,
+		GameID:        game.Id,
+		TeamID:        player.CurrentTeamID,
+		Points:        points,
+		Assists:       5,
+		Rebounds:      3,
+		Steals:        2,
+		Blocks:        1,
+		Turnovers:     4,
+		Fouls:         3,
+		MinutesPlayed: 30,
+	}
+}
+
+func testTeams(t *testing.T, repo postgres.PlayerRepository) {
+	ctx := context.Background()
+
+	created, err := repo.SaveTeam(ctx, model.Team{Name: "Lakers"})
+	if err != nil {
+		t.Fatalf("SaveTeam: %v", err)
+	}
+	if created.Id == 0 {
+		t.Fatal("SaveTeam did not assign an ID")
+	}
+
+	explicit, err := repo.SaveTeam(ctx, model.Team{Id: 40, Name: "Bulls"})
+	if err != nil || explicit.Id != 40 {
+		t.Fatalf("SaveTeam with ID = %+v, %v", explicit, err)
+	}
+	next, err := repo.SaveTeam(ctx, model.Team{Name: "Knicks"})
+	if err != nil {
+		t.Fatalf("SaveTeam: %v", err)
+	}
+	if next.Id <= 40 {
+		t.Errorf("ID after explicit ID 40 = %d, want > 40", next.Id)
+	}
+
+	if _, err := repo.SaveTeam(ctx, model.Team{Name: "Lakers"}); !errors.Is(err, postgres.ErrDuplicate) {
+		t.Errorf("SaveTeam with a taken name = %v, want ErrDuplicate", err)
+	}
+
+	renamed, err := repo.SaveTeam(ctx, model.Team{Id: created.Id, Name: "LA Lakers"})
+	if err != nil {
+		t.Fatalf("SaveTeam rename: %v", err)
+	}
+	got, err := repo.GetTeam(ctx, created.Id)
+	if err != nil || got != renamed {
+		t.Errorf("GetTeam = %+v, %v, want %+v", got, err, renamed)
+	}
+
+	missing, err := repo.GetTeam(ctx, 9999)
+	if err != nil || missing.Id != 0 {
+		t.Errorf("GetTeam of a missing team = %+v, %v, want zero value", missing, err)
+	}
+}
+
+func testPlayersAndGames(t *testing.T, repo postgres.PlayerRepository) {
+	ctx := context.Background()
+	f := seed(t, repo)
+
+	player, err := repo.GetPlayer(ctx, f.playerA.Id)
+	if err != nil || player.Name != "John" || player.CurrentTeamID != f.teamA.Id {
+		t.Errorf("GetPlayer = %+v, %v", player, err)
+	}
+	if missing, err := repo.GetPlayer(ctx, 9999); err != nil || missing.Id != 0 {
+		t.Errorf("GetPlayer of a missing player = %+v, %v, want zero value", missing, err)
+	}
+
+	game, err := repo.GetGame(ctx, f.game.Id)
+	if err != nil {
+		t.Fatalf("GetGame: %v", err)
+	}
+	if !game.Date.Equal(f.game.Date) || game.SeasonID != 2024 || game.TeamAID != f.teamA.Id || game.TeamBID != f.teamB.Id {
+		t.Errorf("GetGame = %+v, want %+v", game, f.game)
+	}
+	if game.TeamAScore != nil || game.TeamBScore != nil {
+		t.Errorf("GetGame scores = %v, %v, want none", game.TeamAScore, game.TeamBScore)
+	}
+
+	scoreA, scoreB := 101, 99
+	f.game.TeamAScore, f.game.TeamBScore, f.game.OvertimePeriods = &scoreA, &scoreB, 1
+	if _, err := repo.SaveGame(ctx, f.game); err != nil {
+		t.Fatalf("SaveGame: %v", err)
+	}
+	game, err = repo.GetGame(ctx, f.game.Id)
+	if err != nil {
+		t.Fatalf("GetGame: %v", err)
+	}
+	if game.TeamAScore == nil || *game.TeamAScore != 101 || game.TeamBScore == nil || *game.TeamBScore != 99 || game.OvertimePeriods != 1 {
+		t.Errorf("GetGame after update = %+v", game)
+	}
+	if missing, err := repo.GetGame(ctx, 9999); err != nil || missing.Id != 0 {
+		t.Errorf("GetGame of a missing game = %+v, %v, want zero value", missing, err)
+	}
+}
+
+func testLookups(t *testing.T, repo postgres.PlayerRepository) {
+	ctx := context.Background()
+	f := seed(t, repo)
+
+	if team, err := repo.GetTeamByName(ctx, "Celtics"); err != nil || team != f.teamB {
+		t.Errorf("GetTeamByName = %+v, %v, want %+v", team, err, f.teamB)
+	}
+	if team, err := repo.GetTeamByName(ctx, "Knicks"); err != nil || team.Id != 0 {
+		t.Errorf("GetTeamByName of a missing team = %+v, %v, want the zero team", team, err)
+	}
+
+	imported, err := repo.SavePlayer(ctx, model.Player{Name: "John", CurrentTeamID: f.teamB.Id, ExternalID: "johnjo01"})
+	if err != nil {
+		t.Fatalf("SavePlayer: %v", err)
+	}
+	if _, err := repo.SavePlayer(ctx, model.Player{Name: "Other", CurrentTeamID: f.teamB.Id, ExternalID: "johnjo01"}); !errors.Is(err, postgres.ErrDuplicate) {
+		t.Errorf("SavePlayer of a taken external ID = %v, want ErrDuplicate", err)
+	}
+	if got, err := repo.GetPlayerByExternalID(ctx, "johnjo01"); err != nil || got.Id != imported.Id || got.ExternalID != "johnjo01" {
+		t.Errorf("GetPlayerByExternalID = %+v, %v, want player %d", got, err, imported.Id)
+	}
+	if got, err := repo.GetPlayerByExternalID(ctx, "missing"); err != nil || got.Id != 0 {
+		t.Errorf("GetPlayerByExternalID of a missing ID = %+v, %v, want the zero player", got, err)
+	}
+	players, err := repo.GetPlayersByName(ctx, "John")
+	if err != nil || len(players) != 2 || players[0].Id != f.playerA.Id || players[1].Id != imported.Id {
+		t.Errorf("GetPlayersByName = %+v, %v, want players %d and %d", players, err, f.playerA.Id, imported.Id)
+	}
+
+	// The teams may be given in either order
+	for _, teams := range [][2]int{{f.teamA.Id, f.teamB.Id}, {f.teamB.Id, f.teamA.Id}} {
+		game, err := repo.GetGameByTeamsOnDate(ctx, f.game.Date, teams[0], teams[1])
+		if err != nil || game.Id != f.game.Id {
+			t.Errorf("GetGameByTeamsOnDate(%v) = %+v, %v, want game %d", teams, game, err, f.game.Id)
+		}
+	}
+	if game, err := repo.GetGameByTeamsOnDate(ctx, date(2024, 1, 2), f.teamA.Id, f.teamB.Id); err != nil || game.Id != 0 {
+		t.Errorf("GetGameByTeamsOnDate of another day = %+v, %v, want the zero game", game, err)
+	}
+}
+
+func testLogPlayerGame(t *testing.T, repo postgres.PlayerRepository) {
+	ctx := context.Background()
+	f := seed(t, repo)
+
+	if err := repo.LogPlayerGame(ctx, statLine(f.playerB, f.game, 12)); err != nil {
+		t.Fatalf("LogPlayerGame: %v", err)
+	}
+	if err := repo.LogPlayerGame(ctx, statLine(f.playerA, f.game, 10)); err != nil {
+		t.Fatalf("LogPlayerGame: %v", err)
+	}
+	if err := repo.LogPlayerGame(ctx, statLine(f.playerA, f.game, 20)); !errors.Is(err, postgres.ErrDuplicate) {
+		t.Errorf("LogPlayerGame of a duplicate = %v, want ErrDuplicate", err)
+	}
+	if err := repo.LogPlayerGame(ctx, statLine(model.Player{Id: 9999}, f.game, 20)); err == nil {
+		t.Error("LogPlayerGame of a missing player succeeded")
+	}
+
+	if err := repo.UpsertPlayerGame(ctx, statLine(f.playerA, f.game, 30)); err != nil {
+		t.Fatalf("UpsertPlayerGame: %v", err)
+	}
+
+	lines, err := repo.GetGameStats(ctx, f.game.Id)
+	if err != nil {
+		t.Fatalf("GetGameStats: %v", err)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("GetGameStats returned %d lines, want 2", len(lines))
+	}
+	want := statLine(f.playerA, f.game, 30)
+	want.PlayerName = "John"
+	if lines[0] != want {
+		t.Errorf("first line = %+v, want %+v", lines[0], want)
+	}
+	if lines[1].PlayerID != f.playerB.Id || lines[1].Points != 12 {
+		t.Errorf("second line = %+v", lines[1])
+	}
+
+	if err := repo.DeletePlayerGame(ctx, f.game.Id, f.playerB.Id); err != nil {
+		t.Fatalf("DeletePlayerGame: %v", err)
+	}
+	if err := repo.DeletePlayerGame(ctx, f.game.Id, f.playerB.Id); err != nil {
+		t.Errorf("DeletePlayerGame of a missing line: %v", err)
+	}
+	if lines, err := repo.GetGameStats(ctx, f.game.Id); err != nil || len(lines) != 1 || lines[0].PlayerID != f.playerA.Id {
+		t.Errorf("GetGameStats after DeletePlayerGame = %+v, %v, want only the first line", lines, err)
+	}
+}
+
+func testPlays(t *testing.T, repo postgres.PlayerRepository) {
+	ctx := context.Background()
+	f := seed(t, repo)
+
+	plays := []model.Play{
+		{GameID: f.game.Id, Sequence: 1, Period: 1, Type: model.PlaySubstitution, PlayerID: f.playerA.Id},
+		{GameID: f.game.Id, Sequence: 2, Period: 1, Type: model.PlayPeriodStart},
+		{GameID: f.game.Id, Sequence: 3, Period: 1, Elapsed: 83500 * time.Millisecond, Type: model.PlayShotMade, PlayerID: f.playerA.Id, ShotType: model.ShotThreePointer},
+		{GameID: f.game.Id, Sequence: 4, Period: 1, Elapsed: 95 * time.Second, Type: model.PlaySubstitution, PlayerID: f.playerB.Id, ReplacedPlayerID: f.playerA.Id},
+	}
+	// Stored out of order, returned in sequence order
+	for _, i := range []int{2, 0, 3, 1} {
+		if err := repo.LogPlay(ctx, plays[i]); err != nil {
+			t.Fatalf("LogPlay: %v", err)
+		}
+	}
+	if err := repo.LogPlay(ctx, plays[0]); !errors.Is(err, postgres.ErrDuplicate) {
+		t.Errorf("LogPlay of a taken sequence = %v, want ErrDuplicate", err)
+	}
+	if err := repo.LogPlay(ctx, model.Play{GameID: f.game.Id, Sequence: 5, Period: 1, Type: model.PlayFoul, PlayerID: 9999}); err == nil {
+		t.Error("LogPlay of a missing player succeeded")
+	}
+
+	got, err := repo.GetPlays(ctx, f.game.Id)
+	if err != nil {
+		t.Fatalf("GetPlays: %v", err)
+	}
+	if len(got) != len(plays) {
+		t.Fatalf("GetPlays = %+v, want %+v", got, plays)
+	}
+	for i := range plays {
+		if got[i] != plays[i] {
+			t.Errorf("play %d = %+v, want %+v", i, got[i], plays[i])
+		}
+	}
+
+	edited := plays[2]
+	edited.Type, edited.ShotType = model.PlayShotMissed, model.ShotTwoPointer
+	if err := repo.UpsertPlay(ctx, edited); err != nil {
+		t.Fatalf("UpsertPlay: %v", err)
+	}
+	if err := repo.DeletePlay(ctx, f.game.Id, 1); err != nil {
+		t.Fatalf("DeletePlay: %v", err)
+	}
+	got, err = repo.GetPlays(ctx, f.game.Id)
+	if err != nil || len(got) != 3 || got[0].Sequence != 2 || got[1] != edited {
+		t.Errorf("GetPlays after edits = %+v, %v, want plays 2 to 4 with play 3 edited", got, err)
+	}
+	if other, err := repo.GetPlays(ctx, 9999); err != nil || len(other) != 0 {
+		t.Errorf("GetPlays of another game = %+v, %v, want none", other, err)
+	}
+}
+
+func testStints(t *testing.T, repo postgres.PlayerRepository) {
+	ctx := context.Background()
+	f := seed(t, repo)
+
+	other, err := repo.SaveGame(ctx, model.Game{Date: date(2023, 3, 1), SeasonID: 2023, TeamAID: f.teamA.Id, TeamBID: f.teamB.Id})
+	if err != nil {
+		t.Fatalf("SaveGame: %v", err)
+	}
+	stintsA := []model.Stint{
+		{GameID: f.game.Id, TeamID: f.teamA.Id, Period: 1, End: 330500 * time.Millisecond, PlayerIDs: []int{f.playerA.Id}, PointsFor: 7, PointsAgainst: 2},
+		{GameID: f.game.Id, TeamID: f.teamA.Id, Period: 1, Start: 330500 * time.Millisecond, End: 12 * time.Minute, PlayerIDs: []int{f.playerA.Id, f.playerB.Id}, PointsAgainst: 4},
+	}
+	stintsB := []model.Stint{
+		{GameID: f.game.Id, TeamID: f.teamB.Id, Period: 1, End: 12 * time.Minute, PlayerIDs: []int{f.playerB.Id}, PointsFor: 6, PointsAgainst: 7},
+	}
+	for _, write := range []struct {
+		gameID, teamID int
+		stints         []model.Stint
+	}{
+		{f.game.Id, f.teamA.Id, stintsA[:1]},
+		// Replacing drops the stints stored before
+		{f.game.Id, f.teamA.Id, stintsA},
+		{f.game.Id, f.teamB.Id, stintsB},
+		{other.Id, f.teamA.Id, []model.Stint{{GameID: other.Id, TeamID: f.teamA.Id, Period: 1, End: time.Minute, PlayerIDs: []int{f.playerA.Id}, PointsFor: 2}}},
+	} {
+		if err := repo.ReplaceStints(ctx, write.gameID, write.teamID, write.stints); err != nil {
+			t.Fatalf("ReplaceStints: %v", err)
+		}
+	}
+	if err := repo.ReplaceStints(ctx, f.game.Id, f.teamB.Id, []model.Stint{{Period: 1, PlayerIDs: []int{9999}}}); err == nil {
+		t.Error("ReplaceStints with a missing player succeeded")
+	}
+
+	got, err := repo.GetStints(ctx, f.game.Id)
+	if err != nil {
+		t.Fatalf("GetStints: %v", err)
+	}
+	if want := append(slices.Clone(stintsA), stintsB...); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetStints = %+v, want %+v", got, want)
+	}
+	got, err = repo.GetTeamStintsBySeason(ctx, f.teamA.Id, 2024)
+	if err != nil {
+		t.Fatalf("GetTeamStintsBySeason: %v", err)
+	}
+	if !reflect.DeepEqual(got, stintsA) {
+		t.Errorf("GetTeamStintsBySeason = %+v, want %+v", got, stintsA)
+	}
+
+	// Plus-minus adds up the stints of each player
+	for _, line := range []model.PlayerGameStats{statLine(f.playerA, f.game, 10), statLine(f.playerB, f.game, 20)} {
+		if err := repo.LogPlayerGame(ctx, line); err != nil {
+			t.Fatalf("LogPlayerGame: %v", err)
+		}
+	}
+	lines, err := repo.GetGameStats(ctx, f.game.Id)
+	if err != nil {
+		t.Fatalf("GetGameStats: %v", err)
+	}
+	if len(lines) != 2 || lines[0].PlusMinus != 1 || lines[1].PlusMinus != -5 {
+		t.Errorf("GetGameStats = %+v, want plus-minus 1 and -5", lines)
+	}
+
+	if err := repo.ReplaceStints(ctx, f.game.Id, f.teamA.Id, nil); err != nil {
+		t.Fatalf("ReplaceStints: %v", err)
+	}
+	got, err = repo.GetStints(ctx, f.game.Id)
+	if err != nil || !reflect.DeepEqual(got, stintsB) {
+		t.Errorf("GetStints after clearing team A = %+v, %v, want %+v", got, err, stintsB)
+	}
+}
+
+func testSeasonQueries(t *testing.T, repo postgres.PlayerRepository) {
+	ctx := context.Background()
+	f := seed(t, repo)
+
+	other, err := repo.SaveGame(ctx, model.Game{Date: date(2023, 3, 1), SeasonID: 2023, TeamAID: f.teamA.Id, TeamBID: f.teamB.Id})
+	if err != nil {
+		t.Fatalf("SaveGame: %v", err)
+	}
+	for _, line := range []model.PlayerGameStats{
+		statLine(f.playerA, f.game, 10),
+		statLine(f.playerB, f.game, 20),
+		statLine(f.playerA, other, 30),
+	} {
+		if err := repo.LogPlayerGame(ctx, line); err != nil {
+			t.Fatalf("LogPlayerGame: %v", err)
+		}
+	}
+
+	lines, err := repo.GetPlayerGamesBySeason(ctx, f.playerA.Id, 2024)
+	if err != nil {
+		t.Fatalf("GetPlayerGamesBySeason: %v", err)
+	}
+	if len(lines) != 1 || lines[0].Points != 10 || lines[0].PlayerName != "John" {
+		t.Errorf("GetPlayerGamesBySeason = %+v", lines)
+	}
+
+	lines, err = repo.GetTeamPlayersBySeason(ctx, f.teamB.Id, 2024)
+	if err != nil {
+		t.Fatalf("GetTeamPlayersBySeason: %v", err)
+	}
+	if len(lines) != 1 || lines[0].PlayerID != f.playerB.Id {
+		t.Errorf("GetTeamPlayersBySeason = %+v", lines)
+	}
+
+	lines, err = repo.GetPlayerGamesBySeason(ctx, f.playerB.Id, 1999)
+	if err != nil || len(lines) != 0 {
+		t.Errorf("GetPlayerGamesBySeason of an empty season = %+v, %v", lines, err)
+	}
+
+	for _, tc := range []struct {
+		filter model.StatLineFilter
+		points []int
+	}{
+		{model.StatLineFilter{}, []int{10, 20, 30}},
+		{model.StatLineFilter{Season: 2024}, []int{10, 20}},
+		{model.StatLineFilter{TeamID: f.teamA.Id}, []int{10, 30}},
+		{model.StatLineFilter{Season: 2023, PlayerID: f.playerA.Id}, []int{30}},
+		{model.StatLineFilter{Season: 2023, TeamID: f.teamB.Id}, nil},
+	} {
+		lines, err := repo.GetStatLines(ctx, tc.filter)
+		if err != nil {
+			t.Fatalf("GetStatLines(%+v): %v", tc.filter, err)
+		}
+		var points []int
+		for _, line := range lines {
+			points = append(points, line.Points)
+		}
+		if !slices.Equal(points, tc.points) {
+			t.Errorf("GetStatLines(%+v) returned the lines with %v points, want %v", tc.filter, points, tc.points)
+		}
+	}
+	if lines, _ := repo.GetStatLines(ctx, model.StatLineFilter{PlayerID: f.playerB.Id}); len(lines) != 1 || lines[0].PlayerName != "Paul" || lines[0].TeamID != f.teamB.Id {
+		t.Errorf("GetStatLines of a player = %+v", lines)
+	}
+}
+
+func testRoster(t *testing.T, repo postgres.PlayerRepository) {
+	ctx := context.Background()
+	f := seed(t, repo)
+
+	traded := date(2024, 2, 1)
+	entries := []model.RosterEntry{
+		{PlayerID: f.playerA.Id, TeamID: f.teamA.Id, StartDate: date(2023, 10, 1), EndDate: &traded},
+		{PlayerID: f.playerA.Id, TeamID: f.teamB.Id, StartDate: traded},
+	}
+	for _, entry := range entries {
+		if err := repo.SaveRosterEntry(ctx, entry); err != nil {
+			t.Fatalf("SaveRosterEntry: %v", err)
+		}
+	}
+
+	tests := []struct {
+		date time.Time
+		want int
+	}{
+		{date(2023, 9, 30), 0},
+		{date(2023, 10, 1), f.teamA.Id},
+		{date(2024, 1, 15), f.teamA.Id},
+		{traded, f.teamB.Id}, // both entries cover the trade day, the newer one wins
+		{date(2025, 1, 1), f.teamB.Id},
+	}
+	for _, tt := range tests {
+		got, err := repo.GetPlayerTeamOnDate(ctx, f.playerA.Id, tt.date)
+		if err != nil || got != tt.want {
+			t.Errorf("GetPlayerTeamOnDate(%s) = %d, %v, want %d", tt.date.Format(time.DateOnly), got, err, tt.want)
+		}
+	}
+	if got, err := repo.GetPlayerTeamOnDate(ctx, f.playerB.Id, traded); err != nil || got != 0 {
+		t.Errorf("GetPlayerTeamOnDate of a player without roster = %d, %v, want 0", got, err)
+	}
+}
+
+func testIdempotencyKeys(t *testing.T, repo postgres.PlayerRepository) {
+	ctx := context.Background()
+	record := model.IdempotencyKey{
+		Key:         "retry-1",
+		RequestHash: "4f53cda18c2baa0c0354bb5f9a3ecbe5ed12ab4d8e11ba873c2f11161202b945",
+		CreatedAt:   time.Now(),
+	}
+
+	if got, err := repo.GetIdempotencyKey(ctx, record.Key); err != nil || got.Key != "" {
+		t.Errorf("GetIdempotencyKey of an unknown key = %+v, %v, want zero value", got, err)
+	}
+	if err := repo.SaveIdempotencyKey(ctx, record); err != nil {
+		t.Fatalf("SaveIdempotencyKey: %v", err)
+	}
+	if err := repo.SaveIdempotencyKey(ctx, record); !errors.Is(err, postgres.ErrDuplicate) {
+		t.Errorf("SaveIdempotencyKey of a known key = %v, want ErrDuplicate", err)
+	}
+	got, err := repo.GetIdempotencyKey(ctx, record.Key)
+	if err != nil || got.Key != record.Key || got.RequestHash != record.RequestHash {
+		t.Errorf("GetIdempotencyKey = %+v, %v, want %+v", got, err, record)
+	}
+}
+
+func testAPIKeys(t *testing.T, repo postgres.PlayerRepository) {
+	ctx := context.Background()
+	created := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	key := model.APIKey{
+		Name:      "scoreboard",
+		Prefix:    "nba_1a2b3c4d",
+		Hash:      "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		Role:      "scorekeeper",
+		CreatedAt: created,
+	}
+
+	if got, err := repo.GetAPIKeyByHash(ctx, key.Hash); err != nil || got.ID != 0 {
+		t.Errorf("GetAPIKeyByHash of an unknown key = %+v, %v, want zero value", got, err)
+	}
+	saved, err := repo.SaveAPIKey(ctx, key)
+	if err != nil || saved.ID == 0 {
+		t.Fatalf("SaveAPIKey = %+v, %v, want a key with an ID", saved, err)
+	}
+	if _, err := repo.SaveAPIKey(ctx, key); !errors.Is(err, postgres.ErrDuplicate) {
+		t.Errorf("SaveAPIKey of a known hash = %v, want ErrDuplicate", err)
+	}
+	other, err := repo.SaveAPIKey(ctx, model.APIKey{Name: "reports", Prefix: "nba_5e6f7a8b", Hash: "60303ae22b998861bce3b28f33eec1be758a213c86c93c076dbe9f558c11c752", Role: "reader", CreatedAt: created})
+	if err != nil {
+		t.Fatalf("SaveAPIKey: %v", err)
+	}
+
+	got, err := repo.GetAPIKeyByHash(ctx, key.Hash)
+	if err != nil || got.ID != saved.ID || got.Name != key.Name || got.Role != key.Role || !got.CreatedAt.Equal(created) || !got.RevokedAt.IsZero() {
+		t.Errorf("GetAPIKeyByHash = %+v, %v, want %+v", got, err, saved)
+	}
+
+	revokedAt := created.Add(time.Hour)
+	revoked, err := repo.RevokeAPIKey(ctx, saved.ID, revokedAt)
+	if err != nil || revoked.ID != saved.ID || !revoked.RevokedAt.Equal(revokedAt) {
+		t.Errorf("RevokeAPIKey = %+v, %v, want the key revoked at %v", revoked, err, revokedAt)
+	}
+	// Revoking again keeps the first revocation time
+	if again, err := repo.RevokeAPIKey(ctx, saved.ID, revokedAt.Add(time.Hour)); err != nil || !again.RevokedAt.Equal(revokedAt) {
+		t.Errorf("RevokeAPIKey again = %+v, %v, want it still revoked at %v", again, err, revokedAt)
+	}
+	if missing, err := repo.RevokeAPIKey(ctx, other.ID+100, revokedAt); err != nil || missing.ID != 0 {
+		t.Errorf("RevokeAPIKey of an unknown key = %+v, %v, want zero value", missing, err)
+	}
+	if got, err := repo.GetAPIKey(ctx, other.ID); err != nil || got.Hash != other.Hash || got.Role != "reader" {
+		t.Errorf("GetAPIKey = %+v, %v, want %+v", got, err, other)
+	}
+	if missing, err := repo.GetAPIKey(ctx, other.ID+100); err != nil || missing.ID != 0 {
+		t.Errorf("GetAPIKey of an unknown key = %+v, %v, want zero value", missing, err)
+	}
+
+	keys, err := repo.ListAPIKeys(ctx)
+	if err != nil || len(keys) != 2 || keys[0].ID != saved.ID || keys[1].ID != other.ID {
+		t.Fatalf("ListAPIKeys = %+v, %v, want both keys in creation order", keys, err)
+	}
+	if keys[0].RevokedAt.IsZero() || !keys[1].RevokedAt.IsZero() {
+		t.Errorf("ListAPIKeys = %+v, want only the first key revoked", keys)
+	}
+}
+
+func testWithTx(t *testing.T, repo postgres.PlayerRepository) {
+	ctx := context.Background()
+	f := seed(t, repo)
+
+	err := repo.WithTx(ctx, func(repos postgres.Repositories) error {
+		if err := repos.Players.LogPlayerGame(ctx, statLine(f.playerA, f.game, 10)); err != nil {
+			return err
+		}
+		// Nested units of work join the outer one
+		return repos.Players.WithTx(ctx, func(repos postgres.Repositories) error {
+			return repos.Players.LogPlayerGame(ctx, statLine(f.playerB, f.game, 20))
+		})
+	})
+	if err != nil {
+		t.Fatalf("WithTx: %v", err)
+	}
+	if lines, _ := repo.GetGameStats(ctx, f.game.Id); len(lines) != 2 {
+		t.Errorf("committed unit of work stored %d lines, want 2", len(lines))
+	}
+
+	failure := errors.New("scorekeeper changed their mind")
+	err = repo.WithTx(ctx, func(repos postgres.Repositories) error {
+		if err := repos.Players.UpsertPlayerGame(ctx, statLine(f.playerA, f.game, 99)); err != nil {
+			return err
+		}
+		line, err := repos.Players.GetGameStats(ctx, f.game.Id)
+		if err != nil {
+			return err
+		}
+		if line[0].Points != 99 {
+			t.Errorf("unit of work does not see its own write, points = %d", line[0].Points)
+		}
+		return failure
+	}, postgres.Isolation(sql.LevelSerializable))
+	if !errors.Is(err, failure) {
+		t.Fatalf("WithTx = %v, want the error of fn", err)
+	}
+	lines, err := repo.GetGameStats(ctx, f.game.Id)
+	if err != nil {
+		t.Fatalf("GetGameStats: %v", err)
+	}
+	if lines[0].Points != 10 {
+		t.Errorf("rolled back unit of work left points = %d, want 10", lines[0].Points)
+	}
+}
+
+func testCancelledContext(t *testing.T, repo postgres.PlayerRepository) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := repo.GetTeam(ctx, 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetTeam with a cancelled context = %v, want context.Canceled", err)
+	}
+	if err := repo.SaveIdempotencyKey(ctx, model.IdempotencyKey{Key: "k", RequestHash: "h", CreatedAt: time.Now()}); !errors.Is(err, context.Canceled) {
+		t.Errorf("SaveIdempotencyKey with a cancelled context = %v, want context.Canceled", err)
+	}
+}
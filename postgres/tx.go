@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Repositories groups the repositories available inside a unit of work.
+// They all share the same transaction.
+type Repositories struct {
+	Players PlayerRepository
+}
+
+// TxOptions configures a unit of work.
+type TxOptions struct {
+	Isolation sql.IsolationLevel
+	ReadOnly  bool
+	// MaxRetries is how many times the unit of work is retried after a serialization failure or deadlock
+	MaxRetries int
+}
+
+// TxOption overrides the repository's default TxOptions for a single WithTx call.
+type TxOption func(*TxOptions)
+
+// Isolation sets the isolation level of the transaction.
+func Isolation(level sql.IsolationLevel) TxOption {
+	return func(o *TxOptions) { o.Isolation = level }
+}
+
+// ReadOnly marks the transaction read-only.
+func ReadOnly() TxOption {
+	return func(o *TxOptions) { o.ReadOnly = true }
+}
+
+// MaxRetries sets how many times a failed serializable transaction is retried.
+func MaxRetries(n int) TxOption {
+	return func(o *TxOptions) { o.MaxRetries = n }
+}
+
+// ParseIsolationLevel parses names like "read committed" or "serializable".
+// An empty name selects the database default.
+func ParseIsolationLevel(name string) (sql.IsolationLevel, error) {
+	normalized := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "_", " ")
+	if normalized == "" || normalized == "default" {
+		return sql.LevelDefault, nil
+	}
+	for level := sql.LevelDefault; level <= sql.LevelLinearizable; level++ {
+		if strings.ToLower(level.String()) == normalized {
+			return level, nil
+		}
+	}
+	return sql.LevelDefault, fmt.Errorf("unknown isolation level %q", name)
+}
+
+// WithTx implements PlayerRepository. It runs fn inside a transaction that is committed
+// if fn returns nil and rolled back otherwise. Serialization failures and deadlocks are
+// retried with backoff, so fn must be safe to run more than once. Calls nested inside
+// another WithTx join the outer transaction.
+func (p *PlayerRepositoryStruct) WithTx(ctx context.Context, fn func(repos Repositories) error, opts ...TxOption) error {
+	if p.inTx {
+		return fn(Repositories{Players: p})
+	}
+
+	options := p.tx
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	ctx, span := tracer.Start(ctx, "PlayerRepository.WithTx", trace.WithAttributes(attribute.String("db.system", p.dialect.Name)))
+	defer span.End()
+
+	backoff := 10 * time.Millisecond
+	for attempt := 0; ; attempt++ {
+		err := p.runTx(ctx, options, fn)
+		if err == nil || !p.dialect.IsRetryable(err) || attempt >= options.MaxRetries {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.SetAttributes(attribute.Int("db.tx.attempts", attempt+1))
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			span.SetStatus(codes.Error, ctx.Err().Error())
+			return contextError(ctx, err)
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (p *PlayerRepositoryStruct) runTx(ctx context.Context, options TxOptions, fn func(repos Repositories) error) error {
+	tx, err := p.db.BeginTx(ctx, &sql.TxOptions{Isolation: options.Isolation, ReadOnly: options.ReadOnly})
+	if err != nil {
+		return contextError(ctx, fmt.Errorf("failed to begin transaction: %w", err))
+	}
+
+	repo := &PlayerRepositoryStruct{
+		db:       p.db,
+		q:        tx,
+		inTx:     true,
+		dialect:  p.dialect,
+		timeouts: p.timeouts,
+		tx:       p.tx,
+		observe:  p.observe,
+	}
+	if err := fn(Repositories{Players: repo}); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			return errors.Join(err, fmt.Errorf("failed to roll back transaction: %w", rollbackErr))
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return contextError(ctx, fmt.Errorf("failed to commit transaction: %w", err))
+	}
+	return nil
+}
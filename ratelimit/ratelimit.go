@@ -0,0 +1,252 @@
+// Package ratelimit limits how fast each client may call the service and how many
+// calls it may make per day. Clients are API keys, JWT subjects or, for callers
+// without credentials, IP addresses.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"nba/auth"
+)
+
+// RetryAfterHeader is the response metadata key, and HTTP header through the gateway,
+// telling rejected callers how many seconds to wait before retrying.
+const RetryAfterHeader = "retry-after"
+
+// Limit is a token bucket: RPS calls per second on average, in bursts of up to Burst
+// calls. A zero RPS is unlimited; a zero Burst is RPS rounded up.
+type Limit struct {
+	RPS   float64
+	Burst int
+}
+
+// unlimited reports whether l lets every call through.
+func (l Limit) unlimited() bool {
+	return l.RPS <= 0
+}
+
+func (l Limit) burst() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return max(int(math.Ceil(l.RPS)), 1)
+}
+
+// Options configures a Limiter.
+type Options struct {
+	// Roles is the limit of each role across every RPC. Callers without credentials
+	// have the public role. Roles missing from the map are not limited.
+	Roles map[auth.Role]Limit
+	// Methods overrides the role limits of full gRPC method names. Calls to an
+	// overridden method take tokens from a bucket of their own.
+	Methods map[string]map[auth.Role]Limit
+	// DailyQuota caps the calls of each client of a role per UTC day. Roles missing
+	// from the map have no quota.
+	DailyQuota map[auth.Role]int
+}
+
+// Recorder counts rejected calls. It is implemented by metrics.Metrics.
+type Recorder interface {
+	RateLimited(method, reason string)
+}
+
+// Usage is the quota consumption of a client for the current UTC day.
+type Usage struct {
+	Used int
+	// Quota is the daily quota of the client's role, zero if it has none.
+	Quota    int
+	ResetsAt time.Time
+}
+
+type bucketKey struct {
+	client string
+	// method is empty for the bucket shared by every method without a limit of its own.
+	method string
+}
+
+// Limiter holds the token buckets and quota counters of every client in memory, so
+// each replica of the service enforces its limits on its own.
+type Limiter struct {
+	opts     Options
+	recorder Recorder
+	now      func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[bucketKey]*rate.Limiter
+	lastSweep time.Time
+	// day is the start of the UTC day used counts calls for.
+	day  time.Time
+	used map[string]int
+}
+
+// sweepInterval is how often buckets that refilled completely are dropped, bounding
+// the memory held for clients that stopped calling.
+const sweepInterval = time.Minute
+
+// New returns a Limiter enforcing opts. recorder may be nil.
+func New(opts Options, recorder Recorder) *Limiter {
+	return &Limiter{
+		opts:     opts,
+		recorder: recorder,
+		now:      time.Now,
+		buckets:  make(map[bucketKey]*rate.Limiter),
+		used:     make(map[string]int),
+	}
+}
+
+// UnaryServerInterceptor rejects unary calls over their client's limits with
+// RESOURCE_EXHAUSTED. It must run after the auth interceptor to see who is calling.
+func (l *Limiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := l.allow(ctx, info.FullMethod, grpc.SetHeader); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streaming calls, which count
+// once however many messages they carry.
+func (l *Limiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		setHeader := func(_ context.Context, md metadata.MD) error { return ss.SetHeader(md) }
+		if err := l.allow(ss.Context(), info.FullMethod, setHeader); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// KeyUsage returns the quota consumption of the API key id, granted role.
+func (l *Limiter) KeyUsage(id int, role auth.Role) Usage {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rollOver(l.now())
+	return Usage{Used: l.used[apiKeyClient(id)], Quota: l.opts.DailyQuota[role], ResetsAt: l.day.AddDate(0, 0, 1)}
+}
+
+func (l *Limiter) allow(ctx context.Context, method string, setHeader func(context.Context, metadata.MD) error) error {
+	client, role := identify(ctx)
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rollOver(now)
+	l.sweep(now)
+
+	if quota := l.opts.DailyQuota[role]; quota > 0 && l.used[client] >= quota {
+		resetsAt := l.day.AddDate(0, 0, 1)
+		return l.reject(ctx, method, "quota", resetsAt.Sub(now), setHeader,
+			fmt.Sprintf("daily quota of %d calls exhausted, resets at %s", quota, resetsAt.Format(time.RFC3339)))
+	}
+
+	limit, key := l.opts.Roles[role], bucketKey{client: client}
+	if override, ok := l.opts.Methods[method][role]; ok {
+		limit, key = override, bucketKey{client: client, method: method}
+	}
+	if !limit.unlimited() {
+		bucket, ok := l.buckets[key]
+		if !ok {
+			bucket = rate.NewLimiter(rate.Limit(limit.RPS), limit.burst())
+			l.buckets[key] = bucket
+		}
+		reservation := bucket.ReserveN(now, 1)
+		if delay := reservation.DelayFrom(now); delay > 0 {
+			reservation.CancelAt(now)
+			return l.reject(ctx, method, "rate", delay, setHeader,
+				fmt.Sprintf("rate limit of %g calls per second exceeded", limit.RPS))
+		}
+	}
+
+	l.used[client]++
+	return nil
+}
+
+// rollOver resets the quota counters when a new UTC day starts.
+func (l *Limiter) rollOver(now time.Time) {
+	day := now.UTC().Truncate(24 * time.Hour)
+	if !day.Equal(l.day) {
+		l.day = day
+		clear(l.used)
+	}
+}
+
+// sweep drops the buckets that refilled completely, as a new bucket behaves the same.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, bucket := range l.buckets {
+		if bucket.TokensAt(now) >= float64(bucket.Burst()) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func (l *Limiter) reject(ctx context.Context, method, reason string, delay time.Duration, setHeader func(context.Context, metadata.MD) error, message string) error {
+	if l.recorder != nil {
+		l.recorder.RateLimited(method, reason)
+	}
+	seconds := int(math.Ceil(delay.Seconds()))
+	_ = setHeader(ctx, metadata.Pairs(RetryAfterHeader, strconv.Itoa(seconds)))
+
+	st, err := status.New(codes.ResourceExhausted, message).WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)})
+	if err != nil {
+		return status.Error(codes.ResourceExhausted, message)
+	}
+	return st.Err()
+}
+
+// identify returns the client making the call in ctx and its role.
+func identify(ctx context.Context) (client string, role auth.Role) {
+	p, ok := auth.FromContext(ctx)
+	switch {
+	case ok && p.KeyID != 0:
+		return apiKeyClient(p.KeyID), p.Role
+	case ok && p.Subject != "":
+		return "jwt:" + p.Subject, p.Role
+	case ok:
+		return "ip:" + clientIP(ctx), p.Role
+	default:
+		return "ip:" + clientIP(ctx), auth.Public
+	}
+}
+
+func apiKeyClient(id int) string {
+	return "api_key:" + strconv.Itoa(id)
+}
+
+// clientIP returns the address of the caller. Calls from the gateway, which runs in
+// process, have no peer; their caller is the last address the gateway added to
+// x-forwarded-for, as earlier addresses are set by the caller and cannot be trusted.
+func clientIP(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			return p.Addr.String()
+		}
+		return host
+	}
+	if forwarded := metadata.ValueFromIncomingContext(ctx, "x-forwarded-for"); len(forwarded) > 0 {
+		addrs := strings.Split(forwarded[len(forwarded)-1], ",")
+		return strings.TrimSpace(addrs[len(addrs)-1])
+	}
+	return "unknown"
+}
@@ -0,0 +1,222 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"nba/auth"
+)
+
+const (
+	getPlayer     = "/pb.PlayerGameService/GetPlayer"
+	logPlayerGame = "/pb.PlayerGameService/LogPlayerGame"
+)
+
+type rejections map[string]int
+
+func (r rejections) RateLimited(method, reason string) {
+	r[method+" "+reason]++
+}
+
+// newTestLimiter returns a Limiter whose clock only moves when the test advances it,
+// starting a minute before midnight UTC.
+func newTestLimiter(opts Options) (*Limiter, rejections, *time.Time) {
+	recorded := rejections{}
+	l := New(opts, recorded)
+	now := time.Date(2024, 1, 1, 23, 59, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+	return l, recorded, &now
+}
+
+// headerStream records the headers a handler sets.
+type headerStream struct {
+	header metadata.MD
+}
+
+func (s *headerStream) Method() string { return "" }
+
+func (s *headerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *headerStream) SendHeader(md metadata.MD) error { return s.SetHeader(md) }
+
+func (s *headerStream) SetTrailer(md metadata.MD) error { return nil }
+
+// call makes a unary call to method as the caller in ctx and returns the retry-after
+// header it was sent and its error.
+func call(l *Limiter, ctx context.Context, method string) (string, error) {
+	stream := &headerStream{}
+	ctx = grpc.NewContextWithServerTransportStream(ctx, stream)
+	_, err := l.UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	})
+	retryAfter := ""
+	if values := stream.header.Get(RetryAfterHeader); len(values) > 0 {
+		retryAfter = values[0]
+	}
+	return retryAfter, err
+}
+
+func asKey(id int, role auth.Role) context.Context {
+	return auth.WithPrincipal(context.Background(), auth.Principal{Subject: "key", KeyID: id, Role: role})
+}
+
+// fromPeer returns the context of a gRPC call without credentials from ip.
+func fromPeer(ip string, forwardedFor string) context.Context {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 40000}})
+	if forwardedFor != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", forwardedFor))
+	}
+	return ctx
+}
+
+// fromGateway returns the context of a call without credentials made by the gateway in process.
+func fromGateway(forwardedFor string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-forwarded-for", forwardedFor))
+}
+
+func TestRateLimit(t *testing.T) {
+	l, recorded, now := newTestLimiter(Options{Roles: map[auth.Role]Limit{auth.Reader: {RPS: 1, Burst: 2}}})
+	reader := asKey(1, auth.Reader)
+
+	for i := 0; i < 2; i++ {
+		if _, err := call(l, reader, getPlayer); err != nil {
+			t.Fatalf("call %d within the burst: %v", i+1, err)
+		}
+	}
+	retryAfter, err := call(l, reader, getPlayer)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("call over the burst = %v, want RESOURCE_EXHAUSTED", err)
+	}
+	if retryAfter != "1" {
+		t.Errorf("retry-after = %q, want 1", retryAfter)
+	}
+	var retry *errdetails.RetryInfo
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			retry = info
+		}
+	}
+	if retry == nil || retry.RetryDelay.AsDuration() != time.Second {
+		t.Errorf("details = %v, want a RetryInfo of 1s", status.Convert(err).Details())
+	}
+	if recorded[getPlayer+" rate"] != 1 {
+		t.Errorf("recorded rejections = %v, want one for %s", recorded, getPlayer)
+	}
+
+	// Other clients and roles without a limit are not affected
+	if _, err := call(l, asKey(2, auth.Reader), getPlayer); err != nil {
+		t.Errorf("call of another key: %v", err)
+	}
+	if _, err := call(l, asKey(3, auth.Admin), getPlayer); err != nil {
+		t.Errorf("call of an unlimited role: %v", err)
+	}
+
+	*now = now.Add(time.Second)
+	if _, err := call(l, reader, getPlayer); err != nil {
+		t.Fatalf("call after the bucket refilled: %v", err)
+	}
+}
+
+func TestMethodLimit(t *testing.T) {
+	l, _, _ := newTestLimiter(Options{
+		Roles:   map[auth.Role]Limit{auth.Scorekeeper: {RPS: 1, Burst: 1}},
+		Methods: map[string]map[auth.Role]Limit{logPlayerGame: {auth.Scorekeeper: {RPS: 10, Burst: 3}}},
+	})
+	scorekeeper := asKey(1, auth.Scorekeeper)
+
+	for i := 0; i < 3; i++ {
+		if _, err := call(l, scorekeeper, logPlayerGame); err != nil {
+			t.Fatalf("call %d of the overridden method: %v", i+1, err)
+		}
+	}
+	if _, err := call(l, scorekeeper, logPlayerGame); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("call over the method burst = %v, want RESOURCE_EXHAUSTED", err)
+	}
+	// The overridden method has a bucket of its own
+	if _, err := call(l, scorekeeper, getPlayer); err != nil {
+		t.Fatalf("call of another method: %v", err)
+	}
+	if _, err := call(l, scorekeeper, getPlayer); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("call over the role burst = %v, want RESOURCE_EXHAUSTED", err)
+	}
+}
+
+func TestDailyQuota(t *testing.T) {
+	l, recorded, now := newTestLimiter(Options{DailyQuota: map[auth.Role]int{auth.Reader: 2}})
+	reader := asKey(7, auth.Reader)
+
+	for i := 0; i < 2; i++ {
+		if _, err := call(l, reader, getPlayer); err != nil {
+			t.Fatalf("call %d within the quota: %v", i+1, err)
+		}
+	}
+	retryAfter, err := call(l, reader, getPlayer)
+	if status.Code(err) != codes.ResourceExhausted || retryAfter != "60" {
+		t.Fatalf("call over the quota = %v with retry-after %q, want RESOURCE_EXHAUSTED until midnight", err, retryAfter)
+	}
+	if recorded[getPlayer+" quota"] != 1 {
+		t.Errorf("recorded rejections = %v, want one for the quota", recorded)
+	}
+	midnight := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	if usage := l.KeyUsage(7, auth.Reader); usage.Used != 2 || usage.Quota != 2 || !usage.ResetsAt.Equal(midnight) {
+		t.Errorf("KeyUsage = %+v, want 2 of 2 used until %v", usage, midnight)
+	}
+
+	*now = midnight
+	if usage := l.KeyUsage(7, auth.Reader); usage.Used != 0 {
+		t.Errorf("KeyUsage the next day = %+v, want none used", usage)
+	}
+	if _, err := call(l, reader, getPlayer); err != nil {
+		t.Fatalf("call the next day: %v", err)
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		wantClient string
+		wantRole   auth.Role
+	}{
+		{"direct gRPC caller", fromPeer("203.0.113.9", "198.51.100.1"), "ip:203.0.113.9", auth.Public},
+		{"loopback gRPC caller", fromPeer("127.0.0.1", "198.51.100.1"), "ip:127.0.0.1", auth.Public},
+		{"through the gateway", fromGateway("198.51.100.1"), "ip:198.51.100.1", auth.Public},
+		{"spoofed forwarded addresses are ignored", fromGateway("10.0.0.1, 198.51.100.2"), "ip:198.51.100.2", auth.Public},
+		{"anonymous role", auth.WithPrincipal(fromPeer("203.0.113.9", ""), auth.Principal{Role: auth.Reader}), "ip:203.0.113.9", auth.Reader},
+		{"JWT subject", auth.WithPrincipal(context.Background(), auth.Principal{Subject: "alice", Role: auth.Reader}), "jwt:alice", auth.Reader},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, role := identify(tt.ctx)
+			if client != tt.wantClient || role != tt.wantRole {
+				t.Fatalf("identify = %q, %q, want %q, %q", client, role, tt.wantClient, tt.wantRole)
+			}
+		})
+	}
+}
+
+func TestSweep(t *testing.T) {
+	l, _, now := newTestLimiter(Options{Roles: map[auth.Role]Limit{auth.Public: {RPS: 1, Burst: 5}}})
+	if _, err := call(l, fromPeer("203.0.113.9", ""), getPlayer); err != nil {
+		t.Fatalf("call: %v", err)
+	}
+	*now = now.Add(sweepInterval)
+	if _, err := call(l, fromPeer("203.0.113.10", ""), getPlayer); err != nil {
+		t.Fatalf("call: %v", err)
+	}
+	if _, ok := l.buckets[bucketKey{client: "ip:203.0.113.9"}]; ok || len(l.buckets) != 1 {
+		t.Fatalf("buckets = %v, want only the bucket used since the last sweep", l.buckets)
+	}
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"nba/auth"
+	"nba/model"
+	"nba/postgres"
+	"nba/ratelimit"
+)
+
+// AdminService manages the API keys callers authenticate with.
+type AdminService interface {
+	// CreateAPIKey stores a new key granting role and returns it with the secret key,
+	// which is not stored and cannot be retrieved again.
+	CreateAPIKey(ctx context.Context, name, role string) (model.APIKey, string, error)
+	ListAPIKeys(ctx context.Context) ([]model.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id int) (model.APIKey, error)
+	// GetQuotaUsage returns how much of its daily quota the key id used today.
+	GetQuotaUsage(ctx context.Context, id int) (ratelimit.Usage, error)
+}
+
+// QuotaTracker counts the calls of every API key. It is implemented by ratelimit.Limiter.
+type QuotaTracker interface {
+	KeyUsage(id int, role auth.Role) ratelimit.Usage
+}
+
+var (
+	// ErrInvalidAPIKeyRequest is returned for API key requests with a missing name or an unknown role.
+	ErrInvalidAPIKeyRequest = errors.New("invalid api key request")
+	// ErrAPIKeyNotFound is returned when revoking a key, or reading the usage of a key, that does not exist.
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	// ErrQuotasDisabled is returned for quota usage while rate limiting is disabled.
+	ErrQuotasDisabled = errors.New("rate limiting is disabled, quotas are not tracked")
+)
+
+type adminService struct {
+	playerRepository postgres.PlayerRepository
+	quotas           QuotaTracker
+	now              func() time.Time
+}
+
+// NewAdminService returns an AdminService reading quota usage from quotas, which is nil
+// while rate limiting is disabled.
+func NewAdminService(playerRepository postgres.PlayerRepository, quotas QuotaTracker) AdminService {
+	return &adminService{playerRepository: playerRepository, quotas: quotas, now: time.Now}
+}
+
+// CreateAPIKey implements AdminService.
+func (s *adminService) CreateAPIKey(ctx context.Context, name, role string) (model.APIKey, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return model.APIKey{}, "", fmt.Errorf("%w: name is required", ErrInvalidAPIKeyRequest)
+	}
+	if _, err := auth.ParseRole(role); err != nil {
+		return model.APIKey{}, "", fmt.Errorf("%w: %v", ErrInvalidAPIKeyRequest, err)
+	}
+
+	secret, prefix, hash, err := auth.NewAPIKey()
+	if err != nil {
+		return model.APIKey{}, "", err
+	}
+	key, err := s.playerRepository.SaveAPIKey(ctx, model.APIKey{
+		Name:      name,
+		Prefix:    prefix,
+		Hash:      hash,
+		Role:      role,
+		CreatedAt: s.now().UTC(),
+	})
+	if err != nil {
+		return model.APIKey{}, "", err
+	}
+	return key, secret, nil
+}
+
+// ListAPIKeys implements AdminService.
+func (s *adminService) ListAPIKeys(ctx context.Context) ([]model.APIKey, error) {
+	return s.playerRepository.ListAPIKeys(ctx)
+}
+
+// RevokeAPIKey implements AdminService. Revoked keys are rejected from the next request on.
+func (s *adminService) RevokeAPIKey(ctx context.Context, id int) (model.APIKey, error) {
+	key, err := s.playerRepository.RevokeAPIKey(ctx, id, s.now().UTC())
+	if err != nil {
+		return model.APIKey{}, err
+	}
+	if key.ID == 0 {
+		return model.APIKey{}, fmt.Errorf("%w: %d", ErrAPIKeyNotFound, id)
+	}
+	return key, nil
+}
+
+// GetQuotaUsage implements AdminService.
+func (s *adminService) GetQuotaUsage(ctx context.Context, id int) (ratelimit.Usage, error) {
+	if s.quotas == nil {
+		return ratelimit.Usage{}, ErrQuotasDisabled
+	}
+	key, err := s.playerRepository.GetAPIKey(ctx, id)
+	if err != nil {
+		return ratelimit.Usage{}, err
+	}
+	if key.ID == 0 {
+		return ratelimit.Usage{}, fmt.Errorf("%w: %d", ErrAPIKeyNotFound, id)
+	}
+	return s.quotas.KeyUsage(key.ID, auth.Role(key.Role)), nil
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"nba/importer"
+	"nba/model"
+)
+
+// cachedService caches season averages for a fixed time. Every write clears the cache,
+// so a client never reads averages older than its own writes.
+type cachedService struct {
+	Service
+	ttl        time.Duration
+	maxEntries int
+	recorder   Recorder
+	now        func() time.Time
+
+	mu      sync.Mutex
+	entries map[cacheKey]cacheEntry
+	// generation counts the clears, so that averages read before a write are not
+	// cached after it.
+	generation uint64
+}
+
+type cacheKey struct {
+	kind   string
+	id     int
+	season int
+}
+
+type cacheEntry struct {
+	value   any
+	expires time.Time
+}
+
+// NewCachedService wraps svc with a cache of at most maxEntries season averages, each kept for ttl.
+// Every lookup is reported to recorder as a hit or a miss.
+func NewCachedService(svc Service, ttl time.Duration, maxEntries int, recorder Recorder) Service {
+	return &cachedService{
+		Service:    svc,
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		recorder:   recorder,
+		now:        time.Now,
+		entries:    make(map[cacheKey]cacheEntry),
+	}
+}
+
+// LogPlayerGame implements Service.
+func (c *cachedService) LogPlayerGame(ctx context.Context, playerId int, request model.LogPlayerGameRequest) error {
+	err := c.Service.LogPlayerGame(ctx, playerId, request)
+	if err == nil {
+		c.clear()
+	}
+	return err
+}
+
+// RecordPlays implements Service.
+func (c *cachedService) RecordPlays(ctx context.Context, gameId int, plays []model.Play) ([]model.Play, error) {
+	recorded, err := c.Service.RecordPlays(ctx, gameId, plays)
+	if err == nil {
+		c.clear()
+	}
+	return recorded, err
+}
+
+// EditPlay implements Service.
+func (c *cachedService) EditPlay(ctx context.Context, play model.Play) (model.Play, error) {
+	edited, err := c.Service.EditPlay(ctx, play)
+	if err == nil {
+		c.clear()
+	}
+	return edited, err
+}
+
+// DeletePlay implements Service.
+func (c *cachedService) DeletePlay(ctx context.Context, gameId int, sequence int) error {
+	err := c.Service.DeletePlay(ctx, gameId, sequence)
+	if err == nil {
+		c.clear()
+	}
+	return err
+}
+
+// RecordStints implements Service. Stints change the plus-minus of season averages.
+func (c *cachedService) RecordStints(ctx context.Context, gameId int, teamId int, stints []model.Stint) error {
+	err := c.Service.RecordStints(ctx, gameId, teamId, stints)
+	if err == nil {
+		c.clear()
+	}
+	return err
+}
+
+// ImportStats implements Service. Even a failed import may have committed some batches.
+func (c *cachedService) ImportStats(ctx context.Context, r io.Reader, opts importer.Options) (importer.Report, error) {
+	report, err := c.Service.ImportStats(ctx, r, opts)
+	if !report.DryRun && report.Imported > 0 {
+		c.clear()
+	}
+	return report, err
+}
+
+// GetPlayerSeasonAverages implements Service.
+func (c *cachedService) GetPlayerSeasonAverages(ctx context.Context, request model.GetPlayerGameStatsRequest) (*model.PlayerSeasonAverage, error) {
+	key := cacheKey{kind: "player", id: request.PlayerID, season: request.SeasonYear}
+	cached, generation, ok := c.get(key)
+	if ok {
+		averages := cached.(model.PlayerSeasonAverage)
+		return &averages, nil
+	}
+	averages, err := c.Service.GetPlayerSeasonAverages(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	c.put(key, *averages, generation)
+	return averages, nil
+}
+
+// GetTeamSeasonAverages implements Service.
+func (c *cachedService) GetTeamSeasonAverages(ctx context.Context, request model.GetTeamGameStatsRequest) (*model.TeamSeasoAverage, error) {
+	key := cacheKey{kind: "team", id: request.TeamID, season: request.SeasonYear}
+	cached, generation, ok := c.get(key)
+	if ok {
+		averages := cached.(model.TeamSeasoAverage)
+		return &averages, nil
+	}
+	averages, err := c.Service.GetTeamSeasonAverages(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	c.put(key, *averages, generation)
+	return averages, nil
+}
+
+func (c *cachedService) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+	c.generation++
+}
+
+// get returns the cached value of key, if any, and the generation of the cache to pass
+// to put on a miss.
+func (c *cachedService) get(key cacheKey) (any, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	hit := ok && c.now().Before(entry.expires)
+	c.recorder.CacheLookup(key.kind, hit)
+	if !hit {
+		return nil, c.generation, false
+	}
+	return entry.value, c.generation, true
+}
+
+// put caches value under key, unless the cache was cleared since the generation value
+// was read in: a write may have committed after it.
+func (c *cachedService) put(key cacheKey, value any, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation {
+		return
+	}
+	now := c.now()
+
+	// Make room by dropping expired entries first, then arbitrary ones
+	if len(c.entries) >= c.maxEntries {
+		for k, entry := range c.entries {
+			if !now.Before(entry.expires) {
+				delete(c.entries, k)
+			}
+		}
+	}
+	for k := range c.entries {
+		if len(c.entries) < c.maxEntries {
+			break
+		}
+		delete(c.entries, k)
+	}
+	c.entries[key] = cacheEntry{value: value, expires: now.Add(c.ttl)}
+}
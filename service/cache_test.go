@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"nba/model"
+)
+
+// countingService counts the season average lookups that reach it.
+type countingService struct {
+	Service
+	playerCalls, teamCalls int
+	logErr                 error
+	// lookingUp, if set, runs while a player lookup reads the stat lines.
+	lookingUp func()
+}
+
+func (s *countingService) LogPlayerGame(ctx context.Context, playerId int, request model.LogPlayerGameRequest) error {
+	return s.logErr
+}
+
+func (s *countingService) GetPlayerSeasonAverages(ctx context.Context, request model.GetPlayerGameStatsRequest) (*model.PlayerSeasonAverage, error) {
+	s.playerCalls++
+	if s.lookingUp != nil {
+		s.lookingUp()
+	}
+	return &model.PlayerSeasonAverage{PlayerID: request.PlayerID, Season: request.SeasonYear, GamesPlayed: s.playerCalls}, nil
+}
+
+func (s *countingService) GetTeamSeasonAverages(ctx context.Context, request model.GetTeamGameStatsRequest) (*model.TeamSeasoAverage, error) {
+	s.teamCalls++
+	return &model.TeamSeasoAverage{TeamID: request.TeamID, Season: request.SeasonYear, GamesPlayed: s.teamCalls}, nil
+}
+
+// countingRecorder counts cache lookups.
+type countingRecorder struct {
+	NopRecorder
+	hits, misses int
+}
+
+func (r *countingRecorder) CacheLookup(kind string, hit bool) {
+	if hit {
+		r.hits++
+	} else {
+		r.misses++
+	}
+}
+
+func TestCachedService(t *testing.T) {
+	ctx := context.Background()
+	backend := &countingService{}
+	recorder := &countingRecorder{}
+	svc := NewCachedService(backend, time.Minute, 2, recorder).(*cachedService)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	player := model.GetPlayerGameStatsRequest{PlayerID: 1, SeasonYear: 2024}
+	lookup := func() int {
+		t.Helper()
+		averages, err := svc.GetPlayerSeasonAverages(ctx, player)
+		if err != nil {
+			t.Fatalf("GetPlayerSeasonAverages() error = %v", err)
+		}
+		return averages.GamesPlayed
+	}
+
+	// Repeated lookups are served from the cache until the entry expires
+	if lookup() != 1 || lookup() != 1 {
+		t.Fatalf("lookups reached the service %d times, want 1", backend.playerCalls)
+	}
+	now = now.Add(time.Minute)
+	if lookup() != 2 {
+		t.Fatalf("expired entry was not refreshed")
+	}
+
+	if recorder.hits != 1 || recorder.misses != 2 {
+		t.Fatalf("recorded %d hits and %d misses, want 1 and 2", recorder.hits, recorder.misses)
+	}
+
+	// Team averages are cached separately
+	team := model.GetTeamGameStatsRequest{TeamID: 1, SeasonYear: 2024}
+	svc.GetTeamSeasonAverages(ctx, team)
+	svc.GetTeamSeasonAverages(ctx, team)
+	if backend.teamCalls != 1 {
+		t.Fatalf("team lookups reached the service %d times, want 1", backend.teamCalls)
+	}
+
+	// A failed write keeps the cache, a successful one clears it
+	backend.logErr = errors.New("game not found")
+	svc.LogPlayerGame(ctx, 1, model.LogPlayerGameRequest{})
+	if lookup() != 2 {
+		t.Fatalf("failed write cleared the cache")
+	}
+	backend.logErr = nil
+	svc.LogPlayerGame(ctx, 1, model.LogPlayerGameRequest{})
+	if lookup() != 3 {
+		t.Fatalf("successful write did not clear the cache")
+	}
+
+	// The cache never holds more than maxEntries
+	for season := 2000; season < 2010; season++ {
+		svc.GetPlayerSeasonAverages(ctx, model.GetPlayerGameStatsRequest{PlayerID: 1, SeasonYear: season})
+	}
+	if len(svc.entries) > 2 {
+		t.Fatalf("cache holds %d entries, want at most 2", len(svc.entries))
+	}
+}
+
+func TestCachedServiceWriteDuringLookup(t *testing.T) {
+	ctx := context.Background()
+	backend := &countingService{}
+	svc := NewCachedService(backend, time.Minute, 2, NopRecorder{})
+	player := model.GetPlayerGameStatsRequest{PlayerID: 1, SeasonYear: 2024}
+
+	// A stat line is logged after the lookup missed and before it caches what it read
+	backend.lookingUp = func() {
+		backend.lookingUp = nil
+		if err := svc.LogPlayerGame(ctx, 1, model.LogPlayerGameRequest{}); err != nil {
+			t.Fatalf("LogPlayerGame() error = %v", err)
+		}
+	}
+	if _, err := svc.GetPlayerSeasonAverages(ctx, player); err != nil {
+		t.Fatalf("GetPlayerSeasonAverages() error = %v", err)
+	}
+
+	averages, err := svc.GetPlayerSeasonAverages(ctx, player)
+	if err != nil {
+		t.Fatalf("GetPlayerSeasonAverages() error = %v", err)
+	}
+	if averages.GamesPlayed != 2 {
+		t.Fatalf("lookup after the write returned averages read before it")
+	}
+}
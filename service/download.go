@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"nba/pb"
+)
+
+// RegisterExportDownloads serves ExportStats on mux as a file download, the chunks making
+// up the response body, as the generated in-process gateway cannot stream. The options
+// are read from the query, as in /api/v1/exports/stat_lines?season=2024&format=parquet.
+// Calls run through interceptor, like the streams of the gRPC port. mux must already hold
+// the generated routes, which the route registered here takes precedence over.
+func RegisterExportDownloads(mux *runtime.ServeMux, srv pb.PlayerGameServiceServer, interceptor grpc.StreamServerInterceptor) error {
+	const pattern = "/api/v1/exports/{kind}"
+	method := pb.PlayerGameService_ExportStats_FullMethodName
+	return mux.HandlePath(http.MethodGet, pattern, func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		_, marshaler := runtime.MarshalerForRequest(mux, r)
+		transport := &runtime.ServerTransportStream{}
+		ctx := grpc.NewContextWithServerTransportStream(r.Context(), transport)
+		ctx, err := runtime.AnnotateIncomingContext(ctx, mux, r, method, runtime.WithHTTPPathPattern(pattern))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, marshaler, w, r, err)
+			return
+		}
+		request := &pb.ExportStatsRequest{Kind: pathParams["kind"]}
+		if err := runtime.PopulateQueryParameters(request, r.URL.Query(), utilities.NewDoubleArray([][]string{{"kind"}})); err != nil {
+			runtime.HTTPError(ctx, mux, marshaler, w, r, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+
+		stream := &downloadStream{ctx: ctx, w: w, transport: transport}
+		if opts, err := toExportOptions(request); err == nil {
+			stream.contentType = opts.Format.ContentType()
+			stream.filename = fmt.Sprintf("%s.%s", opts.Kind, opts.Format)
+		}
+		info := &grpc.StreamServerInfo{FullMethod: method, IsServerStream: true}
+		err = interceptor(srv, stream, info, func(_ any, ss grpc.ServerStream) error {
+			return srv.ExportStats(request, &grpc.GenericServerStream[pb.ExportStatsRequest, pb.ExportStatsChunk]{ServerStream: ss})
+		})
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return
+		}
+		if err == nil {
+			stream.start()
+			return
+		}
+		// Cut the connection rather than end a partial file as if it were complete
+		if stream.started {
+			panic(http.ErrAbortHandler)
+		}
+		ctx = runtime.NewServerMetadataContext(ctx, runtime.ServerMetadata{HeaderMD: metadata.Join(stream.header, transport.Header())})
+		runtime.HTTPError(ctx, mux, marshaler, w, r, err)
+	})
+}
+
+// downloadStream is the grpc.ServerStream of an export served as a file download. The
+// response starts with the first chunk, sending the headers set so far.
+type downloadStream struct {
+	ctx         context.Context
+	w           http.ResponseWriter
+	transport   *runtime.ServerTransportStream
+	contentType string
+	filename    string
+
+	header  metadata.MD
+	started bool
+}
+
+// SetHeader implements grpc.ServerStream.
+func (s *downloadStream) SetHeader(md metadata.MD) error {
+	if s.started {
+		return errors.New("headers already sent")
+	}
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+// SendHeader implements grpc.ServerStream.
+func (s *downloadStream) SendHeader(md metadata.MD) error {
+	if err := s.SetHeader(md); err != nil {
+		return err
+	}
+	s.start()
+	return nil
+}
+
+// SetTrailer implements grpc.ServerStream. A download has no trailers.
+func (s *downloadStream) SetTrailer(metadata.MD) {}
+
+// Context implements grpc.ServerStream.
+func (s *downloadStream) Context() context.Context {
+	return s.ctx
+}
+
+// SendMsg implements grpc.ServerStream.
+func (s *downloadStream) SendMsg(m any) error {
+	chunk, ok := m.(*pb.ExportStatsChunk)
+	if !ok {
+		return fmt.Errorf("cannot download %T", m)
+	}
+	s.start()
+	_, err := s.w.Write(chunk.Data)
+	return err
+}
+
+// RecvMsg implements grpc.ServerStream. The request came with the URL, so there is
+// nothing more to receive.
+func (s *downloadStream) RecvMsg(any) error {
+	return io.EOF
+}
+
+// start writes the response headers once.
+func (s *downloadStream) start() {
+	if s.started {
+		return
+	}
+	s.started = true
+	for key, values := range metadata.Join(s.header, s.transport.Header()) {
+		if name, ok := OutgoingHeaderMatcher(key); ok {
+			for _, v := range values {
+				s.w.Header().Add(name, v)
+			}
+		}
+	}
+	s.w.Header().Set("Content-Type", s.contentType)
+	s.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", s.filename))
+	s.w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"nba/pb"
+)
+
+// HeartbeatInterval is how often an idle event stream sends a comment, which keeps
+// proxies from closing the connection and tells the client the stream is open.
+const HeartbeatInterval = 15 * time.Second
+
+// RegisterEventStreams serves WatchGame and WatchPlayer on mux as Server-Sent Events, one
+// JSON encoded GameEvent per event, as the generated in-process gateway cannot stream.
+// Calls run through interceptor, like the streams of the gRPC port. mux must already hold
+// the generated routes, which the routes registered here take precedence over.
+func RegisterEventStreams(mux *runtime.ServeMux, srv pb.PlayerGameServiceServer, interceptor grpc.StreamServerInterceptor) error {
+	err := mux.HandlePath(http.MethodGet, "/api/v1/games/{game_id}/events", func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		serveEvents(mux, w, r, srv, interceptor, pb.PlayerGameService_WatchGame_FullMethodName, "/api/v1/games/{game_id}/events",
+			func(stream grpc.ServerStream) error {
+				gameID, err := runtime.Int32(pathParams["game_id"])
+				if err != nil {
+					return status.Errorf(codes.InvalidArgument, "game_id: %v", err)
+				}
+				return srv.WatchGame(&pb.WatchGameRequest{GameId: gameID},
+					&grpc.GenericServerStream[pb.WatchGameRequest, pb.GameEvent]{ServerStream: stream})
+			})
+	})
+	if err != nil {
+		return err
+	}
+	return mux.HandlePath(http.MethodGet, "/api/v1/player_game/{player_id}/events", func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		serveEvents(mux, w, r, srv, interceptor, pb.PlayerGameService_WatchPlayer_FullMethodName, "/api/v1/player_game/{player_id}/events",
+			func(stream grpc.ServerStream) error {
+				playerID, err := runtime.Int32(pathParams["player_id"])
+				if err != nil {
+					return status.Errorf(codes.InvalidArgument, "player_id: %v", err)
+				}
+				return srv.WatchPlayer(&pb.WatchPlayerRequest{PlayerId: playerID},
+					&grpc.GenericServerStream[pb.WatchPlayerRequest, pb.GameEvent]{ServerStream: stream})
+			})
+	})
+}
+
+// serveEvents runs call through interceptor as the server streaming method, streaming
+// its messages to the client. Errors before the first message are answered with the
+// HTTP status of the error, later ones with a final "error" event.
+func serveEvents(mux *runtime.ServeMux, w http.ResponseWriter, r *http.Request, srv any, interceptor grpc.StreamServerInterceptor,
+	method, pattern string, call func(stream grpc.ServerStream) error) {
+	_, marshaler := runtime.MarshalerForRequest(mux, r)
+	transport := &runtime.ServerTransportStream{}
+	ctx := grpc.NewContextWithServerTransportStream(r.Context(), transport)
+	ctx, err := runtime.AnnotateIncomingContext(ctx, mux, r, method, runtime.WithHTTPPathPattern(pattern))
+	if err != nil {
+		runtime.HTTPError(ctx, mux, marshaler, w, r, err)
+		return
+	}
+
+	stream := &eventStream{ctx: ctx, w: w, marshaler: marshaler, transport: transport}
+	// The heartbeat is done writing before the error is written or the handler returns,
+	// after which the response must not be touched
+	var heartbeats sync.WaitGroup
+	heartbeatCtx, cancelHeartbeat := context.WithCancel(ctx)
+	stopHeartbeat := func() {
+		cancelHeartbeat()
+		heartbeats.Wait()
+	}
+	defer stopHeartbeat()
+	info := &grpc.StreamServerInfo{FullMethod: method, IsServerStream: true}
+	err = interceptor(srv, stream, info, func(_ any, ss grpc.ServerStream) error {
+		heartbeats.Add(1)
+		go func() {
+			defer heartbeats.Done()
+			stream.heartbeat(heartbeatCtx)
+		}()
+		return call(ss)
+	})
+	stopHeartbeat()
+	if err == nil || errors.Is(ctx.Err(), context.Canceled) {
+		return
+	}
+	if stream.sendError(err) {
+		return
+	}
+	ctx = runtime.NewServerMetadataContext(ctx, runtime.ServerMetadata{HeaderMD: stream.headers()})
+	runtime.HTTPError(ctx, mux, marshaler, w, r, err)
+}
+
+// eventStream is the grpc.ServerStream of a call served as Server-Sent Events. The
+// response starts with the first message or heartbeat, sending the headers set so far.
+type eventStream struct {
+	ctx       context.Context
+	w         http.ResponseWriter
+	marshaler runtime.Marshaler
+	transport *runtime.ServerTransportStream
+
+	mu      sync.Mutex
+	header  metadata.MD
+	started bool
+}
+
+// SetHeader implements grpc.ServerStream.
+func (s *eventStream) SetHeader(md metadata.MD) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started {
+		return errors.New("headers already sent")
+	}
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+// SendHeader implements grpc.ServerStream.
+func (s *eventStream) SendHeader(md metadata.MD) error {
+	if err := s.SetHeader(md); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.start()
+	return nil
+}
+
+// SetTrailer implements grpc.ServerStream. Server-Sent Events have no trailers.
+func (s *eventStream) SetTrailer(metadata.MD) {}
+
+// Context implements grpc.ServerStream.
+func (s *eventStream) Context() context.Context {
+	return s.ctx
+}
+
+// SendMsg implements grpc.ServerStream.
+func (s *eventStream) SendMsg(m any) error {
+	data, err := s.marshaler.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return s.write("data: %s\n\n", data)
+}
+
+// RecvMsg implements grpc.ServerStream. The request came with the URL, so there is
+// nothing more to receive.
+func (s *eventStream) RecvMsg(any) error {
+	return io.EOF
+}
+
+// heartbeat sends a comment every HeartbeatInterval until ctx is done.
+func (s *eventStream) heartbeat(ctx context.Context) {
+	ticker := time.NewTicker(HeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if s.write(": heartbeat\n\n") != nil {
+				return
+			}
+		}
+	}
+}
+
+// sendError ends a started stream with an "error" event holding the status of err, and
+// reports whether the stream had started.
+func (s *eventStream) sendError(err error) bool {
+	s.mu.Lock()
+	started := s.started
+	s.mu.Unlock()
+	if !started {
+		return false
+	}
+	data, marshalErr := s.marshaler.Marshal(status.Convert(err).Proto())
+	if marshalErr == nil {
+		s.write("event: error\ndata: %s\n\n", data)
+	}
+	return true
+}
+
+func (s *eventStream) write(format string, args ...any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.start()
+	if _, err := fmt.Fprintf(s.w, format, args...); err != nil {
+		return err
+	}
+	return http.NewResponseController(s.w).Flush()
+}
+
+// start writes the response headers once; s.mu must be held.
+func (s *eventStream) start() {
+	if s.started {
+		return
+	}
+	s.started = true
+	for key, values := range metadata.Join(s.header, s.transport.Header()) {
+		if name, ok := OutgoingHeaderMatcher(key); ok {
+			for _, v := range values {
+				s.w.Header().Add(name, v)
+			}
+		}
+	}
+	s.w.Header().Set("Content-Type", "text/event-stream")
+	s.w.Header().Set("Cache-Control", "no-cache")
+	// Keep proxies such as nginx from buffering the events
+	s.w.Header().Set("X-Accel-Buffering", "no")
+	s.w.WriteHeader(http.StatusOK)
+}
+
+// headers returns the headers set on the stream so far.
+func (s *eventStream) headers() metadata.MD {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return metadata.Join(s.header, s.transport.Header())
+}
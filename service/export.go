@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+	"io"
+
+	"nba/exporter"
+)
+
+// ExportStats implements Service.
+func (s *ServiceStruct) ExportStats(ctx context.Context, w io.Writer, opts exporter.Options) (int, error) {
+	return exporter.New(s.playerRepository).Run(ctx, w, opts)
+}
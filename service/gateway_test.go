@@ -0,0 +1,608 @@
+package service_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"nba/auth"
+	"nba/live"
+	"nba/memory"
+	"nba/middleware"
+	"nba/model"
+	"nba/pb"
+	"nba/ratelimit"
+	"nba/service"
+	"nba/validation"
+)
+
+// newGateway returns an HTTP server for the gateway calling the service in process,
+// wired the same way as cmd/main.go.
+func newGateway(t *testing.T) *httptest.Server {
+	t.Helper()
+	return newGatewayWithRepository(t, memory.NewPlayerRepository(), nil)
+}
+
+// newGatewayWithRepository is newGateway serving repo and the quota usage of quotas,
+// authorizing RPCs by service.AccessPolicy when interceptors include the auth interceptor.
+func newGatewayWithRepository(t *testing.T, repo *memory.PlayerRepository, quotas service.QuotaTracker, interceptors ...grpc.UnaryServerInterceptor) *httptest.Server {
+	t.Helper()
+	return newGatewayWithHub(t, repo, live.NewHub(), quotas, interceptors...)
+}
+
+// newGatewayWithHub is newGatewayWithRepository publishing stat lines to hub.
+func newGatewayWithHub(t *testing.T, repo *memory.PlayerRepository, hub *live.Hub, quotas service.QuotaTracker, interceptors ...grpc.UnaryServerInterceptor) *httptest.Server {
+	t.Helper()
+	ctx := context.Background()
+
+	seedGatewayData(t, repo)
+	rules, err := validation.NewRegistry("")
+	if err != nil {
+		t.Fatalf("NewRegistry: %v", err)
+	}
+	logger := zap.NewNop().Sugar()
+	svc := service.NewService(logger, repo, rules, hub, nil)
+	interceptor := middleware.ChainUnary(append(interceptors, middleware.UnaryRecovery(logger))...)
+
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(service.IncomingHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(service.OutgoingHeaderMatcher),
+	)
+	grpcServer := service.NewGRPCServer(logger, svc)
+	playerGameServer := service.NewInProcessPlayerGameServer(grpcServer, interceptor)
+	if err := pb.RegisterPlayerGameServiceHandlerServer(ctx, mux, playerGameServer); err != nil {
+		t.Fatalf("RegisterPlayerGameServiceHandlerServer: %v", err)
+	}
+	if err := service.RegisterEventStreams(mux, grpcServer, middleware.StreamRecovery(logger)); err != nil {
+		t.Fatalf("RegisterEventStreams: %v", err)
+	}
+	if err := service.RegisterExportDownloads(mux, grpcServer, middleware.StreamRecovery(logger)); err != nil {
+		t.Fatalf("RegisterExportDownloads: %v", err)
+	}
+	adminServer := service.NewInProcessAdminServer(service.NewAdminServer(service.NewAdminService(repo, quotas)), interceptor)
+	if err := pb.RegisterAdminServiceHandlerServer(ctx, mux, adminServer); err != nil {
+		t.Fatalf("RegisterAdminServiceHandlerServer: %v", err)
+	}
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// seedGatewayData stores teams 1 and 2, player 1 on team 1 and game 1 between them.
+func seedGatewayData(t *testing.T, repo *memory.PlayerRepository) {
+	t.Helper()
+	ctx := context.Background()
+	for _, name := range []string{"Team A", "Team B"} {
+		if _, err := repo.SaveTeam(ctx, model.Team{Name: name}); err != nil {
+			t.Fatalf("SaveTeam: %v", err)
+		}
+	}
+	if _, err := repo.SavePlayer(ctx, model.Player{Name: "Player 1", CurrentTeamID: 1}); err != nil {
+		t.Fatalf("SavePlayer: %v", err)
+	}
+	err := repo.SaveRosterEntry(ctx, model.RosterEntry{PlayerID: 1, TeamID: 1, StartDate: time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatalf("SaveRosterEntry: %v", err)
+	}
+	_, err = repo.SaveGame(ctx, model.Game{Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), SeasonID: 2024, TeamAID: 1, TeamBID: 2, League: validation.DefaultLeague})
+	if err != nil {
+		t.Fatalf("SaveGame: %v", err)
+	}
+}
+
+// do sends an HTTP request to the gateway and decodes the JSON response.
+func do(t *testing.T, server *httptest.Server, method, path, body string, header http.Header) (int, map[string]any) {
+	t.Helper()
+	req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	var decoded map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		t.Fatalf("decode %s %s: %v", method, path, err)
+	}
+	return resp.StatusCode, decoded
+}
+
+func TestGatewayLogPlayerGame(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantCode   float64
+	}{
+		{"valid stat line", `{"player_id": 1, "game_id": 1, "points": 25, "fouls": 2, "minutes_played": 36}`, http.StatusOK, 0},
+		{"broken league rule", `{"player_id": 1, "game_id": 1, "fouls": 7}`, http.StatusBadRequest, 3},
+		{"malformed body", `{"player_id": "one"}`, http.StatusBadRequest, 3},
+		{"unknown game", `{"player_id": 1, "game_id": 9, "points": 10}`, http.StatusNotFound, 5},
+		{"unknown player", `{"player_id": 9, "game_id": 1, "points": 10}`, http.StatusNotFound, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newGateway(t)
+			status, body := do(t, server, http.MethodPost, "/api/v1/player_game", tt.body, nil)
+			if status != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %v)", status, tt.wantStatus, body)
+			}
+			if tt.wantStatus == http.StatusOK {
+				if body["success"] != true {
+					t.Fatalf("body = %v, want success", body)
+				}
+				return
+			}
+			if body["code"] != tt.wantCode {
+				t.Fatalf("code = %v, want %v", body["code"], tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestGatewayNotOnRoster(t *testing.T) {
+	repo := memory.NewPlayerRepository()
+	server := newGatewayWithRepository(t, repo, nil)
+	// Player 2 plays for team 2 but was never on its roster
+	if _, err := repo.SavePlayer(context.Background(), model.Player{Name: "Player 2", CurrentTeamID: 2}); err != nil {
+		t.Fatalf("SavePlayer: %v", err)
+	}
+
+	status, body := do(t, server, http.MethodPost, "/api/v1/player_game", `{"player_id": 2, "game_id": 1, "points": 10}`, nil)
+	if status != http.StatusBadRequest || body["code"] != float64(codes.FailedPrecondition) {
+		t.Fatalf("status = %d, body = %v, want FAILED_PRECONDITION", status, body)
+	}
+}
+
+func TestGatewayValidationDetails(t *testing.T) {
+	server := newGateway(t)
+	_, body := do(t, server, http.MethodPost, "/api/v1/player_game", `{"player_id": 1, "game_id": 1, "fouls": 7}`, nil)
+
+	details, _ := body["details"].([]any)
+	if len(details) != 1 {
+		t.Fatalf("details = %v, want one BadRequest", body["details"])
+	}
+	detail := details[0].(map[string]any)
+	if detail["@type"] != "type.googleapis.com/google.rpc.BadRequest" {
+		t.Fatalf("detail type = %v", detail["@type"])
+	}
+	violations, _ := detail["fieldViolations"].([]any)
+	if len(violations) != 1 || violations[0].(map[string]any)["field"] != "fouls" {
+		t.Fatalf("fieldViolations = %v, want fouls", detail["fieldViolations"])
+	}
+}
+
+func TestGatewayIdempotencyKeyHeader(t *testing.T) {
+	server := newGateway(t)
+	header := http.Header{"Idempotency-Key": {"retry-1"}}
+	body := `{"player_id": 1, "game_id": 1, "points": 25}`
+
+	// A retry with the same key succeeds instead of failing as a duplicate
+	for i := 0; i < 2; i++ {
+		if status, resp := do(t, server, http.MethodPost, "/api/v1/player_game", body, header); status != http.StatusOK {
+			t.Fatalf("attempt %d: status = %d (body %v)", i+1, status, resp)
+		}
+	}
+
+	// Reusing the key for a different request conflicts
+	status, _ := do(t, server, http.MethodPost, "/api/v1/player_game", `{"player_id": 1, "game_id": 1, "points": 30}`, header)
+	if status != http.StatusConflict {
+		t.Fatalf("status = %d, want %d", status, http.StatusConflict)
+	}
+
+	// Without a key the same stat line is a duplicate
+	status, _ = do(t, server, http.MethodPost, "/api/v1/player_game", body, nil)
+	if status != http.StatusConflict {
+		t.Fatalf("status = %d, want %d", status, http.StatusConflict)
+	}
+}
+
+func TestGatewayGetPlayerGameSeasonStats(t *testing.T) {
+	server := newGateway(t)
+	do(t, server, http.MethodPost, "/api/v1/player_game", `{"player_id": 1, "game_id": 1, "points": 25, "assists": 4, "minutes_played": 36.5}`, nil)
+
+	status, body := do(t, server, http.MethodGet, "/api/v1/player_game/seasons/2024/players/1", "", nil)
+	if status != http.StatusOK {
+		t.Fatalf("status = %d (body %v)", status, body)
+	}
+	stats, _ := body["playerGameStats"].(map[string]any)
+	if stats["points"] != float64(25) || stats["assists"] != float64(4) || stats["minutesPlayed"] != 36.5 {
+		t.Fatalf("playerGameStats = %v", stats)
+	}
+}
+
+func TestGatewayUnknownTeam(t *testing.T) {
+	server := newGateway(t)
+	status, body := do(t, server, http.MethodGet, "/api/v1/team_game/seasons/2024/teams/9/lineups", "", nil)
+	if status != http.StatusNotFound || body["message"] != "team not found" {
+		t.Fatalf("unknown team = %d %v, want NOT_FOUND", status, body)
+	}
+}
+
+func TestGatewayEmptySeason(t *testing.T) {
+	server := newGateway(t)
+
+	status, body := do(t, server, http.MethodGet, "/api/v1/player_game/seasons/2030/players/1", "", nil)
+	if status != http.StatusOK {
+		t.Fatalf("status = %d (body %v)", status, body)
+	}
+	stats, _ := body["playerGameStats"].(map[string]any)
+	if stats["gamesPlayed"] != float64(0) || stats["points"] != float64(0) || stats["playerId"] != float64(1) {
+		t.Fatalf("playerGameStats = %v, want zero games for player 1", stats)
+	}
+}
+
+func TestGatewayValidateGame(t *testing.T) {
+	server := newGateway(t)
+	do(t, server, http.MethodPost, "/api/v1/player_game", `{"player_id": 1, "game_id": 1, "points": 25}`, nil)
+
+	status, body := do(t, server, http.MethodGet, "/api/v1/games/1/validation", "", nil)
+	if status != http.StatusOK {
+		t.Fatalf("status = %d (body %v)", status, body)
+	}
+	if body["valid"] != true {
+		t.Fatalf("valid = %v, want true (body %v)", body["valid"], body)
+	}
+	checks, _ := body["checks"].([]any)
+	if len(checks) != 3 {
+		t.Fatalf("checks = %v, want roster check and two score checks", checks)
+	}
+	if got := checks[0].(map[string]any)["status"]; got != "CHECK_STATUS_PASSED" {
+		t.Fatalf("roster check status = %v", got)
+	}
+}
+
+func TestGatewayAuthorization(t *testing.T) {
+	repo := memory.NewPlayerRepository()
+	authenticator, err := auth.NewAuthenticator(repo, auth.Options{})
+	if err != nil {
+		t.Fatalf("NewAuthenticator: %v", err)
+	}
+	server := newGatewayWithRepository(t, repo, nil, auth.UnaryServerInterceptor(authenticator, service.AccessPolicy))
+
+	// The admin key is stored directly, like the bootstrap key; the others are created through the API.
+	admin, prefix, hash, _ := auth.NewAPIKey()
+	if _, err := repo.SaveAPIKey(context.Background(), model.APIKey{Name: "admin", Prefix: prefix, Hash: hash, Role: "admin", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("SaveAPIKey: %v", err)
+	}
+	createKey := func(role string) (string, float64) {
+		status, body := do(t, server, http.MethodPost, "/api/v1/admin/api_keys", `{"name": "`+role+`", "role": "`+role+`"}`, http.Header{"X-Api-Key": {admin}})
+		if status != http.StatusOK {
+			t.Fatalf("create %s key: status = %d (body %v)", role, status, body)
+		}
+		return body["key"].(string), body["apiKey"].(map[string]any)["id"].(float64)
+	}
+	reader, _ := createKey("reader")
+	scorekeeper, scorekeeperID := createKey("scorekeeper")
+
+	statLine := `{"player_id": 1, "game_id": 1, "points": 25}`
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		header     http.Header
+		wantStatus int
+	}{
+		{"no credentials", http.MethodPost, "/api/v1/player_game", statLine, nil, http.StatusUnauthorized},
+		{"unknown key", http.MethodPost, "/api/v1/player_game", statLine, http.Header{"X-Api-Key": {auth.APIKeyPrefix + "unknown"}}, http.StatusUnauthorized},
+		{"reader writes", http.MethodPost, "/api/v1/player_game", statLine, http.Header{"X-Api-Key": {reader}}, http.StatusForbidden},
+		{"scorekeeper writes", http.MethodPost, "/api/v1/player_game", statLine, http.Header{"Authorization": {"Bearer " + scorekeeper}}, http.StatusOK},
+		{"scorekeeper lists keys", http.MethodGet, "/api/v1/admin/api_keys", "", http.Header{"X-Api-Key": {scorekeeper}}, http.StatusForbidden},
+		{"admin lists keys", http.MethodGet, "/api/v1/admin/api_keys", "", http.Header{"X-Api-Key": {admin}}, http.StatusOK},
+		{"admin revokes the scorekeeper key", http.MethodDelete, fmt.Sprintf("/api/v1/admin/api_keys/%d", int(scorekeeperID)), "", http.Header{"X-Api-Key": {admin}}, http.StatusOK},
+		{"revoked key writes", http.MethodPost, "/api/v1/player_game", statLine, http.Header{"X-Api-Key": {scorekeeper}}, http.StatusUnauthorized},
+		{"admin revokes an unknown key", http.MethodDelete, "/api/v1/admin/api_keys/99", "", http.Header{"X-Api-Key": {admin}}, http.StatusNotFound},
+		{"admin creates a key with an unknown role", http.MethodPost, "/api/v1/admin/api_keys", `{"name": "x", "role": "owner"}`, http.Header{"X-Api-Key": {admin}}, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		status, body := do(t, server, tt.method, tt.path, tt.body, tt.header)
+		if status != tt.wantStatus {
+			t.Fatalf("%s: status = %d, want %d (body %v)", tt.name, status, tt.wantStatus, body)
+		}
+	}
+
+	_, body := do(t, server, http.MethodGet, "/api/v1/admin/api_keys", "", http.Header{"X-Api-Key": {admin}})
+	keys := body["apiKeys"].([]any)
+	if len(keys) != 3 {
+		t.Fatalf("listed %d keys, want 3", len(keys))
+	}
+	for _, key := range keys {
+		if _, ok := key.(map[string]any)["key"]; ok {
+			t.Fatalf("listed key %v includes the secret", key)
+		}
+	}
+}
+
+func TestGatewayRateLimit(t *testing.T) {
+	repo := memory.NewPlayerRepository()
+	authenticator, err := auth.NewAuthenticator(repo, auth.Options{})
+	if err != nil {
+		t.Fatalf("NewAuthenticator: %v", err)
+	}
+	limiter := ratelimit.New(ratelimit.Options{
+		Roles:      map[auth.Role]ratelimit.Limit{auth.Reader: {RPS: 0.001, Burst: 2}},
+		DailyQuota: map[auth.Role]int{auth.Reader: 1000},
+	}, nil)
+	server := newGatewayWithRepository(t, repo, limiter,
+		auth.UnaryServerInterceptor(authenticator, service.AccessPolicy),
+		limiter.UnaryServerInterceptor(),
+	)
+
+	admin, prefix, hash, _ := auth.NewAPIKey()
+	if _, err := repo.SaveAPIKey(context.Background(), model.APIKey{Name: "admin", Prefix: prefix, Hash: hash, Role: "admin", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("SaveAPIKey: %v", err)
+	}
+	reader, prefix, hash, _ := auth.NewAPIKey()
+	readerKey, err := repo.SaveAPIKey(context.Background(), model.APIKey{Name: "reader", Prefix: prefix, Hash: hash, Role: "reader", CreatedAt: time.Now()})
+	if err != nil {
+		t.Fatalf("SaveAPIKey: %v", err)
+	}
+
+	get := func() *http.Response {
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/api/v1/player_game/1", nil)
+		req.Header.Set("X-Api-Key", reader)
+		resp, err := server.Client().Do(req)
+		if err != nil {
+			t.Fatalf("GET: %v", err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+	for i := 0; i < 2; i++ {
+		if resp := get(); resp.StatusCode != http.StatusOK {
+			t.Fatalf("call %d within the burst: status = %d", i+1, resp.StatusCode)
+		}
+	}
+	resp := get()
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") == "" {
+		t.Fatalf("call over the burst: status = %d, Retry-After = %q, want 429 with Retry-After", resp.StatusCode, resp.Header.Get("Retry-After"))
+	}
+
+	status, body := do(t, server, http.MethodGet, fmt.Sprintf("/api/v1/admin/api_keys/%d/quota", readerKey.ID), "", http.Header{"X-Api-Key": {admin}})
+	if status != http.StatusOK || body["used"] != "2" || body["dailyQuota"] != "1000" {
+		t.Fatalf("quota usage = %d %v, want 2 of 1000 used", status, body)
+	}
+	if status, _ := do(t, server, http.MethodGet, "/api/v1/admin/api_keys/99/quota", "", http.Header{"X-Api-Key": {admin}}); status != http.StatusNotFound {
+		t.Fatalf("quota usage of an unknown key: status = %d, want 404", status)
+	}
+}
+
+func TestGatewayWatchGame(t *testing.T) {
+	hub := live.NewHub()
+	server := newGatewayWithHub(t, memory.NewPlayerRepository(), hub, nil)
+
+	// The response starts with the first event, so subscribe in the background
+	responses := make(chan *http.Response, 1)
+	go func() {
+		resp, err := server.Client().Get(server.URL + "/api/v1/games/1/events")
+		if err != nil {
+			t.Errorf("GET events: %v", err)
+			close(responses)
+			return
+		}
+		responses <- resp
+	}()
+	for !hub.Watched(1, 0) {
+		time.Sleep(time.Millisecond)
+	}
+
+	if status, body := do(t, server, http.MethodPost, "/api/v1/player_game", `{"player_id": 1, "game_id": 1, "points": 25}`, nil); status != http.StatusOK {
+		t.Fatalf("POST status = %d, body %v", status, body)
+	}
+	resp, ok := <-responses
+	if !ok {
+		t.FailNow()
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q, want text/event-stream", ct)
+	}
+
+	// The line is followed by the score it moved
+	scanner := bufio.NewScanner(resp.Body)
+	var events []map[string]any
+	for len(events) < 2 && scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok {
+			continue
+		}
+		var event map[string]any
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			t.Fatalf("event %q is not JSON: %v", data, err)
+		}
+		events = append(events, event)
+	}
+	if len(events) != 2 {
+		t.Fatalf("events = %v, scan error %v", events, scanner.Err())
+	}
+	line, _ := events[0]["line"].(map[string]any)
+	if events[0]["kind"] != "GAME_EVENT_KIND_STAT_LINE_LOGGED" || line["playerName"] != "Player 1" || line["points"] != float64(25) {
+		t.Fatalf("first event = %v, want the logged line", events[0])
+	}
+	score, _ := events[1]["score"].(map[string]any)
+	if events[1]["kind"] != "GAME_EVENT_KIND_SCORE_CHANGED" || score["teamAPoints"] != float64(25) {
+		t.Fatalf("second event = %v, want the new score", events[1])
+	}
+
+	// Shutting down ends the stream with an error event
+	hub.Close()
+	for scanner.Scan() {
+		if scanner.Text() == "event: error" {
+			return
+		}
+	}
+	t.Fatal("stream ended without an error event")
+}
+
+func TestGatewayWatchGameErrors(t *testing.T) {
+	server := newGateway(t)
+
+	if status, body := do(t, server, http.MethodGet, "/api/v1/games/9/events", "", nil); status != http.StatusNotFound || body["message"] != "game not found" {
+		t.Fatalf("unknown game = %d %v, want the error before any event", status, body)
+	}
+	if status, _ := do(t, server, http.MethodGet, "/api/v1/player_game/one/events", "", nil); status != http.StatusBadRequest {
+		t.Fatalf("malformed player id = %d, want 400", status)
+	}
+}
+
+func TestGatewayPlays(t *testing.T) {
+	server := newGateway(t)
+	stats := func() map[string]any {
+		t.Helper()
+		_, body := do(t, server, http.MethodGet, "/api/v1/player_game/seasons/2024/players/1", "", nil)
+		stats, _ := body["playerGameStats"].(map[string]any)
+		return stats
+	}
+
+	status, body := do(t, server, http.MethodPost, "/api/v1/games/1/plays", `{"plays": [
+		{"period": 1, "type": "PLAY_TYPE_SUBSTITUTION", "playerId": 1},
+		{"period": 1, "type": "PLAY_TYPE_PERIOD_START"},
+		{"period": 1, "elapsed": "120s", "type": "PLAY_TYPE_SHOT_MADE", "playerId": 1, "shotType": "SHOT_TYPE_THREE_POINTER"},
+		{"period": 1, "elapsed": "720s", "type": "PLAY_TYPE_PERIOD_END"}
+	]}`, nil)
+	if status != http.StatusOK {
+		t.Fatalf("status = %d (body %v)", status, body)
+	}
+	if plays, _ := body["plays"].([]any); len(plays) != 4 || plays[3].(map[string]any)["sequence"] != float64(4) {
+		t.Fatalf("plays = %v, want 4 numbered plays", body["plays"])
+	}
+	if got := stats(); got["points"] != float64(3) || got["minutesPlayed"] != float64(12) {
+		t.Fatalf("derived stats = %v, want 3 points in 12 minutes", got)
+	}
+
+	status, body = do(t, server, http.MethodPut, "/api/v1/games/1/plays/3",
+		`{"period": 1, "elapsed": "120s", "type": "PLAY_TYPE_SHOT_MADE", "playerId": 1, "shotType": "SHOT_TYPE_TWO_POINTER"}`, nil)
+	if status != http.StatusOK {
+		t.Fatalf("edit status = %d (body %v)", status, body)
+	}
+	if got := stats(); got["points"] != float64(2) {
+		t.Fatalf("stats after edit = %v, want 2 points", got)
+	}
+
+	// Without its end the period runs up to its last play
+	if status, body = do(t, server, http.MethodDelete, "/api/v1/games/1/plays/4", "", nil); status != http.StatusOK {
+		t.Fatalf("delete status = %d (body %v)", status, body)
+	}
+	if got := stats(); got["minutesPlayed"] != float64(2) {
+		t.Fatalf("stats after delete = %v, want 2 minutes", got)
+	}
+	if _, body = do(t, server, http.MethodGet, "/api/v1/games/1/plays", "", nil); len(body["plays"].([]any)) != 3 {
+		t.Fatalf("plays = %v, want 3", body["plays"])
+	}
+
+	status, body = do(t, server, http.MethodPost, "/api/v1/games/1/plays",
+		`{"plays": [{"period": 1, "elapsed": "60s", "type": "PLAY_TYPE_FOUL", "playerId": 1}]}`, nil)
+	if status != http.StatusBadRequest || !strings.Contains(body["message"].(string), "happens before play 3") {
+		t.Fatalf("out of order play = %d %v, want 400", status, body)
+	}
+	if status, body = do(t, server, http.MethodDelete, "/api/v1/games/1/plays/99", "", nil); status != http.StatusNotFound {
+		t.Fatalf("delete of a missing play = %d %v, want 404", status, body)
+	}
+}
+
+func TestGatewayLineups(t *testing.T) {
+	repo := memory.NewPlayerRepository()
+	server := newGatewayWithRepository(t, repo, nil)
+	ctx := context.Background()
+	for i := 2; i <= 6; i++ {
+		p, err := repo.SavePlayer(ctx, model.Player{Name: fmt.Sprintf("Player %d", i), CurrentTeamID: 1})
+		if err != nil {
+			t.Fatalf("SavePlayer: %v", err)
+		}
+		err = repo.SaveRosterEntry(ctx, model.RosterEntry{PlayerID: p.Id, TeamID: 1, StartDate: time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)})
+		if err != nil {
+			t.Fatalf("SaveRosterEntry: %v", err)
+		}
+	}
+	if status, body := do(t, server, http.MethodPost, "/api/v1/player_game", `{"player_id": 1, "game_id": 1, "points": 12, "minutes_played": 12}`, nil); status != http.StatusOK {
+		t.Fatalf("log status = %d (body %v)", status, body)
+	}
+
+	status, body := do(t, server, http.MethodPut, "/api/v1/games/1/teams/1/stints", `{"stints": [
+		{"period": 1, "start": "0s", "end": "480s", "playerIds": [1, 2, 3, 4, 5], "pointsFor": 20, "pointsAgainst": 12},
+		{"period": 1, "start": "480s", "end": "720s", "playerIds": [1, 2, 3, 4, 6], "pointsFor": 4, "pointsAgainst": 8}
+	]}`, nil)
+	if status != http.StatusOK {
+		t.Fatalf("status = %d (body %v)", status, body)
+	}
+	_, body = do(t, server, http.MethodGet, "/api/v1/player_game/seasons/2024/players/1", "", nil)
+	if stats, _ := body["playerGameStats"].(map[string]any); stats["plusMinus"] != float64(4) {
+		t.Fatalf("season stats = %v, want plus-minus 4", body)
+	}
+
+	status, body = do(t, server, http.MethodGet, "/api/v1/team_game/seasons/2024/teams/1/lineups", "", nil)
+	if status != http.StatusOK {
+		t.Fatalf("lineups status = %d (body %v)", status, body)
+	}
+	lineups, _ := body["lineups"].([]any)
+	if len(lineups) != 2 {
+		t.Fatalf("lineups = %v, want 2", body["lineups"])
+	}
+	starters := lineups[0].(map[string]any)
+	if starters["minutes"] != float64(8) || starters["netRating"] != float64(48) || starters["playerNames"].([]any)[0] != "Player 1" {
+		t.Fatalf("starting lineup = %v, want 8 minutes at +48", starters)
+	}
+	if pairs, _ := body["pairs"].([]any); len(pairs) != 14 {
+		t.Fatalf("pairs = %v, want 14", body["pairs"])
+	}
+
+	status, body = do(t, server, http.MethodPut, "/api/v1/games/1/teams/1/stints",
+		`{"stints": [{"period": 1, "start": "0s", "end": "480s", "playerIds": [1, 1]}]}`, nil)
+	if status != http.StatusBadRequest {
+		t.Fatalf("invalid stint = %d %v, want 400", status, body)
+	}
+}
+
+func TestGatewayExportStats(t *testing.T) {
+	server := newGateway(t)
+	if status, body := do(t, server, http.MethodPost, "/api/v1/player_game", `{"player_id": 1, "game_id": 1, "points": 12, "assists": 3, "minutes_played": 30.5}`, nil); status != http.StatusOK {
+		t.Fatalf("log status = %d (body %v)", status, body)
+	}
+
+	resp, err := server.Client().Get(server.URL + "/api/v1/exports/stat_lines?season=2024&teamId=1")
+	if err != nil {
+		t.Fatalf("GET export: %v", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/csv" ||
+		resp.Header.Get("Content-Disposition") != `attachment; filename="stat_lines.csv"` {
+		t.Fatalf("export = %d %v", resp.StatusCode, resp.Header)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 || lines[1] != "2024,2024-01-01,1,nba,1,Team A,2,Team B,1,Player 1,12,3,0,0,0,0,0,30.5,0" {
+		t.Errorf("export =\n%s", data)
+	}
+
+	for _, path := range []string{
+		"/api/v1/exports/stat_lines",
+		"/api/v1/exports/plays?season=2024",
+		"/api/v1/exports/box_scores?season=2024&format=xlsx",
+	} {
+		if status, body := do(t, server, http.MethodGet, path, "", nil); status != http.StatusBadRequest {
+			t.Errorf("GET %s = %d %v, want 400", path, status, body)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"io"
+
+	"nba/importer"
+)
+
+// ImportStats implements Service. Imported stat lines are historical, so they are
+// not published to watchers.
+func (s *ServiceStruct) ImportStats(ctx context.Context, r io.Reader, opts importer.Options) (importer.Report, error) {
+	if !opts.DryRun {
+		onReject := opts.OnReject
+		opts.OnReject = func(reject importer.Reject) error {
+			s.recordRejection(reject.Err)
+			if onReject == nil {
+				return nil
+			}
+			return onReject(reject)
+		}
+	}
+	report, err := importer.New(s.playerRepository, s.rules).Run(ctx, r, opts)
+	// The lines of the batches committed before a failure are written all the same
+	if !opts.DryRun {
+		s.recordLines(report.Imported)
+	}
+	return report, err
+}
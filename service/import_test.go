@@ -0,0 +1,104 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"nba/live"
+	"nba/memory"
+	"nba/pb"
+	"nba/postgres"
+	"nba/service"
+	"nba/validation"
+)
+
+// failingTxRepository fails the unit of work numbered failAt, counting from 1.
+type failingTxRepository struct {
+	*memory.PlayerRepository
+	failAt int
+	txs    int
+}
+
+func (r *failingTxRepository) WithTx(ctx context.Context, fn func(repos postgres.Repositories) error, opts ...postgres.TxOption) error {
+	r.txs++
+	if r.txs == r.failAt {
+		return errors.New("connection reset")
+	}
+	return r.PlayerRepository.WithTx(ctx, fn, opts...)
+}
+
+// importStatsStream feeds requests to GRPCServer.ImportStats and keeps its response.
+type importStatsStream struct {
+	grpc.ServerStream
+	requests []*pb.ImportStatsRequest
+	response *pb.ImportStatsResponse
+}
+
+func (s *importStatsStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *importStatsStream) Recv() (*pb.ImportStatsRequest, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+	request := s.requests[0]
+	s.requests = s.requests[1:]
+	return request, nil
+}
+
+func (s *importStatsStream) SendAndClose(response *pb.ImportStatsResponse) error {
+	s.response = response
+	return nil
+}
+
+func TestImportStatsReportsCheckpointOnFailure(t *testing.T) {
+	rules, err := validation.NewRegistry("")
+	if err != nil {
+		t.Fatalf("NewRegistry: %v", err)
+	}
+	// The second batch fails to commit
+	repo := &failingTxRepository{PlayerRepository: memory.NewPlayerRepository(), failAt: 2}
+	logger := zap.NewNop().Sugar()
+	server := service.NewGRPCServer(logger, service.NewService(logger, repo, rules, live.NewHub(), nil))
+
+	// Four records in batches of two, sent in two requests
+	requests := func(resumeAfter int32) []*pb.ImportStatsRequest {
+		return []*pb.ImportStatsRequest{
+			{Format: "csv", Season: 2001, BatchSize: 2, ResumeAfter: resumeAfter, Data: []byte("date,team,opponent,player_name,points\n2001-01-02,Lakers,Celtics,Shaq,30\n")},
+			{Data: []byte("2001-01-02,Celtics,Lakers,Pierce,25\n2001-01-03,Lakers,Kings,Shaq,20\n2001-01-03,Kings,Lakers,Webber,22\n")},
+		}
+	}
+	stream := &importStatsStream{requests: requests(0)}
+	err = server.ImportStats(stream)
+	st, _ := status.FromError(err)
+	if st.Code() != codes.Unknown || stream.response != nil {
+		t.Fatalf("ImportStats = %v, want the failure of the second batch", err)
+	}
+	var report *pb.ImportStatsResponse
+	for _, detail := range st.Details() {
+		if r, ok := detail.(*pb.ImportStatsResponse); ok {
+			report = r
+		}
+	}
+	if report == nil || report.Checkpoint != 2 || report.Imported != 2 {
+		t.Fatalf("report = %v, want the first batch committed through record 2", report)
+	}
+
+	// Resuming after the checkpoint imports the rest
+	repo.failAt = 0
+	stream.requests = requests(report.Checkpoint)
+	if err := server.ImportStats(stream); err != nil {
+		t.Fatalf("resumed ImportStats: %v", err)
+	}
+	if stream.response.Skipped != 2 || stream.response.Imported != 2 || stream.response.Checkpoint != 4 {
+		t.Errorf("resumed response = %v, want records 3 and 4 imported", stream.response)
+	}
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+
+	"nba/pb"
+)
+
+// The gateway calls the servers in process through pb.Register*HandlerServer, which
+// skips the gRPC server and so its interceptors. The servers below run the same
+// interceptors around every call, so the gateway authenticates, limits and logs calls
+// like the gRPC port does.
+
+// InProcessPlayerGameServer runs interceptor around the calls the gateway makes to srv.
+type InProcessPlayerGameServer struct {
+	srv         pb.PlayerGameServiceServer
+	interceptor grpc.UnaryServerInterceptor
+	pb.UnimplementedPlayerGameServiceServer
+}
+
+// NewInProcessPlayerGameServer returns a server running interceptor around the calls to srv.
+func NewInProcessPlayerGameServer(srv pb.PlayerGameServiceServer, interceptor grpc.UnaryServerInterceptor) *InProcessPlayerGameServer {
+	return &InProcessPlayerGameServer{srv: srv, interceptor: interceptor}
+}
+
+// GetPlayer implements pb.PlayerGameServiceServer.
+func (s *InProcessPlayerGameServer) GetPlayer(ctx context.Context, request *pb.GetPlayerRequest) (*pb.GetPlayerResponse, error) {
+	return intercept(ctx, s.interceptor, s.srv, pb.PlayerGameService_GetPlayer_FullMethodName, request, s.srv.GetPlayer)
+}
+
+// LogPlayerGame implements pb.PlayerGameServiceServer.
+func (s *InProcessPlayerGameServer) LogPlayerGame(ctx context.Context, request *pb.LogPlayerGameRequest) (*pb.LogGameResponse, error) {
+	return intercept(ctx, s.interceptor, s.srv, pb.PlayerGameService_LogPlayerGame_FullMethodName, request, s.srv.LogPlayerGame)
+}
+
+// GetPlayerGameSeasonStats implements pb.PlayerGameServiceServer.
+func (s *InProcessPlayerGameServer) GetPlayerGameSeasonStats(ctx context.Context, request *pb.GetPlayerGameSeasonStatsRequest) (*pb.PlayerGameSeasonStatsResponse, error) {
+	return intercept(ctx, s.interceptor, s.srv, pb.PlayerGameService_GetPlayerGameSeasonStats_FullMethodName, request, s.srv.GetPlayerGameSeasonStats)
+}
+
+// GetTeamSeasonStats implements pb.PlayerGameServiceServer.
+func (s *InProcessPlayerGameServer) GetTeamSeasonStats(ctx context.Context, request *pb.GetTeamsSeasonStatsRequest) (*pb.TeamsSeasonStatsResponse, error) {
+	return intercept(ctx, s.interceptor, s.srv, pb.PlayerGameService_GetTeamSeasonStats_FullMethodName, request, s.srv.GetTeamSeasonStats)
+}
+
+// ValidateGame implements pb.PlayerGameServiceServer.
+func (s *InProcessPlayerGameServer) ValidateGame(ctx context.Context, request *pb.ValidateGameRequest) (*pb.ValidateGameResponse, error) {
+	return intercept(ctx, s.interceptor, s.srv, pb.PlayerGameService_ValidateGame_FullMethodName, request, s.srv.ValidateGame)
+}
+
+// RecordPlays implements pb.PlayerGameServiceServer.
+func (s *InProcessPlayerGameServer) RecordPlays(ctx context.Context, request *pb.RecordPlaysRequest) (*pb.RecordPlaysResponse, error) {
+	return intercept(ctx, s.interceptor, s.srv, pb.PlayerGameService_RecordPlays_FullMethodName, request, s.srv.RecordPlays)
+}
+
+// ListPlays implements pb.PlayerGameServiceServer.
+func (s *InProcessPlayerGameServer) ListPlays(ctx context.Context, request *pb.ListPlaysRequest) (*pb.ListPlaysResponse, error) {
+	return intercept(ctx, s.interceptor, s.srv, pb.PlayerGameService_ListPlays_FullMethodName, request, s.srv.ListPlays)
+}
+
+// EditPlay implements pb.PlayerGameServiceServer.
+func (s *InProcessPlayerGameServer) EditPlay(ctx context.Context, request *pb.EditPlayRequest) (*pb.EditPlayResponse, error) {
+	return intercept(ctx, s.interceptor, s.srv, pb.PlayerGameService_EditPlay_FullMethodName, request, s.srv.EditPlay)
+}
+
+// DeletePlay implements pb.PlayerGameServiceServer.
+func (s *InProcessPlayerGameServer) DeletePlay(ctx context.Context, request *pb.DeletePlayRequest) (*pb.DeletePlayResponse, error) {
+	return intercept(ctx, s.interceptor, s.srv, pb.PlayerGameService_DeletePlay_FullMethodName, request, s.srv.DeletePlay)
+}
+
+// RecordStints implements pb.PlayerGameServiceServer.
+func (s *InProcessPlayerGameServer) RecordStints(ctx context.Context, request *pb.RecordStintsRequest) (*pb.RecordStintsResponse, error) {
+	return intercept(ctx, s.interceptor, s.srv, pb.PlayerGameService_RecordStints_FullMethodName, request, s.srv.RecordStints)
+}
+
+// GetLineupStats implements pb.PlayerGameServiceServer.
+func (s *InProcessPlayerGameServer) GetLineupStats(ctx context.Context, request *pb.GetLineupStatsRequest) (*pb.GetLineupStatsResponse, error) {
+	return intercept(ctx, s.interceptor, s.srv, pb.PlayerGameService_GetLineupStats_FullMethodName, request, s.srv.GetLineupStats)
+}
+
+// InProcessAdminServer runs interceptor around the calls the gateway makes to srv.
+type InProcessAdminServer struct {
+	srv         pb.AdminServiceServer
+	interceptor grpc.UnaryServerInterceptor
+	pb.UnimplementedAdminServiceServer
+}
+
+// NewInProcessAdminServer returns a server running interceptor around the calls to srv.
+func NewInProcessAdminServer(srv pb.AdminServiceServer, interceptor grpc.UnaryServerInterceptor) *InProcessAdminServer {
+	return &InProcessAdminServer{srv: srv, interceptor: interceptor}
+}
+
+// CreateAPIKey implements pb.AdminServiceServer.
+func (s *InProcessAdminServer) CreateAPIKey(ctx context.Context, request *pb.CreateAPIKeyRequest) (*pb.CreateAPIKeyResponse, error) {
+	return intercept(ctx, s.interceptor, s.srv, pb.AdminService_CreateAPIKey_FullMethodName, request, s.srv.CreateAPIKey)
+}
+
+// ListAPIKeys implements pb.AdminServiceServer.
+func (s *InProcessAdminServer) ListAPIKeys(ctx context.Context, request *pb.ListAPIKeysRequest) (*pb.ListAPIKeysResponse, error) {
+	return intercept(ctx, s.interceptor, s.srv, pb.AdminService_ListAPIKeys_FullMethodName, request, s.srv.ListAPIKeys)
+}
+
+// RevokeAPIKey implements pb.AdminServiceServer.
+func (s *InProcessAdminServer) RevokeAPIKey(ctx context.Context, request *pb.RevokeAPIKeyRequest) (*pb.RevokeAPIKeyResponse, error) {
+	return intercept(ctx, s.interceptor, s.srv, pb.AdminService_RevokeAPIKey_FullMethodName, request, s.srv.RevokeAPIKey)
+}
+
+// GetQuotaUsage implements pb.AdminServiceServer.
+func (s *InProcessAdminServer) GetQuotaUsage(ctx context.Context, request *pb.GetQuotaUsageRequest) (*pb.GetQuotaUsageResponse, error) {
+	return intercept(ctx, s.interceptor, s.srv, pb.AdminService_GetQuotaUsage_FullMethodName, request, s.srv.GetQuotaUsage)
+}
+
+// intercept calls call through interceptor, as the gRPC server would for method.
+func intercept[Req, Resp any](ctx context.Context, interceptor grpc.UnaryServerInterceptor, srv any, method string, request Req, call func(context.Context, Req) (Resp, error)) (Resp, error) {
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: method}
+	resp, err := interceptor(ctx, request, info, func(ctx context.Context, req any) (any, error) {
+		return call(ctx, req.(Req))
+	})
+	if err != nil {
+		var zero Resp
+		return zero, err
+	}
+	typed, ok := resp.(Resp)
+	if !ok {
+		var zero Resp
+		return zero, fmt.Errorf("%s returned %T", method, resp)
+	}
+	return typed, nil
+}
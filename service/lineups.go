@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"nba/lineups"
+	"nba/model"
+	"nba/postgres"
+)
+
+// ErrStintsDerived is returned when recording stints for a game with play-by-play,
+// whose stints are derived from its plays.
+var ErrStintsDerived = errors.New("game has play-by-play; its stints are derived from the plays")
+
+// RecordStints implements Service. The plus-minus of the lines of the game follows the
+// new stints; the lines it changes are published.
+func (s *ServiceStruct) RecordStints(ctx context.Context, gameId int, teamId int, stints []model.Stint) error {
+	if gameId <= 0 {
+		return errors.New("game ID must be a positive integer")
+	}
+	if teamId <= 0 {
+		return errors.New("team ID must be a positive integer")
+	}
+	if err := lineups.Check(stints); err != nil {
+		return err
+	}
+
+	var events []model.GameEvent
+	var written int
+	err := s.playerRepository.WithTx(ctx, func(repos postgres.Repositories) error {
+		repo := repos.Players
+		g, err := repo.GetGame(ctx, gameId)
+		if err != nil {
+			return err
+		}
+		if g.Id == 0 {
+			return ErrGameNotFound
+		}
+		if teamId != g.TeamAID && teamId != g.TeamBID {
+			return fmt.Errorf("team %d did not play game %d", teamId, gameId)
+		}
+		recorded, err := repo.GetPlays(ctx, gameId)
+		if err != nil {
+			return err
+		}
+		if len(recorded) > 0 {
+			return ErrStintsDerived
+		}
+
+		// Every player on the court must have been on the roster of the team on game day
+		var checked []int
+		for _, stint := range stints {
+			for _, playerId := range stint.PlayerIDs {
+				if slices.Contains(checked, playerId) {
+					continue
+				}
+				teamID, err := repo.GetPlayerTeamOnDate(ctx, playerId, g.Date)
+				if err != nil {
+					return err
+				}
+				if teamID != teamId {
+					return fmt.Errorf("player %d was %w of team %d on %s", playerId, ErrNotOnRoster, teamId, g.Date.Format(time.DateOnly))
+				}
+				checked = append(checked, playerId)
+			}
+		}
+
+		before, err := repo.GetGameStats(ctx, gameId)
+		if err != nil {
+			return err
+		}
+		if err := repo.ReplaceStints(ctx, gameId, teamId, stints); err != nil {
+			return err
+		}
+		after, err := repo.GetGameStats(ctx, gameId)
+		if err != nil {
+			return err
+		}
+		var changed []model.PlayerGameStats
+		for _, line := range after {
+			if !slices.Contains(before, line) {
+				changed = append(changed, line)
+			}
+		}
+		events, written = gameEvents(g, before, changed, nil, time.Now().UTC()), len(changed)
+		return nil
+	})
+	if err != nil {
+		s.recordRejection(err)
+		return err
+	}
+	// Watchers only hear of committed lines
+	s.recordLines(written)
+	for _, event := range events {
+		s.hub.Publish(event)
+	}
+	return nil
+}
+
+// GetLineupStats implements Service.
+func (s *ServiceStruct) GetLineupStats(ctx context.Context, teamId int, season int) (*model.TeamLineupStats, error) {
+	if season <= 0 {
+		return nil, errors.New("season must be a positive integer")
+	}
+	if teamId <= 0 {
+		return nil, errors.New("team ID must be a positive integer")
+	}
+
+	team, err := s.playerRepository.GetTeam(ctx, teamId)
+	if err != nil {
+		return nil, err
+	}
+	if team.Id == 0 {
+		return nil, ErrTeamNotFound
+	}
+	stints, err := s.playerRepository.GetTeamStintsBySeason(ctx, teamId, season)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := model.TeamLineupStats{TeamID: team.Id, TeamName: team.Name, Season: season}
+	stats.Lineups, stats.Pairs = lineups.Aggregate(stints)
+
+	// Name the players, looking each one up once
+	names := make(map[int]string)
+	for _, group := range [][]model.LineupStats{stats.Lineups, stats.Pairs} {
+		for i := range group {
+			group[i].PlayerNames = make([]string, len(group[i].PlayerIDs))
+			for j, playerId := range group[i].PlayerIDs {
+				name, ok := names[playerId]
+				if !ok {
+					p, err := s.playerRepository.GetPlayer(ctx, playerId)
+					if err != nil {
+						return nil, err
+					}
+					name = p.Name
+					names[playerId] = name
+				}
+				group[i].PlayerNames[j] = name
+			}
+		}
+	}
+	return &stats, nil
+}
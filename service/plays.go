@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"nba/model"
+	"nba/plays"
+	"nba/postgres"
+	"nba/validation"
+)
+
+// ErrPlayNotFound is returned when editing or deleting a play the game does not have.
+var ErrPlayNotFound = errors.New("play not found")
+
+// playChange is an edit of the play-by-play of a game.
+type playChange struct {
+	// plays is the play-by-play after the edit, in sequence order
+	plays []model.Play
+	// write stores the edit
+	write func(repo postgres.PlayerRepository) error
+}
+
+// RecordPlays implements Service. The plays are numbered in the order given, after the
+// plays already recorded, and returned with their sequence numbers.
+func (s *ServiceStruct) RecordPlays(ctx context.Context, gameId int, newPlays []model.Play) ([]model.Play, error) {
+	if gameId <= 0 {
+		return nil, errors.New("game ID must be a positive integer")
+	}
+	if len(newPlays) == 0 {
+		return nil, errors.New("no plays to record")
+	}
+
+	var recorded []model.Play
+	err := s.changePlays(ctx, gameId, func(current []model.Play) (playChange, error) {
+		next := 1
+		if len(current) > 0 {
+			next = current[len(current)-1].Sequence + 1
+		}
+		recorded = make([]model.Play, len(newPlays))
+		for i, play := range newPlays {
+			play.GameID, play.Sequence = gameId, next+i
+			recorded[i] = play
+		}
+		return playChange{
+			plays: append(slices.Clip(current), recorded...),
+			write: func(repo postgres.PlayerRepository) error {
+				for _, play := range recorded {
+					if err := repo.LogPlay(ctx, play); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return recorded, nil
+}
+
+// EditPlay implements Service.
+func (s *ServiceStruct) EditPlay(ctx context.Context, play model.Play) (model.Play, error) {
+	if play.GameID <= 0 {
+		return model.Play{}, errors.New("game ID must be a positive integer")
+	}
+	err := s.changePlays(ctx, play.GameID, func(current []model.Play) (playChange, error) {
+		i := slices.IndexFunc(current, func(p model.Play) bool { return p.Sequence == play.Sequence })
+		if i < 0 {
+			return playChange{}, ErrPlayNotFound
+		}
+		edited := slices.Clone(current)
+		edited[i] = play
+		return playChange{
+			plays: edited,
+			write: func(repo postgres.PlayerRepository) error { return repo.UpsertPlay(ctx, play) },
+		}, nil
+	})
+	if err != nil {
+		return model.Play{}, err
+	}
+	return play, nil
+}
+
+// DeletePlay implements Service.
+func (s *ServiceStruct) DeletePlay(ctx context.Context, gameId int, sequence int) error {
+	if gameId <= 0 {
+		return errors.New("game ID must be a positive integer")
+	}
+	return s.changePlays(ctx, gameId, func(current []model.Play) (playChange, error) {
+		i := slices.IndexFunc(current, func(p model.Play) bool { return p.Sequence == sequence })
+		if i < 0 {
+			return playChange{}, ErrPlayNotFound
+		}
+		return playChange{
+			plays: slices.Delete(slices.Clone(current), i, i+1),
+			write: func(repo postgres.PlayerRepository) error { return repo.DeletePlay(ctx, gameId, sequence) },
+		}, nil
+	})
+}
+
+// ListPlays implements Service.
+func (s *ServiceStruct) ListPlays(ctx context.Context, gameId int) ([]model.Play, error) {
+	if gameId <= 0 {
+		return nil, errors.New("game ID must be a positive integer")
+	}
+	g, err := s.playerRepository.GetGame(ctx, gameId)
+	if err != nil {
+		return nil, err
+	}
+	if g.Id == 0 {
+		return nil, ErrGameNotFound
+	}
+	return s.playerRepository.GetPlays(ctx, gameId)
+}
+
+// changePlays applies an edit of the play-by-play of a game and re-derives the stat
+// lines of its players and the stints of both teams, in one transaction. Only the
+// lines the edit changes are written and published; players left without plays lose
+// their line.
+func (s *ServiceStruct) changePlays(ctx context.Context, gameId int, change func(current []model.Play) (playChange, error)) error {
+	var events []model.GameEvent
+	var written int
+	err := s.playerRepository.WithTx(ctx, func(repos postgres.Repositories) error {
+		repo := repos.Players
+		g, err := repo.GetGame(ctx, gameId)
+		if err != nil {
+			return err
+		}
+		if g.Id == 0 {
+			return ErrGameNotFound
+		}
+		current, err := repo.GetPlays(ctx, gameId)
+		if err != nil {
+			return err
+		}
+		c, err := change(current)
+		if err != nil {
+			return err
+		}
+
+		// Every player taking part must have been on the roster of one of the two teams on game day
+		teams := make(map[int]int)
+		names := make(map[int]string)
+		for _, playerId := range plays.Players(c.plays) {
+			p, err := repo.GetPlayer(ctx, playerId)
+			if err != nil {
+				return err
+			}
+			if p.Id == 0 {
+				return fmt.Errorf("%w: %d", ErrPlayerNotFound, playerId)
+			}
+			teamID, err := repo.GetPlayerTeamOnDate(ctx, playerId, g.Date)
+			if err != nil {
+				return err
+			}
+			if teamID == 0 || (teamID != g.TeamAID && teamID != g.TeamBID) {
+				return fmt.Errorf("player %d was %w of team %d or team %d on %s",
+					playerId, ErrNotOnRoster, g.TeamAID, g.TeamBID, g.Date.Format(time.DateOnly))
+			}
+			teams[playerId], names[playerId] = teamID, p.Name
+		}
+
+		lines, stints, err := plays.Derive(gameId, c.plays, teams)
+		if err != nil {
+			return err
+		}
+		rules := s.rules.For(g.League)
+		gameContext := validation.GameContext{League: g.League, OvertimePeriods: g.OvertimePeriods}
+		for i := range lines {
+			if err := rules.Validate(lines[i], gameContext); err != nil {
+				return fmt.Errorf("player %d: %w", lines[i].PlayerID, err)
+			}
+			lines[i].PlayerName = names[lines[i].PlayerID]
+		}
+
+		// Read the lines first, so plus-minus changes of the new stints are published too
+		before, err := repo.GetGameStats(ctx, gameId)
+		if err != nil {
+			return err
+		}
+		if err := c.write(repo); err != nil {
+			return err
+		}
+		for _, teamID := range []int{g.TeamAID, g.TeamBID} {
+			if err := repo.ReplaceStints(ctx, gameId, teamID, teamStints(stints, teamID)); err != nil {
+				return err
+			}
+		}
+		var changed []model.PlayerGameStats
+		for _, line := range lines {
+			i := slices.IndexFunc(before, func(stored model.PlayerGameStats) bool { return stored.PlayerID == line.PlayerID })
+			if i >= 0 && before[i] == line {
+				continue
+			}
+			if err := repo.UpsertPlayerGame(ctx, line); err != nil {
+				return err
+			}
+			changed = append(changed, line)
+		}
+		var removed []int
+		for _, playerId := range plays.Players(current) {
+			if _, ok := teams[playerId]; ok {
+				continue
+			}
+			if err := repo.DeletePlayerGame(ctx, gameId, playerId); err != nil {
+				return err
+			}
+			removed = append(removed, playerId)
+		}
+		events, written = gameEvents(g, before, changed, removed, time.Now().UTC()), len(changed)
+		return nil
+	})
+	if err != nil {
+		s.recordRejection(err)
+		return err
+	}
+	// Watchers only hear of committed lines
+	s.recordLines(written)
+	for _, event := range events {
+		s.hub.Publish(event)
+	}
+	return nil
+}
+
+// teamStints returns the stints of one team.
+func teamStints(stints []model.Stint, teamID int) []model.Stint {
+	var team []model.Stint
+	for _, stint := range stints {
+		if stint.TeamID == teamID {
+			team = append(team, stint)
+		}
+	}
+	return team
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"errors"
+
+	"nba/validation"
+)
+
+// Recorder receives the domain events of the service, e.g. to export them as metrics.
+type Recorder interface {
+	// StatLineLogged is called for every stat line written, whether logged, derived
+	// from the play-by-play, changed by new stints or imported. Replays are not counted.
+	StatLineLogged()
+	ValidationRejected(rule string)
+	CacheLookup(kind string, hit bool)
+}
+
+// NopRecorder discards every event.
+type NopRecorder struct{}
+
+func (NopRecorder) StatLineLogged()                   {}
+func (NopRecorder) ValidationRejected(rule string)    {}
+func (NopRecorder) CacheLookup(kind string, hit bool) {}
+
+// recordLines reports n stat lines written.
+func (s *ServiceStruct) recordLines(n int) {
+	for range n {
+		s.recorder.StatLineLogged()
+	}
+}
+
+// recordRejection reports the broken rules of err, if stat lines failed validation.
+func (s *ServiceStruct) recordRejection(err error) {
+	var validationErr *validation.Error
+	if errors.As(err, &validationErr) {
+		for _, v := range validationErr.Violations {
+			s.recorder.ValidationRejected(v.Rule)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package service_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"nba/importer"
+	"nba/live"
+	"nba/memory"
+	"nba/model"
+	"nba/service"
+	"nba/validation"
+)
+
+// eventRecorder keeps the stat line events it receives.
+type eventRecorder struct {
+	service.NopRecorder
+	logged   int
+	rejected []string
+}
+
+func (r *eventRecorder) StatLineLogged()                { r.logged++ }
+func (r *eventRecorder) ValidationRejected(rule string) { r.rejected = append(r.rejected, rule) }
+
+// check fails the test unless the recorder received logged lines and rejected rules.
+func (r *eventRecorder) check(t *testing.T, step string, logged int, rejected ...string) {
+	t.Helper()
+	if r.logged != logged || !reflect.DeepEqual(r.rejected, rejected) {
+		t.Fatalf("%s: recorded %d lines and rejections %v, want %d and %v", step, r.logged, r.rejected, logged, rejected)
+	}
+}
+
+// newRecordedService returns a service over the gateway data recording to a new
+// eventRecorder.
+func newRecordedService(t *testing.T) (service.Service, *eventRecorder) {
+	t.Helper()
+	repo := memory.NewPlayerRepository()
+	seedGatewayData(t, repo)
+	rules, err := validation.NewRegistry("")
+	if err != nil {
+		t.Fatalf("NewRegistry: %v", err)
+	}
+	recorder := &eventRecorder{}
+	return service.NewService(zap.NewNop().Sugar(), repo, rules, live.NewHub(), recorder), recorder
+}
+
+func TestRecorderLogPlayerGame(t *testing.T) {
+	svc, recorder := newRecordedService(t)
+	ctx := context.Background()
+
+	request := model.LogPlayerGameRequest{PlayerId: 1, GameId: 1, Points: 20, MinutesPlayed: 30, IdempotencyKey: "first"}
+	if err := svc.LogPlayerGame(ctx, 1, request); err != nil {
+		t.Fatalf("LogPlayerGame() error = %v", err)
+	}
+	recorder.check(t, "logged", 1)
+
+	// A replay writes nothing
+	if err := svc.LogPlayerGame(ctx, 1, request); err != nil {
+		t.Fatalf("replayed LogPlayerGame() error = %v", err)
+	}
+	recorder.check(t, "replayed", 1)
+
+	rejected := model.LogPlayerGameRequest{PlayerId: 1, GameId: 1, Points: -1, Fouls: 7, Upsert: true}
+	if err := svc.LogPlayerGame(ctx, 1, rejected); err == nil {
+		t.Fatal("LogPlayerGame() error = nil, want the broken rules")
+	}
+	recorder.check(t, "rejected by rules", 1, "points_non_negative", "personal_foul_limit")
+
+	// Other failures are not recorded
+	if err := svc.LogPlayerGame(ctx, 1, model.LogPlayerGameRequest{PlayerId: 1, GameId: 99}); err == nil {
+		t.Fatal("LogPlayerGame() error = nil, want game not found")
+	}
+	recorder.check(t, "unknown game", 1, "points_non_negative", "personal_foul_limit")
+}
+
+func TestRecorderPlays(t *testing.T) {
+	svc, recorder := newRecordedService(t)
+	ctx := context.Background()
+
+	recorded, err := svc.RecordPlays(ctx, 1, []model.Play{
+		{Period: 1, Type: model.PlayPeriodStart},
+		{Period: 1, Type: model.PlaySubstitution, PlayerID: 1},
+		{Period: 1, Elapsed: time.Minute, Type: model.PlayShotMade, PlayerID: 1, ShotType: model.ShotTwoPointer},
+	})
+	if err != nil {
+		t.Fatalf("RecordPlays() error = %v", err)
+	}
+	recorder.check(t, "recorded", 1)
+
+	shot := recorded[2]
+	shot.ShotType = model.ShotThreePointer
+	if _, err := svc.EditPlay(ctx, shot); err != nil {
+		t.Fatalf("EditPlay() error = %v", err)
+	}
+	recorder.check(t, "edited", 2)
+
+	if err := svc.DeletePlay(ctx, 1, shot.Sequence); err != nil {
+		t.Fatalf("DeletePlay() error = %v", err)
+	}
+	recorder.check(t, "deleted", 3)
+}
+
+func TestRecorderStints(t *testing.T) {
+	svc, recorder := newRecordedService(t)
+	ctx := context.Background()
+	if err := svc.LogPlayerGame(ctx, 1, model.LogPlayerGameRequest{PlayerId: 1, GameId: 1, Points: 20, MinutesPlayed: 12}); err != nil {
+		t.Fatalf("LogPlayerGame() error = %v", err)
+	}
+
+	// The stints change the plus-minus of the logged line
+	err := svc.RecordStints(ctx, 1, 1, []model.Stint{
+		{Period: 1, End: 12 * time.Minute, PlayerIDs: []int{1}, PointsFor: 10},
+	})
+	if err != nil {
+		t.Fatalf("RecordStints() error = %v", err)
+	}
+	recorder.check(t, "recorded", 2)
+}
+
+func TestRecorderImportStats(t *testing.T) {
+	csv := "date,team,opponent,player_name,points,fouls\n" +
+		"2001-01-02,Lakers,Celtics,Shaq,30,3\n" +
+		"2001-01-02,Celtics,Lakers,Pierce,25,7\n" +
+		"2001-01-02,Lakers,Celtics,Kobe,28,2\n"
+
+	tests := []struct {
+		name         string
+		dryRun       bool
+		wantLogged   int
+		wantRejected []string
+	}{
+		{name: "imported", wantLogged: 2, wantRejected: []string{"personal_foul_limit"}},
+		{name: "dry run", dryRun: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, recorder := newRecordedService(t)
+			var rejects int
+			opts := importer.Options{
+				Format:   importer.CSV,
+				Season:   2001,
+				DryRun:   tt.dryRun,
+				OnReject: func(importer.Reject) error { rejects++; return nil },
+			}
+			if _, err := svc.ImportStats(context.Background(), strings.NewReader(csv), opts); err != nil {
+				t.Fatalf("ImportStats() error = %v", err)
+			}
+			// The caller still hears of every reject
+			if rejects != 1 {
+				t.Errorf("OnReject called %d times, want 1", rejects)
+			}
+			recorder.check(t, tt.name, tt.wantLogged, tt.wantRejected...)
+		})
+	}
+}
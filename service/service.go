@@ -2,9 +2,20 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"nba/exporter"
+	"nba/importer"
+	"nba/live"
 	"nba/model"
 	"nba/postgres"
+	"nba/validation"
+	"time"
 
 	"go.uber.org/zap"
 )
@@ -13,51 +24,73 @@ type Service interface {
 	LogPlayerGame(ctx context.Context, playerId int, request model.LogPlayerGameRequest) error
 	GetPlayerSeasonAverages(ctx context.Context, request model.GetPlayerGameStatsRequest) (*model.PlayerSeasonAverage, error)
 	GetTeamSeasonAverages(ctx context.Context, request model.GetTeamGameStatsRequest) (*model.TeamSeasoAverage, error)
+	ValidateGame(ctx context.Context, gameId int) (*model.GameValidation, error)
+	// WatchGame subscribes to the stat lines and score changes of a game as they are committed.
+	WatchGame(ctx context.Context, gameId int) (*live.Subscription, error)
+	// WatchPlayer subscribes to the stat lines of a player as they are committed.
+	WatchPlayer(ctx context.Context, playerId int) (*live.Subscription, error)
+	// RecordPlays appends plays to the play-by-play of a game and re-derives the stat
+	// lines of its players.
+	RecordPlays(ctx context.Context, gameId int, plays []model.Play) ([]model.Play, error)
+	// EditPlay replaces a recorded play and re-derives the stat lines it changes.
+	EditPlay(ctx context.Context, play model.Play) (model.Play, error)
+	// DeletePlay removes a recorded play and re-derives the stat lines it changes.
+	DeletePlay(ctx context.Context, gameId int, sequence int) error
+	// ListPlays returns the play-by-play of a game in sequence order.
+	ListPlays(ctx context.Context, gameId int) ([]model.Play, error)
+	// RecordStints replaces the stints of a team in a game scored without play-by-play.
+	RecordStints(ctx context.Context, gameId int, teamId int, stints []model.Stint) error
+	// GetLineupStats adds up the stints of a team over a season into its five-man
+	// lineups and two-man combinations.
+	GetLineupStats(ctx context.Context, teamId int, season int) (*model.TeamLineupStats, error)
+	// ImportStats bulk loads historical stat lines, creating the teams, players and
+	// games they name.
+	ImportStats(ctx context.Context, r io.Reader, opts importer.Options) (importer.Report, error)
+	// ExportStats writes the stat lines, season averages or box scores of a season, team
+	// or player to w and returns the number of rows written.
+	ExportStats(ctx context.Context, w io.Writer, opts exporter.Options) (int, error)
 }
 
+// ErrIdempotencyKeyReused is returned when an idempotency key is replayed with a different request.
+var ErrIdempotencyKeyReused = errors.New("idempotency key was already used for a different request")
+
+// ErrGameNotFound, ErrPlayerNotFound and ErrTeamNotFound are returned when a request
+// names a game, player or team that does not exist.
+var (
+	ErrGameNotFound   = errors.New("game not found")
+	ErrPlayerNotFound = errors.New("player not found")
+	ErrTeamNotFound   = errors.New("team not found")
+)
+
+// ErrNotOnRoster is returned when a stat line, play or stint names a player who was not
+// on the roster of the team on game day.
+var ErrNotOnRoster = errors.New("not on the roster")
+
 type ServiceStruct struct {
 	logger           *zap.SugaredLogger
 	playerRepository postgres.PlayerRepository
+	rules            *validation.Registry
+	hub              *live.Hub
+	recorder         Recorder
 }
 
-func NewService(logger *zap.SugaredLogger, playerRepository postgres.PlayerRepository) Service {
+// NewService creates the service. Committed stat lines are published to hub, and
+// counted along with rule violations by recorder, which may be nil.
+func NewService(logger *zap.SugaredLogger, playerRepository postgres.PlayerRepository, rules *validation.Registry, hub *live.Hub, recorder Recorder) Service {
+	if recorder == nil {
+		recorder = NopRecorder{}
+	}
 	return &ServiceStruct{
 		logger:           logger,
 		playerRepository: playerRepository,
+		rules:            rules,
+		hub:              hub,
+		recorder:         recorder,
 	}
 }
 
 // LogPlayerGame implements Service.
 func (s *ServiceStruct) LogPlayerGame(ctx context.Context, playerId int, request model.LogPlayerGameRequest) error {
-	// Validate Points, Rebounds, Assists, Steals, Blocks, Turnovers (must be positive integers)
-	if request.Points <= 0 {
-		return errors.New("points must be a positive integer")
-	}
-	if request.Rebounds <= 0 {
-		return errors.New("rebounds must be a positive integer")
-	}
-	if request.Assists <= 0 {
-		return errors.New("assists must be a positive integer")
-	}
-	if request.Steals <= 0 {
-		return errors.New("steals must be a positive integer")
-	}
-	if request.Blocks <= 0 {
-		return errors.New("blocks must be a positive integer")
-	}
-	if request.Turnovers <= 0 {
-		return errors.New("turnovers must be a positive integer")
-	}
-	// Validate Fouls (must be an integer between 0 and 6)
-	if request.Fouls < 0 || request.Fouls > 6 {
-		return errors.New("fouls must be an integer between 0 and 6")
-	}
-
-	// Validate MinutesPlayed (must be a float between 0 and 48.0)
-	if request.MinutesPlayed < 0 || request.MinutesPlayed > 48.0 {
-		return errors.New("minutes played must be a float between 0 and 48.0")
-	}
-
 	if playerId <= 0 {
 		return errors.New("player ID must be a positive integer")
 	}
@@ -66,23 +99,63 @@ func (s *ServiceStruct) LogPlayerGame(ctx context.Context, playerId int, request
 		return errors.New("game ID must be a positive integer")
 	}
 
-	g, err := s.playerRepository.GetGame(request.GameId)
-	if err != nil {
-		return err
+	var requestHash string
+	if request.IdempotencyKey != "" {
+		requestHash = hashLogPlayerGameRequest(playerId, request)
 	}
-	if g.Id == 0 {
-		return errors.New("game not found")
+
+	// Reads, the insert and the idempotency record are committed together
+	var events []model.GameEvent
+	var written bool
+	err := s.playerRepository.WithTx(ctx, func(repos postgres.Repositories) error {
+		var err error
+		events, written, err = s.logPlayerGame(ctx, repos.Players, playerId, request, requestHash)
+		return err
+	})
+	if err == nil {
+		// Watchers only hear of committed lines, and replays are not counted again
+		if written {
+			s.recorder.StatLineLogged()
+		}
+		for _, event := range events {
+			s.hub.Publish(event)
+		}
+		return nil
+	}
+	s.recordRejection(err)
+	// A concurrent retry may have committed the same idempotency key first
+	if errors.Is(err, postgres.ErrDuplicate) && request.IdempotencyKey != "" {
+		replayed, checkErr := s.checkIdempotencyKey(ctx, s.playerRepository, request.IdempotencyKey, requestHash)
+		if checkErr != nil {
+			return checkErr
+		}
+		if replayed {
+			return nil
+		}
+	}
+	return err
+}
+
+// logPlayerGame validates and stores a stat line using repo, which runs inside a transaction.
+// It returns the events to publish once the transaction commits, if anyone is watching,
+// and whether the line was written rather than replayed.
+func (s *ServiceStruct) logPlayerGame(ctx context.Context, repo postgres.PlayerRepository, playerId int, request model.LogPlayerGameRequest, requestHash string) ([]model.GameEvent, bool, error) {
+	// A retried request with a known idempotency key returns the original result
+	if request.IdempotencyKey != "" {
+		replayed, err := s.checkIdempotencyKey(ctx, repo, request.IdempotencyKey, requestHash)
+		if err != nil || replayed {
+			return nil, false, err
+		}
 	}
 
-	p, err := s.playerRepository.GetPlayer(playerId)
+	g, err := repo.GetGame(ctx, request.GameId)
 	if err != nil {
-		return err
+		return nil, false, err
 	}
-	if p.Id == 0 {
-		return errors.New("player not found")
+	if g.Id == 0 {
+		return nil, false, ErrGameNotFound
 	}
-	// If all validations pass, proceed with logging the game.
-	// (Logging logic would go here, e.g., save the game stats to the database)
+
 	playerGame := model.PlayerGameStats{
 		PlayerID:      playerId,
 		GameID:        request.GameId,
@@ -95,11 +168,140 @@ func (s *ServiceStruct) LogPlayerGame(ctx context.Context, playerId int, request
 		Fouls:         request.Fouls,
 		MinutesPlayed: request.MinutesPlayed,
 	}
-	err = s.playerRepository.LogPlayerGame(playerGame)
+
+	// Validate the stat line against the rules of the game's league
+	gameContext := validation.GameContext{League: g.League, OvertimePeriods: g.OvertimePeriods}
+	if err := s.rules.For(g.League).Validate(playerGame, gameContext); err != nil {
+		return nil, false, err
+	}
+
+	p, err := repo.GetPlayer(ctx, playerId)
 	if err != nil {
-		return err
+		return nil, false, err
+	}
+	if p.Id == 0 {
+		return nil, false, ErrPlayerNotFound
 	}
-	return nil
+
+	// The player must have been on the roster of one of the two teams on game day
+	teamID, err := repo.GetPlayerTeamOnDate(ctx, playerId, g.Date)
+	if err != nil {
+		return nil, false, err
+	}
+	if teamID == 0 || (teamID != g.TeamAID && teamID != g.TeamBID) {
+		return nil, false, fmt.Errorf("player %d was %w of team %d or team %d on %s",
+			playerId, ErrNotOnRoster, g.TeamAID, g.TeamBID, g.Date.Format(time.DateOnly))
+	}
+	playerGame.TeamID = teamID
+
+	// Watchers are told whether the line is new and how it moves the score, which
+	// takes the lines stored so far; nobody watching saves the read
+	watched := s.hub.Watched(request.GameId, playerId)
+	var before []model.PlayerGameStats
+	if watched {
+		if before, err = repo.GetGameStats(ctx, request.GameId); err != nil {
+			return nil, false, err
+		}
+	}
+
+	// If all validations pass, proceed with logging the game.
+	if request.Upsert {
+		err = repo.UpsertPlayerGame(ctx, playerGame)
+	} else {
+		err = repo.LogPlayerGame(ctx, playerGame)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	if request.IdempotencyKey != "" {
+		err = repo.SaveIdempotencyKey(ctx, model.IdempotencyKey{
+			Key:         request.IdempotencyKey,
+			RequestHash: requestHash,
+			CreatedAt:   time.Now(),
+		})
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	if !watched {
+		return nil, true, nil
+	}
+	line := playerGame
+	line.PlayerName = p.Name
+	return gameEvents(g, before, []model.PlayerGameStats{line}, nil, time.Now().UTC()), true, nil
+}
+
+// gameEvents describes a change to the stat lines of a game to the watchers of the game
+// and its players: each changed line, as a correction if before already held a line of
+// the player, and the running score if the lines changed or removed moved it.
+func gameEvents(g model.Game, before, changed []model.PlayerGameStats, removed []int, at time.Time) []model.GameEvent {
+	var events []model.GameEvent
+	replaced := make(map[int]bool, len(changed)+len(removed))
+	for _, line := range changed {
+		kind := model.StatLineLogged
+		for _, stored := range before {
+			if stored.PlayerID == line.PlayerID {
+				kind = model.StatLineCorrected
+			}
+		}
+		events = append(events, model.GameEvent{Kind: kind, GameID: g.Id, Line: line, OccurredAt: at})
+		replaced[line.PlayerID] = true
+	}
+	for _, id := range removed {
+		replaced[id] = true
+	}
+
+	after := append([]model.PlayerGameStats(nil), changed...)
+	for _, stored := range before {
+		if !replaced[stored.PlayerID] {
+			after = append(after, stored)
+		}
+	}
+	if score := gameScore(g, after); score != gameScore(g, before) {
+		events = append(events, model.GameEvent{Kind: model.ScoreChanged, GameID: g.Id, Score: score, OccurredAt: at})
+	}
+	return events
+}
+
+// gameScore adds up the points of each team's lines. Lines logged before team tracking
+// have no team and count for neither.
+func gameScore(g model.Game, lines []model.PlayerGameStats) model.GameScore {
+	score := model.GameScore{TeamAID: g.TeamAID, TeamBID: g.TeamBID}
+	for _, line := range lines {
+		switch line.TeamID {
+		case g.TeamAID:
+			score.TeamAPoints += line.Points
+		case g.TeamBID:
+			score.TeamBPoints += line.Points
+		}
+	}
+	return score
+}
+
+// checkIdempotencyKey reports whether the key was already used for the same request,
+// and fails with ErrIdempotencyKeyReused if it was used for a different one.
+func (s *ServiceStruct) checkIdempotencyKey(ctx context.Context, repo postgres.PlayerRepository, key string, requestHash string) (bool, error) {
+	record, err := repo.GetIdempotencyKey(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	if record.Key == "" {
+		return false, nil
+	}
+	if record.RequestHash != requestHash {
+		return false, ErrIdempotencyKeyReused
+	}
+	return true, nil
+}
+
+// hashLogPlayerGameRequest fingerprints everything in the request except the idempotency key itself.
+func hashLogPlayerGameRequest(playerId int, request model.LogPlayerGameRequest) string {
+	request.PlayerId = playerId
+	request.IdempotencyKey = ""
+	payload, _ := json.Marshal(request)
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
 }
 
 func (s *ServiceStruct) GetPlayerSeasonAverages(ctx context.Context, req model.GetPlayerGameStatsRequest) (*model.PlayerSeasonAverage, error) {
@@ -124,29 +326,30 @@ func (s *ServiceStruct) GetPlayerSeasonAverages(ctx context.Context, req model.G
 	}
 
 	// Get player data from the repository
-	p, err := s.playerRepository.GetPlayer(req.PlayerID)
+	p, err := s.playerRepository.GetPlayer(ctx, req.PlayerID)
 	if err != nil {
 		return nil, err
 	}
 	if p.Id == 0 {
-		return nil, errors.New("player not found")
+		return nil, ErrPlayerNotFound
 	}
 
 	// Get player stats by season
-	playerStats, err := s.playerRepository.GetPlayerGamesBySeason(req.PlayerID, req.SeasonYear)
+	playerStats, err := s.playerRepository.GetPlayerGamesBySeason(ctx, req.PlayerID, req.SeasonYear)
 	if err != nil {
 		return nil, err
 	}
 	totalGames := len(playerStats)
-	if totalGames == 0 {
-		return nil, nil
-	}
 
-	// Initialize the stats object with zero values
+	// Initialize the stats object with zero values; a season without games keeps them
 	stats := model.PlayerSeasonAverage{
-		PlayerID:   p.Id,
-		PlayerName: p.Name,
-		Season:     req.SeasonYear,
+		PlayerID:    p.Id,
+		PlayerName:  p.Name,
+		Season:      req.SeasonYear,
+		GamesPlayed: totalGames,
+	}
+	if totalGames == 0 {
+		return &stats, nil
 	}
 
 	// Accumulate the stats for all games
@@ -159,19 +362,19 @@ func (s *ServiceStruct) GetPlayerSeasonAverages(ctx context.Context, req model.G
 		stats.TurnoversPerGame += float32(game.Turnovers)
 		stats.FoulsPerGame += float32(game.Fouls)
 		stats.MinutesPlayedPerGame += game.MinutesPlayed
+		stats.PlusMinusPerGame += float32(game.PlusMinus)
 	}
 
 	// Calculate the averages
-	if totalGames > 0 {
-		stats.PointsPerGame /= float32(totalGames)
-		stats.AssistsPerGame /= float32(totalGames)
-		stats.ReboundsPerGame /= float32(totalGames)
-		stats.StealsPerGame /= float32(totalGames)
-		stats.BlocksPerGame /= float32(totalGames)
-		stats.TurnoversPerGame /= float32(totalGames)
-		stats.FoulsPerGame /= float32(totalGames)
-		stats.MinutesPlayedPerGame /= float32(totalGames)
-	}
+	stats.PointsPerGame /= float32(totalGames)
+	stats.AssistsPerGame /= float32(totalGames)
+	stats.ReboundsPerGame /= float32(totalGames)
+	stats.StealsPerGame /= float32(totalGames)
+	stats.BlocksPerGame /= float32(totalGames)
+	stats.TurnoversPerGame /= float32(totalGames)
+	stats.FoulsPerGame /= float32(totalGames)
+	stats.MinutesPlayedPerGame /= float32(totalGames)
+	stats.PlusMinusPerGame /= float32(totalGames)
 
 	return &stats, nil
 }
@@ -197,27 +400,34 @@ func (s *ServiceStruct) GetTeamSeasonAverages(ctx context.Context, req model.Get
 	}
 
 	// Retrieve the team from the repository
-	team, err := s.playerRepository.GetTeam(req.TeamID)
+	team, err := s.playerRepository.GetTeam(ctx, req.TeamID)
 	if err != nil {
 		return nil, err
 	}
 	if team.Id == 0 {
-		return nil, errors.New("team not found")
+		return nil, ErrTeamNotFound
 	}
 
 	// Get team players' game stats for the season
-	teamPlayersBySeason, err := s.playerRepository.GetTeamPlayersBySeason(req.TeamID, req.SeasonYear)
+	teamPlayersBySeason, err := s.playerRepository.GetTeamPlayersBySeason(ctx, req.TeamID, req.SeasonYear)
 	if err != nil {
 		return nil, err
 	}
-	totalGames := len(teamPlayersBySeason)
-	if totalGames == 0 {
-		return nil, nil
+
+	// Initialize the stats object; a season without games keeps the zero averages
+	stats := model.TeamSeasoAverage{
+		TeamID:   team.Id,
+		TeamName: team.Name,
+		Season:   req.SeasonYear,
+	}
+	if len(teamPlayersBySeason) == 0 {
+		return &stats, nil
 	}
 
-	// Initialize the stats object
-	stats := model.TeamSeasoAverage{}
+	// Add up the stat lines of every player into the team's totals
+	games := make(map[int]bool)
 	for _, teamPlayer := range teamPlayersBySeason {
+		games[teamPlayer.GameID] = true
 		stats.PointsPerGame += float32(teamPlayer.Points)
 		stats.AssistsPerGame += float32(teamPlayer.Assists)
 		stats.ReboundsPerGame += float32(teamPlayer.Rebounds)
@@ -228,19 +438,138 @@ func (s *ServiceStruct) GetTeamSeasonAverages(ctx context.Context, req model.Get
 		stats.MinutesPlayedPerGame += float32(teamPlayer.MinutesPlayed)
 	}
 
-	// Calculate the averages, ensure no division by zero
-	stats.PointsPerGame /= float32(totalGames)
-	stats.AssistsPerGame /= float32(totalGames)
-	stats.ReboundsPerGame /= float32(totalGames)
-	stats.StealsPerGame /= float32(totalGames)
-	stats.BlocksPerGame /= float32(totalGames)
-	stats.TurnoversPerGame /= float32(totalGames)
-	stats.FoulsPerGame /= float32(totalGames)
-	stats.MinutesPlayedPerGame /= float32(totalGames)
-
-	stats.TeamID = team.Id
-	stats.TeamName = team.Name
-	stats.Season = req.SeasonYear
+	// Average the totals over the games the team played, not over its stat lines
+	stats.GamesPlayed = len(games)
+	totalGames := float32(stats.GamesPlayed)
+	stats.PointsPerGame /= totalGames
+	stats.AssistsPerGame /= totalGames
+	stats.ReboundsPerGame /= totalGames
+	stats.StealsPerGame /= totalGames
+	stats.BlocksPerGame /= totalGames
+	stats.TurnoversPerGame /= totalGames
+	stats.FoulsPerGame /= totalGames
+	stats.MinutesPlayedPerGame /= totalGames
 
 	return &stats, nil
 }
+
+// ValidateGame cross-checks every stat line of a game. Each line must belong to a player
+// who was on the roster of one of the two teams on game day, and once the final score is
+// recorded the points of each team's players must add up to it.
+func (s *ServiceStruct) ValidateGame(ctx context.Context, gameId int) (*model.GameValidation, error) {
+	if gameId <= 0 {
+		return nil, errors.New("game ID must be a positive integer")
+	}
+
+	// Check a consistent snapshot of the game
+	var result *model.GameValidation
+	err := s.playerRepository.WithTx(ctx, func(repos postgres.Repositories) error {
+		var err error
+		result, err = validateGame(ctx, repos.Players, gameId)
+		return err
+	}, postgres.ReadOnly(), postgres.Isolation(sql.LevelRepeatableRead))
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// validateGame runs the checks of ValidateGame against repo.
+func validateGame(ctx context.Context, repo postgres.PlayerRepository, gameId int) (*model.GameValidation, error) {
+	g, err := repo.GetGame(ctx, gameId)
+	if err != nil {
+		return nil, err
+	}
+	if g.Id == 0 {
+		return nil, ErrGameNotFound
+	}
+
+	lines, err := repo.GetGameStats(ctx, gameId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := model.GameValidation{GameID: g.Id, Valid: true}
+	addCheck := func(check model.GameCheck) {
+		if check.Status == model.CheckFailed {
+			result.Valid = false
+		}
+		result.Checks = append(result.Checks, check)
+	}
+
+	// Roster checks, one per stat line
+	points := make(map[int]int)
+	for _, line := range lines {
+		check := model.GameCheck{Name: fmt.Sprintf("roster:player:%d", line.PlayerID), Status: model.CheckPassed}
+		teamID, err := repo.GetPlayerTeamOnDate(ctx, line.PlayerID, g.Date)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case teamID != g.TeamAID && teamID != g.TeamBID:
+			check.Status = model.CheckFailed
+			check.Message = fmt.Sprintf("%s was not on the roster of team %d or team %d on %s",
+				line.PlayerName, g.TeamAID, g.TeamBID, g.Date.Format(time.DateOnly))
+		case line.TeamID != 0 && line.TeamID != teamID:
+			check.Status = model.CheckFailed
+			check.Message = fmt.Sprintf("%s is logged for team %d but was on the roster of team %d",
+				line.PlayerName, line.TeamID, teamID)
+		}
+		addCheck(check)
+
+		// Lines logged before team tracking have no team; fall back to the roster
+		if line.TeamID != 0 {
+			teamID = line.TeamID
+		}
+		points[teamID] += line.Points
+	}
+
+	// Score checks, one per team
+	for _, team := range []struct {
+		id    int
+		score *int
+	}{{g.TeamAID, g.TeamAScore}, {g.TeamBID, g.TeamBScore}} {
+		check := model.GameCheck{Name: fmt.Sprintf("score:team:%d", team.id), Status: model.CheckPassed}
+		switch {
+		case team.score == nil:
+			check.Status = model.CheckSkipped
+			check.Message = "final score not recorded yet"
+		case points[team.id] != *team.score:
+			check.Status = model.CheckFailed
+			check.Message = fmt.Sprintf("player points add up to %d but the final score is %d", points[team.id], *team.score)
+		}
+		addCheck(check)
+	}
+
+	return &result, nil
+}
+
+// WatchGame implements Service.
+func (s *ServiceStruct) WatchGame(ctx context.Context, gameId int) (*live.Subscription, error) {
+	if gameId <= 0 {
+		return nil, errors.New("game ID must be a positive integer")
+	}
+	g, err := s.playerRepository.GetGame(ctx, gameId)
+	if err != nil {
+		return nil, err
+	}
+	if g.Id == 0 {
+		return nil, ErrGameNotFound
+	}
+	return s.hub.WatchGame(gameId), nil
+}
+
+// WatchPlayer implements Service.
+func (s *ServiceStruct) WatchPlayer(ctx context.Context, playerId int) (*live.Subscription, error) {
+	if playerId <= 0 {
+		return nil, errors.New("player ID must be a positive integer")
+	}
+	p, err := s.playerRepository.GetPlayer(ctx, playerId)
+	if err != nil {
+		return nil, err
+	}
+	if p.Id == 0 {
+		return nil, ErrPlayerNotFound
+	}
+	return s.hub.WatchPlayer(playerId), nil
+}
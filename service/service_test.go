@@ -0,0 +1,684 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"nba/live"
+	"nba/model"
+	"nba/plays"
+	"nba/postgres"
+	"nba/postgres/mocks"
+	"nba/service"
+	"nba/validation"
+)
+
+var gameDay = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newTestService(t *testing.T) (service.Service, *mocks.MockPlayerRepository) {
+	t.Helper()
+	svc, repo, _ := newTestServiceWithHub(t)
+	return svc, repo
+}
+
+// newTestServiceWithHub is newTestService also returning the hub it publishes to.
+func newTestServiceWithHub(t *testing.T) (service.Service, *mocks.MockPlayerRepository, *live.Hub) {
+	t.Helper()
+	rules, err := validation.NewRegistry("")
+	if err != nil {
+		t.Fatalf("NewRegistry: %v", err)
+	}
+	repo := mocks.NewMockPlayerRepository(gomock.NewController(t))
+	hub := live.NewHub()
+	return service.NewService(zap.NewNop().Sugar(), repo, rules, hub, nil), repo, hub
+}
+
+// expectTx runs units of work directly against the mock, as if they were committed.
+func expectTx(repo *mocks.MockPlayerRepository) {
+	repo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(postgres.Repositories) error, opts ...postgres.TxOption) error {
+			return fn(postgres.Repositories{Players: repo})
+		},
+	).AnyTimes()
+}
+
+func testGame() model.Game {
+	return model.Game{Id: 1, Date: gameDay, SeasonID: 2024, TeamAID: 1, TeamBID: 2, League: validation.DefaultLeague}
+}
+
+func validRequest() model.LogPlayerGameRequest {
+	return model.LogPlayerGameRequest{GameId: 1, Points: 20, Assists: 5, Rebounds: 7, Fouls: 3, MinutesPlayed: 34}
+}
+
+// expectValidLookups expects the game, player and roster lookups of a valid stat line.
+func expectValidLookups(repo *mocks.MockPlayerRepository) {
+	repo.EXPECT().GetGame(gomock.Any(), 1).Return(testGame(), nil)
+	repo.EXPECT().GetPlayer(gomock.Any(), 7).Return(model.Player{Id: 7, Name: "Player 7", CurrentTeamID: 1}, nil)
+	repo.EXPECT().GetPlayerTeamOnDate(gomock.Any(), 7, gameDay).Return(1, nil)
+}
+
+func TestLogPlayerGame(t *testing.T) {
+	errDB := errors.New("connection refused")
+
+	tests := []struct {
+		name     string
+		playerId int
+		request  func(r *model.LogPlayerGameRequest)
+		setup    func(repo *mocks.MockPlayerRepository)
+		wantErr  string
+		wantIs   error
+	}{
+		{
+			name:     "logs the stat line for the roster team",
+			playerId: 7,
+			setup: func(repo *mocks.MockPlayerRepository) {
+				expectValidLookups(repo)
+				repo.EXPECT().LogPlayerGame(gomock.Any(), model.PlayerGameStats{
+					PlayerID: 7, GameID: 1, TeamID: 1,
+					Points: 20, Assists: 5, Rebounds: 7, Fouls: 3, MinutesPlayed: 34,
+				}).Return(nil)
+			},
+		},
+		{
+			name:     "upserts when asked to",
+			playerId: 7,
+			request:  func(r *model.LogPlayerGameRequest) { r.Upsert = true },
+			setup: func(repo *mocks.MockPlayerRepository) {
+				expectValidLookups(repo)
+				repo.EXPECT().UpsertPlayerGame(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:     "rejects a non-positive player ID",
+			playerId: 0,
+			wantErr:  "player ID must be a positive integer",
+		},
+		{
+			name:     "rejects a non-positive game ID",
+			playerId: 7,
+			request:  func(r *model.LogPlayerGameRequest) { r.GameId = -1 },
+			wantErr:  "game ID must be a positive integer",
+		},
+		{
+			name:     "fails when the game lookup fails",
+			playerId: 7,
+			setup: func(repo *mocks.MockPlayerRepository) {
+				repo.EXPECT().GetGame(gomock.Any(), 1).Return(model.Game{}, errDB)
+			},
+			wantIs: errDB,
+		},
+		{
+			name:     "fails when the game does not exist",
+			playerId: 7,
+			setup: func(repo *mocks.MockPlayerRepository) {
+				repo.EXPECT().GetGame(gomock.Any(), 1).Return(model.Game{}, nil)
+			},
+			wantErr: "game not found",
+		},
+		{
+			name:     "rejects stat lines that break the league rules",
+			playerId: 7,
+			request:  func(r *model.LogPlayerGameRequest) { r.Fouls = 7 },
+			setup: func(repo *mocks.MockPlayerRepository) {
+				repo.EXPECT().GetGame(gomock.Any(), 1).Return(testGame(), nil)
+			},
+			wantErr: "invalid stat line: fouls must be at most 6, got 7",
+		},
+		{
+			name:     "fails when the player does not exist",
+			playerId: 7,
+			setup: func(repo *mocks.MockPlayerRepository) {
+				repo.EXPECT().GetGame(gomock.Any(), 1).Return(testGame(), nil)
+				repo.EXPECT().GetPlayer(gomock.Any(), 7).Return(model.Player{}, nil)
+			},
+			wantErr: "player not found",
+		},
+		{
+			name:     "rejects players without a roster entry",
+			playerId: 7,
+			setup: func(repo *mocks.MockPlayerRepository) {
+				repo.EXPECT().GetGame(gomock.Any(), 1).Return(testGame(), nil)
+				repo.EXPECT().GetPlayer(gomock.Any(), 7).Return(model.Player{Id: 7}, nil)
+				repo.EXPECT().GetPlayerTeamOnDate(gomock.Any(), 7, gameDay).Return(0, nil)
+			},
+			wantErr: "player 7 was not on the roster of team 1 or team 2 on 2024-01-01",
+		},
+		{
+			name:     "rejects players of a team not in the game",
+			playerId: 7,
+			setup: func(repo *mocks.MockPlayerRepository) {
+				repo.EXPECT().GetGame(gomock.Any(), 1).Return(testGame(), nil)
+				repo.EXPECT().GetPlayer(gomock.Any(), 7).Return(model.Player{Id: 7}, nil)
+				repo.EXPECT().GetPlayerTeamOnDate(gomock.Any(), 7, gameDay).Return(3, nil)
+			},
+			wantErr: "player 7 was not on the roster of team 1 or team 2 on 2024-01-01",
+		},
+		{
+			name:     "reports duplicate stat lines",
+			playerId: 7,
+			setup: func(repo *mocks.MockPlayerRepository) {
+				expectValidLookups(repo)
+				repo.EXPECT().LogPlayerGame(gomock.Any(), gomock.Any()).Return(postgres.ErrDuplicate)
+			},
+			wantIs: postgres.ErrDuplicate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo := newTestService(t)
+			expectTx(repo)
+			if tt.setup != nil {
+				tt.setup(repo)
+			}
+			request := validRequest()
+			if tt.request != nil {
+				tt.request(&request)
+			}
+
+			err := svc.LogPlayerGame(context.Background(), tt.playerId, request)
+			switch {
+			case tt.wantErr != "":
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("LogPlayerGame() error = %v, want %q", err, tt.wantErr)
+				}
+			case tt.wantIs != nil:
+				if !errors.Is(err, tt.wantIs) {
+					t.Fatalf("LogPlayerGame() error = %v, want %v", err, tt.wantIs)
+				}
+			case err != nil:
+				t.Fatalf("LogPlayerGame() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestLogPlayerGameValidationDetails(t *testing.T) {
+	svc, repo := newTestService(t)
+	expectTx(repo)
+	repo.EXPECT().GetGame(gomock.Any(), 1).Return(testGame(), nil)
+
+	request := validRequest()
+	request.Points = -1
+	request.MinutesPlayed = 60
+	err := svc.LogPlayerGame(context.Background(), 7, request)
+
+	var validationErr *validation.Error
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("LogPlayerGame() error = %v, want *validation.Error", err)
+	}
+	var fields []string
+	for _, v := range validationErr.Violations {
+		fields = append(fields, v.Field)
+	}
+	if len(fields) != 2 || fields[0] != "points" || fields[1] != "minutes_played" {
+		t.Fatalf("violated fields = %v, want [points minutes_played]", fields)
+	}
+}
+
+func TestLogPlayerGameIdempotency(t *testing.T) {
+	ctx := context.Background()
+	request := validRequest()
+	request.IdempotencyKey = "retry-1"
+
+	// The first request stores the key along with the request fingerprint
+	svc, repo := newTestService(t)
+	expectTx(repo)
+	expectValidLookups(repo)
+	repo.EXPECT().GetIdempotencyKey(gomock.Any(), "retry-1").Return(model.IdempotencyKey{}, nil)
+	repo.EXPECT().LogPlayerGame(gomock.Any(), gomock.Any()).Return(nil)
+	var saved model.IdempotencyKey
+	repo.EXPECT().SaveIdempotencyKey(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, record model.IdempotencyKey) error {
+			saved = record
+			return nil
+		},
+	)
+	if err := svc.LogPlayerGame(ctx, 7, request); err != nil {
+		t.Fatalf("LogPlayerGame() error = %v", err)
+	}
+	if saved.Key != "retry-1" || saved.RequestHash == "" {
+		t.Fatalf("saved idempotency key = %+v", saved)
+	}
+
+	t.Run("replay of the same request writes nothing", func(t *testing.T) {
+		svc, repo := newTestService(t)
+		expectTx(repo)
+		repo.EXPECT().GetIdempotencyKey(gomock.Any(), "retry-1").Return(saved, nil)
+		if err := svc.LogPlayerGame(ctx, 7, request); err != nil {
+			t.Fatalf("LogPlayerGame() error = %v", err)
+		}
+	})
+
+	t.Run("reuse for a different request is rejected", func(t *testing.T) {
+		svc, repo := newTestService(t)
+		expectTx(repo)
+		repo.EXPECT().GetIdempotencyKey(gomock.Any(), "retry-1").Return(saved, nil)
+		changed := request
+		changed.Points++
+		if err := svc.LogPlayerGame(ctx, 7, changed); !errors.Is(err, service.ErrIdempotencyKeyReused) {
+			t.Fatalf("LogPlayerGame() error = %v, want %v", err, service.ErrIdempotencyKeyReused)
+		}
+	})
+
+	t.Run("concurrent retry that committed first is a replay", func(t *testing.T) {
+		svc, repo := newTestService(t)
+		expectTx(repo)
+		expectValidLookups(repo)
+		gomock.InOrder(
+			repo.EXPECT().GetIdempotencyKey(gomock.Any(), "retry-1").Return(model.IdempotencyKey{}, nil),
+			repo.EXPECT().GetIdempotencyKey(gomock.Any(), "retry-1").Return(saved, nil),
+		)
+		repo.EXPECT().LogPlayerGame(gomock.Any(), gomock.Any()).Return(nil)
+		repo.EXPECT().SaveIdempotencyKey(gomock.Any(), gomock.Any()).Return(postgres.ErrDuplicate)
+		if err := svc.LogPlayerGame(ctx, 7, request); err != nil {
+			t.Fatalf("LogPlayerGame() error = %v", err)
+		}
+	})
+}
+
+func TestGetPlayerSeasonAverages(t *testing.T) {
+	player := model.Player{Id: 7, Name: "Player 7", CurrentTeamID: 1}
+
+	tests := []struct {
+		name    string
+		request model.GetPlayerGameStatsRequest
+		setup   func(repo *mocks.MockPlayerRepository)
+		want    *model.PlayerSeasonAverage
+		wantErr string
+	}{
+		{
+			name:    "averages every stat over the games played",
+			request: model.GetPlayerGameStatsRequest{PlayerID: 7, SeasonYear: 2024},
+			setup: func(repo *mocks.MockPlayerRepository) {
+				repo.EXPECT().GetPlayer(gomock.Any(), 7).Return(player, nil)
+				repo.EXPECT().GetPlayerGamesBySeason(gomock.Any(), 7, 2024).Return([]model.PlayerGameStats{
+					{Points: 10, Assists: 2, Rebounds: 4, Steals: 1, Blocks: 0, Turnovers: 3, Fouls: 2, MinutesPlayed: 30.5},
+					{Points: 20, Assists: 4, Rebounds: 8, Steals: 0, Blocks: 2, Turnovers: 1, Fouls: 4, MinutesPlayed: 35.5},
+					{Points: 33, Assists: 6, Rebounds: 0, Steals: 2, Blocks: 1, Turnovers: 2, Fouls: 0, MinutesPlayed: 36},
+				}, nil)
+			},
+			want: &model.PlayerSeasonAverage{
+				PlayerID: 7, PlayerName: "Player 7", Season: 2024, GamesPlayed: 3,
+				PointsPerGame: 21, AssistsPerGame: 4, ReboundsPerGame: 4, StealsPerGame: 1,
+				BlocksPerGame: 1, TurnoversPerGame: 2, FoulsPerGame: 2, MinutesPlayedPerGame: 34,
+			},
+		},
+		{
+			name:    "returns zero games for an empty season",
+			request: model.GetPlayerGameStatsRequest{PlayerID: 7, SeasonYear: 2030},
+			setup: func(repo *mocks.MockPlayerRepository) {
+				repo.EXPECT().GetPlayer(gomock.Any(), 7).Return(player, nil)
+				repo.EXPECT().GetPlayerGamesBySeason(gomock.Any(), 7, 2030).Return(nil, nil)
+			},
+			want: &model.PlayerSeasonAverage{PlayerID: 7, PlayerName: "Player 7", Season: 2030},
+		},
+		{
+			name:    "rejects a non-positive season",
+			request: model.GetPlayerGameStatsRequest{PlayerID: 7},
+			wantErr: "season must be a positive integer",
+		},
+		{
+			name:    "rejects a non-positive player ID",
+			request: model.GetPlayerGameStatsRequest{SeasonYear: 2024},
+			wantErr: "player ID must be a positive integer",
+		},
+		{
+			name:    "fails when the player does not exist",
+			request: model.GetPlayerGameStatsRequest{PlayerID: 7, SeasonYear: 2024},
+			setup: func(repo *mocks.MockPlayerRepository) {
+				repo.EXPECT().GetPlayer(gomock.Any(), 7).Return(model.Player{}, nil)
+			},
+			wantErr: "player not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo := newTestService(t)
+			if tt.setup != nil {
+				tt.setup(repo)
+			}
+
+			got, err := svc.GetPlayerSeasonAverages(context.Background(), tt.request)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("GetPlayerSeasonAverages() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetPlayerSeasonAverages() error = %v", err)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Fatalf("GetPlayerSeasonAverages() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTeamSeasonAverages(t *testing.T) {
+	team := model.Team{Id: 1, Name: "Team A"}
+
+	tests := []struct {
+		name    string
+		request model.GetTeamGameStatsRequest
+		setup   func(repo *mocks.MockPlayerRepository)
+		want    *model.TeamSeasoAverage
+		wantErr string
+	}{
+		{
+			name:    "adds up the lines of players in the same game",
+			request: model.GetTeamGameStatsRequest{TeamID: 1, SeasonYear: 2024},
+			setup: func(repo *mocks.MockPlayerRepository) {
+				repo.EXPECT().GetTeam(gomock.Any(), 1).Return(team, nil)
+				repo.EXPECT().GetTeamPlayersBySeason(gomock.Any(), 1, 2024).Return([]model.PlayerGameStats{
+					{PlayerID: 1, GameID: 1, Points: 30, Assists: 1, Rebounds: 10, Steals: 2, Blocks: 3, Turnovers: 4, Fouls: 5, MinutesPlayed: 40},
+					{PlayerID: 2, GameID: 1, Points: 10, Assists: 9, Rebounds: 2, Steals: 0, Blocks: 1, Turnovers: 2, Fouls: 1, MinutesPlayed: 20},
+				}, nil)
+			},
+			want: &model.TeamSeasoAverage{
+				TeamID: 1, TeamName: "Team A", Season: 2024, GamesPlayed: 1,
+				PointsPerGame: 40, AssistsPerGame: 10, ReboundsPerGame: 12, StealsPerGame: 2,
+				BlocksPerGame: 4, TurnoversPerGame: 6, FoulsPerGame: 6, MinutesPlayedPerGame: 60,
+			},
+		},
+		{
+			name:    "averages the team totals over its games",
+			request: model.GetTeamGameStatsRequest{TeamID: 1, SeasonYear: 2024},
+			setup: func(repo *mocks.MockPlayerRepository) {
+				repo.EXPECT().GetTeam(gomock.Any(), 1).Return(team, nil)
+				repo.EXPECT().GetTeamPlayersBySeason(gomock.Any(), 1, 2024).Return([]model.PlayerGameStats{
+					{PlayerID: 1, GameID: 1, Points: 30, Rebounds: 10, MinutesPlayed: 40},
+					{PlayerID: 2, GameID: 1, Points: 10, Rebounds: 2, MinutesPlayed: 20},
+					{PlayerID: 1, GameID: 2, Points: 20, Rebounds: 8, MinutesPlayed: 36},
+				}, nil)
+			},
+			want: &model.TeamSeasoAverage{
+				TeamID: 1, TeamName: "Team A", Season: 2024, GamesPlayed: 2,
+				PointsPerGame: 30, ReboundsPerGame: 10, MinutesPlayedPerGame: 48,
+			},
+		},
+		{
+			name:    "returns zero games for an empty season",
+			request: model.GetTeamGameStatsRequest{TeamID: 1, SeasonYear: 2030},
+			setup: func(repo *mocks.MockPlayerRepository) {
+				repo.EXPECT().GetTeam(gomock.Any(), 1).Return(team, nil)
+				repo.EXPECT().GetTeamPlayersBySeason(gomock.Any(), 1, 2030).Return(nil, nil)
+			},
+			want: &model.TeamSeasoAverage{TeamID: 1, TeamName: "Team A", Season: 2030},
+		},
+		{
+			name:    "rejects a non-positive season",
+			request: model.GetTeamGameStatsRequest{TeamID: 1},
+			wantErr: "season must be a positive integer",
+		},
+		{
+			name:    "rejects a non-positive team ID",
+			request: model.GetTeamGameStatsRequest{SeasonYear: 2024},
+			wantErr: "team ID must be a positive integer",
+		},
+		{
+			name:    "fails when the team does not exist",
+			request: model.GetTeamGameStatsRequest{TeamID: 1, SeasonYear: 2024},
+			setup: func(repo *mocks.MockPlayerRepository) {
+				repo.EXPECT().GetTeam(gomock.Any(), 1).Return(model.Team{}, nil)
+			},
+			wantErr: "team not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo := newTestService(t)
+			if tt.setup != nil {
+				tt.setup(repo)
+			}
+
+			got, err := svc.GetTeamSeasonAverages(context.Background(), tt.request)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("GetTeamSeasonAverages() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetTeamSeasonAverages() error = %v", err)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Fatalf("GetTeamSeasonAverages() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateGame(t *testing.T) {
+	score := func(points int) *int { return &points }
+
+	tests := []struct {
+		name      string
+		game      func(g *model.Game)
+		lines     []model.PlayerGameStats
+		rosters   map[int]int
+		wantValid bool
+		want      []model.GameCheck
+	}{
+		{
+			name: "passes when rosters and scores match",
+			game: func(g *model.Game) { g.TeamAScore, g.TeamBScore = score(30), score(12) },
+			lines: []model.PlayerGameStats{
+				{PlayerID: 7, TeamID: 1, Points: 30},
+				{PlayerID: 8, TeamID: 2, Points: 12},
+			},
+			rosters:   map[int]int{7: 1, 8: 2},
+			wantValid: true,
+			want: []model.GameCheck{
+				{Name: "roster:player:7", Status: model.CheckPassed},
+				{Name: "roster:player:8", Status: model.CheckPassed},
+				{Name: "score:team:1", Status: model.CheckPassed},
+				{Name: "score:team:2", Status: model.CheckPassed},
+			},
+		},
+		{
+			name:      "skips score checks until the final score is recorded",
+			lines:     []model.PlayerGameStats{{PlayerID: 7, TeamID: 1, Points: 30}},
+			rosters:   map[int]int{7: 1},
+			wantValid: true,
+			want: []model.GameCheck{
+				{Name: "roster:player:7", Status: model.CheckPassed},
+				{Name: "score:team:1", Status: model.CheckSkipped, Message: "final score not recorded yet"},
+				{Name: "score:team:2", Status: model.CheckSkipped, Message: "final score not recorded yet"},
+			},
+		},
+		{
+			name:    "fails on players off the roster and wrong totals",
+			game:    func(g *model.Game) { g.TeamAScore, g.TeamBScore = score(31), score(0) },
+			lines:   []model.PlayerGameStats{{PlayerID: 7, PlayerName: "Player 7", TeamID: 1, Points: 30}},
+			rosters: map[int]int{7: 3},
+			want: []model.GameCheck{
+				{Name: "roster:player:7", Status: model.CheckFailed, Message: "Player 7 was not on the roster of team 1 or team 2 on 2024-01-01"},
+				{Name: "score:team:1", Status: model.CheckFailed, Message: "player points add up to 30 but the final score is 31"},
+				{Name: "score:team:2", Status: model.CheckPassed},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo := newTestService(t)
+			expectTx(repo)
+			game := testGame()
+			if tt.game != nil {
+				tt.game(&game)
+			}
+			repo.EXPECT().GetGame(gomock.Any(), 1).Return(game, nil)
+			repo.EXPECT().GetGameStats(gomock.Any(), 1).Return(tt.lines, nil)
+			for playerID, teamID := range tt.rosters {
+				repo.EXPECT().GetPlayerTeamOnDate(gomock.Any(), playerID, gameDay).Return(teamID, nil)
+			}
+
+			got, err := svc.ValidateGame(context.Background(), 1)
+			if err != nil {
+				t.Fatalf("ValidateGame() error = %v", err)
+			}
+			if got.Valid != tt.wantValid {
+				t.Errorf("Valid = %v, want %v", got.Valid, tt.wantValid)
+			}
+			if len(got.Checks) != len(tt.want) {
+				t.Fatalf("Checks = %+v, want %+v", got.Checks, tt.want)
+			}
+			for i := range tt.want {
+				if got.Checks[i] != tt.want[i] {
+					t.Errorf("Checks[%d] = %+v, want %+v", i, got.Checks[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+// received reads the events already delivered to sub.
+func received(sub *live.Subscription) []model.GameEvent {
+	var events []model.GameEvent
+	for {
+		select {
+		case event := <-sub.Events():
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestLogPlayerGamePublishesEvents(t *testing.T) {
+	teamB := model.PlayerGameStats{PlayerID: 8, GameID: 1, TeamID: 2, Points: 10}
+	earlier := model.PlayerGameStats{PlayerID: 7, GameID: 1, TeamID: 1, Points: 20, Fouls: 1}
+
+	tests := []struct {
+		name      string
+		upsert    bool
+		before    []model.PlayerGameStats
+		writeErr  error
+		wantKinds []model.GameEventKind
+		wantScore model.GameScore
+	}{
+		{
+			name:      "new line moves the score",
+			before:    []model.PlayerGameStats{teamB},
+			wantKinds: []model.GameEventKind{model.StatLineLogged, model.ScoreChanged},
+			wantScore: model.GameScore{TeamAID: 1, TeamAPoints: 20, TeamBID: 2, TeamBPoints: 10},
+		},
+		{
+			name:      "correction keeping the points",
+			upsert:    true,
+			before:    []model.PlayerGameStats{earlier, teamB},
+			wantKinds: []model.GameEventKind{model.StatLineCorrected},
+		},
+		{
+			name:     "failed write",
+			before:   []model.PlayerGameStats{teamB},
+			writeErr: errors.New("connection refused"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, _ := newTestServiceWithHub(t)
+			expectTx(repo)
+			repo.EXPECT().GetGame(gomock.Any(), 1).Return(testGame(), nil)
+			game, err := svc.WatchGame(context.Background(), 1)
+			if err != nil {
+				t.Fatalf("WatchGame() error = %v", err)
+			}
+			defer game.Close()
+			repo.EXPECT().GetPlayer(gomock.Any(), 7).Return(model.Player{Id: 7, Name: "Player 7"}, nil)
+			player, err := svc.WatchPlayer(context.Background(), 7)
+			if err != nil {
+				t.Fatalf("WatchPlayer() error = %v", err)
+			}
+			defer player.Close()
+
+			expectValidLookups(repo)
+			repo.EXPECT().GetGameStats(gomock.Any(), 1).Return(tt.before, nil)
+			if tt.upsert {
+				repo.EXPECT().UpsertPlayerGame(gomock.Any(), gomock.Any()).Return(tt.writeErr)
+			} else {
+				repo.EXPECT().LogPlayerGame(gomock.Any(), gomock.Any()).Return(tt.writeErr)
+			}
+			request := validRequest()
+			request.Upsert = tt.upsert
+			if err := svc.LogPlayerGame(context.Background(), 7, request); !errors.Is(err, tt.writeErr) {
+				t.Fatalf("LogPlayerGame() error = %v, want %v", err, tt.writeErr)
+			}
+
+			events := received(game)
+			if len(events) != len(tt.wantKinds) {
+				t.Fatalf("game events = %+v, want kinds %v", events, tt.wantKinds)
+			}
+			for i, kind := range tt.wantKinds {
+				if events[i].Kind != kind {
+					t.Errorf("events[%d].Kind = %s, want %s", i, events[i].Kind, kind)
+				}
+			}
+			if len(events) > 0 && (events[0].Line.PlayerName != "Player 7" || events[0].Line.Points != 20) {
+				t.Errorf("line = %+v, want the stored line of Player 7", events[0].Line)
+			}
+			if len(events) > 1 && events[1].Score != tt.wantScore {
+				t.Errorf("score = %+v, want %+v", events[1].Score, tt.wantScore)
+			}
+			// The player's watchers hear of the line but not of the score
+			if lines := received(player); len(lines) != min(len(events), 1) {
+				t.Errorf("player events = %+v, want only the line", lines)
+			}
+		})
+	}
+}
+
+func TestLogPlayerGameUnwatched(t *testing.T) {
+	svc, repo := newTestService(t)
+	expectTx(repo)
+	expectValidLookups(repo)
+	// Without watchers the lines of the game are not read
+	repo.EXPECT().LogPlayerGame(gomock.Any(), gomock.Any()).Return(nil)
+	if err := svc.LogPlayerGame(context.Background(), 7, validRequest()); err != nil {
+		t.Fatalf("LogPlayerGame() error = %v", err)
+	}
+}
+
+func TestWatchGameNotFound(t *testing.T) {
+	svc, repo := newTestService(t)
+	repo.EXPECT().GetGame(gomock.Any(), 9).Return(model.Game{}, nil)
+	if _, err := svc.WatchGame(context.Background(), 9); err == nil || err.Error() != "game not found" {
+		t.Fatalf("WatchGame() error = %v, want game not found", err)
+	}
+}
+
+func TestRecordPlaysRejectsBrokenPlayByPlay(t *testing.T) {
+	svc, repo := newTestService(t)
+	expectTx(repo)
+	expectValidLookups(repo)
+	repo.EXPECT().GetPlays(gomock.Any(), 1).Return(nil, nil)
+
+	// Player 7 goes out without having come in; nothing may be written
+	_, err := svc.RecordPlays(context.Background(), 1, []model.Play{
+		{Period: 1, Type: model.PlaySubstitution, ReplacedPlayerID: 7},
+	})
+	var playErr *plays.Error
+	if !errors.As(err, &playErr) || playErr.Sequence != 1 {
+		t.Fatalf("RecordPlays() error = %v, want a play error on play 1", err)
+	}
+}
+
+func TestRecordStintsRejectsGamesWithPlays(t *testing.T) {
+	svc, repo := newTestService(t)
+	expectTx(repo)
+	repo.EXPECT().GetGame(gomock.Any(), 1).Return(testGame(), nil)
+	repo.EXPECT().GetPlays(gomock.Any(), 1).Return([]model.Play{{GameID: 1, Sequence: 1, Period: 1, Type: model.PlayPeriodStart}}, nil)
+
+	// The stints of a game with play-by-play are derived from it; nothing may be written
+	err := svc.RecordStints(context.Background(), 1, 1, []model.Stint{
+		{Period: 1, End: time.Minute, PlayerIDs: []int{7}},
+	})
+	if !errors.Is(err, service.ErrStintsDerived) {
+		t.Fatalf("RecordStints() error = %v, want ErrStintsDerived", err)
+	}
+}
@@ -1,14 +1,100 @@
 package service
 
 import (
+	"bufio"
 	"context"
-	"log"
+	"errors"
+	"io"
+	"nba/auth"
+	"nba/exporter"
+	"nba/importer"
+	"nba/lineups"
+	"nba/live"
+	"nba/middleware"
 	"nba/model"
 	"nba/pb"
+	"nba/plays"
+	"nba/postgres"
+	"nba/ratelimit"
+	"nba/validation"
+	"strings"
 
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// IdempotencyKeyHeader is the metadata key clients can use instead of the idempotency_key field.
+const IdempotencyKeyHeader = "idempotency-key"
+
+// IncomingHeaderMatcher forwards the Idempotency-Key, X-Request-Id and X-Api-Key HTTP headers
+// to gRPC metadata, along with the headers the gateway forwards by default, Authorization among them.
+func IncomingHeaderMatcher(key string) (string, bool) {
+	switch {
+	case strings.EqualFold(key, IdempotencyKeyHeader):
+		return IdempotencyKeyHeader, true
+	case strings.EqualFold(key, middleware.RequestIDHeader):
+		return middleware.RequestIDHeader, true
+	case strings.EqualFold(key, auth.APIKeyHeader):
+		return auth.APIKeyHeader, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// OutgoingHeaderMatcher returns the request id as the X-Request-Id HTTP header, the wait
+// of rate limited calls as Retry-After, and other response metadata with the gateway's
+// default Grpc-Metadata- prefix.
+func OutgoingHeaderMatcher(key string) (string, bool) {
+	switch key {
+	case middleware.RequestIDHeader:
+		return "X-Request-Id", true
+	case ratelimit.RetryAfterHeader:
+		return "Retry-After", true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+// AccessPolicy is the role every RPC of the service requires. Writes need a scorekeeper
+// and key management an admin; health checks are open to load balancers and probes, and
+// reflection to tools such as grpcurl.
+var AccessPolicy = auth.Policy{
+	pb.PlayerGameService_GetPlayer_FullMethodName:                auth.Reader,
+	pb.PlayerGameService_GetPlayerGameSeasonStats_FullMethodName: auth.Reader,
+	pb.PlayerGameService_GetTeamSeasonStats_FullMethodName:       auth.Reader,
+	pb.PlayerGameService_ValidateGame_FullMethodName:             auth.Reader,
+	pb.PlayerGameService_WatchGame_FullMethodName:                auth.Reader,
+	pb.PlayerGameService_WatchPlayer_FullMethodName:              auth.Reader,
+	pb.PlayerGameService_ListPlays_FullMethodName:                auth.Reader,
+	pb.PlayerGameService_GetLineupStats_FullMethodName:           auth.Reader,
+	pb.PlayerGameService_ExportStats_FullMethodName:              auth.Reader,
+	pb.PlayerGameService_LogPlayerGame_FullMethodName:            auth.Scorekeeper,
+	pb.PlayerGameService_RecordPlays_FullMethodName:              auth.Scorekeeper,
+	pb.PlayerGameService_EditPlay_FullMethodName:                 auth.Scorekeeper,
+	pb.PlayerGameService_DeletePlay_FullMethodName:               auth.Scorekeeper,
+	pb.PlayerGameService_RecordStints_FullMethodName:             auth.Scorekeeper,
+	pb.PlayerGameService_ImportStats_FullMethodName:              auth.Admin,
+
+	pb.AdminService_CreateAPIKey_FullMethodName:  auth.Admin,
+	pb.AdminService_ListAPIKeys_FullMethodName:   auth.Admin,
+	pb.AdminService_RevokeAPIKey_FullMethodName:  auth.Admin,
+	pb.AdminService_GetQuotaUsage_FullMethodName: auth.Admin,
+
+	healthpb.Health_Check_FullMethodName: auth.Public,
+	healthpb.Health_Watch_FullMethodName: auth.Public,
+
+	reflectionpb.ServerReflection_ServerReflectionInfo_FullMethodName:      auth.Public,
+	reflectionv1alpha.ServerReflection_ServerReflectionInfo_FullMethodName: auth.Public,
+}
+
 type GRPCServer struct {
 	Logger *zap.SugaredLogger
 	Svc    Service
@@ -21,7 +107,6 @@ func NewGRPCServer(logger *zap.SugaredLogger, svc Service) *GRPCServer {
 
 // Implement the LogPlayerGame method
 func (t *GRPCServer) LogPlayerGame(ctx context.Context, req *pb.LogPlayerGameRequest) (*pb.LogGameResponse, error) {
-	t.Logger.Info("Received LogPlayerGame request", req)
 	request := model.LogPlayerGameRequest{
 		PlayerId:      int(req.PlayerId),
 		GameId:        int(req.GameId),
@@ -33,24 +118,34 @@ func (t *GRPCServer) LogPlayerGame(ctx context.Context, req *pb.LogPlayerGameReq
 		Turnovers:     int(req.Turnovers),
 		Fouls:         int(req.Fouls),
 		MinutesPlayed: req.MinutesPlayed,
+
+		IdempotencyKey: req.IdempotencyKey,
+		Upsert:         req.Upsert,
+	}
+	// The idempotency key may also come from metadata, e.g. the Idempotency-Key HTTP header
+	if request.IdempotencyKey == "" {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if keys := md.Get(IdempotencyKeyHeader); len(keys) > 0 {
+				request.IdempotencyKey = keys[0]
+			}
+		}
 	}
 
 	err := t.Svc.LogPlayerGame(ctx, request.PlayerId, request)
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 	return &pb.LogGameResponse{Success: true}, nil
 }
 
 // Implement the GetPlayersGameStats method
 func (t *GRPCServer) GetPlayerGameSeasonStats(ctx context.Context, request *pb.GetPlayerGameSeasonStatsRequest) (*pb.PlayerGameSeasonStatsResponse, error) {
-	log.Println("Received GetPlayerGameSeasonStats request", request)
 	player, err := t.Svc.GetPlayerSeasonAverages(ctx, model.GetPlayerGameStatsRequest{
 		PlayerID:   int(request.PlayerId),
 		SeasonYear: int(request.Season),
 	})
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 	playerStats := pb.PlayerGameStat{
 		Points:        int32(player.PointsPerGame),
@@ -62,6 +157,8 @@ func (t *GRPCServer) GetPlayerGameSeasonStats(ctx context.Context, request *pb.G
 		Fouls:         int32(player.FoulsPerGame),
 		MinutesPlayed: player.MinutesPlayedPerGame,
 		PlayerId:      int32(player.PlayerID),
+		GamesPlayed:   int32(player.GamesPlayed),
+		PlusMinus:     player.PlusMinusPerGame,
 	}
 
 	return &pb.PlayerGameSeasonStatsResponse{PlayerGameStats: &playerStats}, nil
@@ -69,6 +166,523 @@ func (t *GRPCServer) GetPlayerGameSeasonStats(ctx context.Context, request *pb.G
 }
 
 func (t *GRPCServer) GetPlayer(ctx context.Context, request *pb.GetPlayerRequest) (*pb.GetPlayerResponse, error) {
-	log.Println("Received GetPlayerGameStats request", request)
 	return &pb.GetPlayerResponse{Message: "cool", Success: true}, nil
 }
+
+func (t *GRPCServer) ValidateGame(ctx context.Context, request *pb.ValidateGameRequest) (*pb.ValidateGameResponse, error) {
+	result, err := t.Svc.ValidateGame(ctx, int(request.GameId))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	response := &pb.ValidateGameResponse{GameId: int32(result.GameID), Valid: result.Valid}
+	for _, check := range result.Checks {
+		response.Checks = append(response.Checks, &pb.GameCheck{
+			Name:    check.Name,
+			Status:  checkStatuses[check.Status],
+			Message: check.Message,
+		})
+	}
+	return response, nil
+}
+
+// WatchGame implements pb.PlayerGameServiceServer.
+func (t *GRPCServer) WatchGame(request *pb.WatchGameRequest, stream pb.PlayerGameService_WatchGameServer) error {
+	sub, err := t.Svc.WatchGame(stream.Context(), int(request.GameId))
+	if err != nil {
+		return toStatusError(err)
+	}
+	return sendEvents(sub, stream)
+}
+
+// WatchPlayer implements pb.PlayerGameServiceServer.
+func (t *GRPCServer) WatchPlayer(request *pb.WatchPlayerRequest, stream pb.PlayerGameService_WatchPlayerServer) error {
+	sub, err := t.Svc.WatchPlayer(stream.Context(), int(request.PlayerId))
+	if err != nil {
+		return toStatusError(err)
+	}
+	return sendEvents(sub, stream)
+}
+
+// sendEvents sends the events of sub until the client leaves or the subscription ends.
+func sendEvents(sub *live.Subscription, stream grpc.ServerStreamingServer[pb.GameEvent]) error {
+	defer sub.Close()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return toStatusError(stream.Context().Err())
+		case event, ok := <-sub.Events():
+			if !ok {
+				return toStatusError(sub.Err())
+			}
+			if err := stream.Send(toPBGameEvent(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func toPBGameEvent(event model.GameEvent) *pb.GameEvent {
+	response := &pb.GameEvent{
+		Kind:       gameEventKinds[event.Kind],
+		GameId:     int32(event.GameID),
+		OccurredAt: timestamppb.New(event.OccurredAt),
+	}
+	switch event.Kind {
+	case model.StatLineLogged, model.StatLineCorrected:
+		line := event.Line
+		response.Line = &pb.StatLine{
+			PlayerId:      int32(line.PlayerID),
+			PlayerName:    line.PlayerName,
+			GameId:        int32(line.GameID),
+			TeamId:        int32(line.TeamID),
+			Points:        int32(line.Points),
+			Rebounds:      int32(line.Rebounds),
+			Assists:       int32(line.Assists),
+			Steals:        int32(line.Steals),
+			Blocks:        int32(line.Blocks),
+			Fouls:         int32(line.Fouls),
+			Turnovers:     int32(line.Turnovers),
+			MinutesPlayed: line.MinutesPlayed,
+			PlusMinus:     int32(line.PlusMinus),
+		}
+	case model.ScoreChanged:
+		response.Score = &pb.GameScore{
+			TeamAId:     int32(event.Score.TeamAID),
+			TeamAPoints: int32(event.Score.TeamAPoints),
+			TeamBId:     int32(event.Score.TeamBID),
+			TeamBPoints: int32(event.Score.TeamBPoints),
+		}
+	}
+	return response
+}
+
+var gameEventKinds = map[model.GameEventKind]pb.GameEventKind{
+	model.StatLineLogged:    pb.GameEventKind_GAME_EVENT_KIND_STAT_LINE_LOGGED,
+	model.StatLineCorrected: pb.GameEventKind_GAME_EVENT_KIND_STAT_LINE_CORRECTED,
+	model.ScoreChanged:      pb.GameEventKind_GAME_EVENT_KIND_SCORE_CHANGED,
+}
+
+// RecordPlays implements pb.PlayerGameServiceServer.
+func (t *GRPCServer) RecordPlays(ctx context.Context, request *pb.RecordPlaysRequest) (*pb.RecordPlaysResponse, error) {
+	plays := make([]model.Play, 0, len(request.Plays))
+	for _, play := range request.Plays {
+		plays = append(plays, toModelPlay(request.GameId, play))
+	}
+	recorded, err := t.Svc.RecordPlays(ctx, int(request.GameId), plays)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	response := &pb.RecordPlaysResponse{}
+	for _, play := range recorded {
+		response.Plays = append(response.Plays, toPBPlay(play))
+	}
+	return response, nil
+}
+
+// ListPlays implements pb.PlayerGameServiceServer.
+func (t *GRPCServer) ListPlays(ctx context.Context, request *pb.ListPlaysRequest) (*pb.ListPlaysResponse, error) {
+	plays, err := t.Svc.ListPlays(ctx, int(request.GameId))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	response := &pb.ListPlaysResponse{}
+	for _, play := range plays {
+		response.Plays = append(response.Plays, toPBPlay(play))
+	}
+	return response, nil
+}
+
+// EditPlay implements pb.PlayerGameServiceServer.
+func (t *GRPCServer) EditPlay(ctx context.Context, request *pb.EditPlayRequest) (*pb.EditPlayResponse, error) {
+	play := toModelPlay(request.GameId, request.Play)
+	play.Sequence = int(request.Sequence)
+	edited, err := t.Svc.EditPlay(ctx, play)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.EditPlayResponse{Play: toPBPlay(edited)}, nil
+}
+
+// DeletePlay implements pb.PlayerGameServiceServer.
+func (t *GRPCServer) DeletePlay(ctx context.Context, request *pb.DeletePlayRequest) (*pb.DeletePlayResponse, error) {
+	if err := t.Svc.DeletePlay(ctx, int(request.GameId), int(request.Sequence)); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.DeletePlayResponse{}, nil
+}
+
+// RecordStints implements pb.PlayerGameServiceServer.
+func (t *GRPCServer) RecordStints(ctx context.Context, request *pb.RecordStintsRequest) (*pb.RecordStintsResponse, error) {
+	stints := make([]model.Stint, 0, len(request.Stints))
+	for _, stint := range request.Stints {
+		playerIds := make([]int, len(stint.PlayerIds))
+		for i, id := range stint.PlayerIds {
+			playerIds[i] = int(id)
+		}
+		stints = append(stints, model.Stint{
+			GameID:        int(request.GameId),
+			TeamID:        int(request.TeamId),
+			Period:        int(stint.Period),
+			Start:         stint.GetStart().AsDuration(),
+			End:           stint.GetEnd().AsDuration(),
+			PlayerIDs:     playerIds,
+			PointsFor:     int(stint.PointsFor),
+			PointsAgainst: int(stint.PointsAgainst),
+		})
+	}
+	if err := t.Svc.RecordStints(ctx, int(request.GameId), int(request.TeamId), stints); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.RecordStintsResponse{}, nil
+}
+
+// GetLineupStats implements pb.PlayerGameServiceServer.
+func (t *GRPCServer) GetLineupStats(ctx context.Context, request *pb.GetLineupStatsRequest) (*pb.GetLineupStatsResponse, error) {
+	stats, err := t.Svc.GetLineupStats(ctx, int(request.TeamId), int(request.Season))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.GetLineupStatsResponse{
+		TeamId:   int32(stats.TeamID),
+		TeamName: stats.TeamName,
+		Season:   int32(stats.Season),
+		Lineups:  toPBLineupStats(stats.Lineups),
+		Pairs:    toPBLineupStats(stats.Pairs),
+	}, nil
+}
+
+// maxImportRejects bounds the rejects an ImportStats response lists; the report still
+// counts every one.
+const maxImportRejects = 1000
+
+// ImportStats implements pb.PlayerGameServiceServer. The options are taken from the
+// first request and the data of every request is imported as one file.
+func (t *GRPCServer) ImportStats(stream pb.PlayerGameService_ImportStatsServer) error {
+	first, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		return status.Error(codes.InvalidArgument, "no import request was sent")
+	}
+	if err != nil {
+		return err
+	}
+	format, err := importer.ParseFormat(first.Format)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	response := &pb.ImportStatsResponse{}
+	opts := importer.Options{
+		Format:      format,
+		Columns:     first.Columns,
+		Season:      int(first.Season),
+		League:      first.League,
+		DryRun:      first.DryRun,
+		ResumeAfter: int(first.ResumeAfter),
+		BatchSize:   int(first.BatchSize),
+		OnReject: func(reject importer.Reject) error {
+			if len(response.Rejects) < maxImportRejects {
+				response.Rejects = append(response.Rejects, &pb.ImportReject{
+					Record: int32(reject.Record),
+					Raw:    reject.Raw,
+					Reason: reject.Reason,
+				})
+			}
+			return nil
+		},
+	}
+	report, err := t.Svc.ImportStats(stream.Context(), &importStream{stream: stream, data: first.Data}, opts)
+	response.DryRun = report.DryRun
+	response.Records = int32(report.Records)
+	response.Skipped = int32(report.Skipped)
+	response.Imported = int32(report.Imported)
+	response.Rejected = int32(report.Rejected)
+	response.TeamsCreated = int32(report.TeamsCreated)
+	response.PlayersCreated = int32(report.PlayersCreated)
+	response.GamesCreated = int32(report.GamesCreated)
+	response.Checkpoint = int32(report.Checkpoint)
+	if err != nil {
+		// The batches before the failure stay committed, so the report goes along as a
+		// detail of the error, its checkpoint being the resume_after of a rerun
+		st := status.Convert(toStatusError(err))
+		if detailed, detailErr := st.WithDetails(response); detailErr == nil {
+			st = detailed
+		}
+		return st.Err()
+	}
+	return stream.SendAndClose(response)
+}
+
+// importStream reads the data of an ImportStats stream, receiving requests as it goes.
+type importStream struct {
+	stream pb.PlayerGameService_ImportStatsServer
+	data   []byte
+}
+
+func (r *importStream) Read(p []byte) (int, error) {
+	for len(r.data) == 0 {
+		request, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.data = request.Data
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+// exportChunkSize is the size of the chunks ExportStats streams.
+const exportChunkSize = 64 * 1024
+
+// ExportStats implements pb.PlayerGameServiceServer.
+func (t *GRPCServer) ExportStats(request *pb.ExportStatsRequest, stream pb.PlayerGameService_ExportStatsServer) error {
+	opts, err := toExportOptions(request)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	w := bufio.NewWriterSize(exportStream{stream}, exportChunkSize)
+	if _, err := t.Svc.ExportStats(stream.Context(), w, opts); err != nil {
+		return toStatusError(err)
+	}
+	return w.Flush()
+}
+
+// toExportOptions reads the options of an export, which is CSV unless asked otherwise.
+func toExportOptions(request *pb.ExportStatsRequest) (exporter.Options, error) {
+	kind, err := exporter.ParseKind(request.Kind)
+	if err != nil {
+		return exporter.Options{}, err
+	}
+	format := exporter.CSV
+	if request.Format != "" {
+		if format, err = exporter.ParseFormat(request.Format); err != nil {
+			return exporter.Options{}, err
+		}
+	}
+	return exporter.Options{
+		Kind:   kind,
+		Format: format,
+		Filter: model.StatLineFilter{
+			Season:   int(request.Season),
+			TeamID:   int(request.TeamId),
+			PlayerID: int(request.PlayerId),
+		},
+	}, nil
+}
+
+// exportStream sends what is written to it as ExportStats chunks.
+type exportStream struct {
+	stream pb.PlayerGameService_ExportStatsServer
+}
+
+func (e exportStream) Write(p []byte) (int, error) {
+	if err := e.stream.Send(&pb.ExportStatsChunk{Data: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func toPBLineupStats(groups []model.LineupStats) []*pb.LineupStats {
+	response := make([]*pb.LineupStats, 0, len(groups))
+	for _, group := range groups {
+		playerIds := make([]int32, len(group.PlayerIDs))
+		for i, id := range group.PlayerIDs {
+			playerIds[i] = int32(id)
+		}
+		response = append(response, &pb.LineupStats{
+			PlayerIds:     playerIds,
+			PlayerNames:   group.PlayerNames,
+			Minutes:       group.Minutes,
+			PointsFor:     int32(group.PointsFor),
+			PointsAgainst: int32(group.PointsAgainst),
+			NetRating:     group.NetRating,
+		})
+	}
+	return response
+}
+
+// toModelPlay converts a play of the given game. Unknown enum values become unknown
+// types, which the service rejects.
+func toModelPlay(gameId int32, play *pb.Play) model.Play {
+	return model.Play{
+		GameID:           int(gameId),
+		Sequence:         int(play.GetSequence()),
+		Period:           int(play.GetPeriod()),
+		Elapsed:          play.GetElapsed().AsDuration(),
+		Type:             keyOf(playTypes, play.GetType()),
+		PlayerID:         int(play.GetPlayerId()),
+		ShotType:         keyOf(shotTypes, play.GetShotType()),
+		ReplacedPlayerID: int(play.GetReplacedPlayerId()),
+	}
+}
+
+func toPBPlay(play model.Play) *pb.Play {
+	return &pb.Play{
+		Sequence:         int32(play.Sequence),
+		Period:           int32(play.Period),
+		Elapsed:          durationpb.New(play.Elapsed),
+		Type:             playTypes[play.Type],
+		PlayerId:         int32(play.PlayerID),
+		ShotType:         shotTypes[play.ShotType],
+		ReplacedPlayerId: int32(play.ReplacedPlayerID),
+	}
+}
+
+// keyOf returns the key m maps to v, or the zero key.
+func keyOf[K, V comparable](m map[K]V, v V) K {
+	for key, value := range m {
+		if value == v {
+			return key
+		}
+	}
+	var zero K
+	return zero
+}
+
+var playTypes = map[model.PlayType]pb.PlayType{
+	model.PlayShotMade:     pb.PlayType_PLAY_TYPE_SHOT_MADE,
+	model.PlayShotMissed:   pb.PlayType_PLAY_TYPE_SHOT_MISSED,
+	model.PlayRebound:      pb.PlayType_PLAY_TYPE_REBOUND,
+	model.PlayAssist:       pb.PlayType_PLAY_TYPE_ASSIST,
+	model.PlaySteal:        pb.PlayType_PLAY_TYPE_STEAL,
+	model.PlayBlock:        pb.PlayType_PLAY_TYPE_BLOCK,
+	model.PlayTurnover:     pb.PlayType_PLAY_TYPE_TURNOVER,
+	model.PlayFoul:         pb.PlayType_PLAY_TYPE_FOUL,
+	model.PlaySubstitution: pb.PlayType_PLAY_TYPE_SUBSTITUTION,
+	model.PlayPeriodStart:  pb.PlayType_PLAY_TYPE_PERIOD_START,
+	model.PlayPeriodEnd:    pb.PlayType_PLAY_TYPE_PERIOD_END,
+}
+
+var shotTypes = map[model.ShotType]pb.ShotType{
+	model.ShotTwoPointer:   pb.ShotType_SHOT_TYPE_TWO_POINTER,
+	model.ShotThreePointer: pb.ShotType_SHOT_TYPE_THREE_POINTER,
+	model.ShotFreeThrow:    pb.ShotType_SHOT_TYPE_FREE_THROW,
+}
+
+var checkStatuses = map[model.CheckStatus]pb.CheckStatus{
+	model.CheckPassed:  pb.CheckStatus_CHECK_STATUS_PASSED,
+	model.CheckFailed:  pb.CheckStatus_CHECK_STATUS_FAILED,
+	model.CheckSkipped: pb.CheckStatus_CHECK_STATUS_SKIPPED,
+}
+
+// AdminServer serves AdminService over gRPC.
+type AdminServer struct {
+	Svc AdminService
+	pb.UnimplementedAdminServiceServer
+}
+
+func NewAdminServer(svc AdminService) *AdminServer {
+	return &AdminServer{Svc: svc}
+}
+
+// CreateAPIKey implements pb.AdminServiceServer.
+func (a *AdminServer) CreateAPIKey(ctx context.Context, request *pb.CreateAPIKeyRequest) (*pb.CreateAPIKeyResponse, error) {
+	key, secret, err := a.Svc.CreateAPIKey(ctx, request.Name, request.Role)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.CreateAPIKeyResponse{ApiKey: toPBAPIKey(key), Key: secret}, nil
+}
+
+// ListAPIKeys implements pb.AdminServiceServer.
+func (a *AdminServer) ListAPIKeys(ctx context.Context, request *pb.ListAPIKeysRequest) (*pb.ListAPIKeysResponse, error) {
+	keys, err := a.Svc.ListAPIKeys(ctx)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	response := &pb.ListAPIKeysResponse{}
+	for _, key := range keys {
+		response.ApiKeys = append(response.ApiKeys, toPBAPIKey(key))
+	}
+	return response, nil
+}
+
+// RevokeAPIKey implements pb.AdminServiceServer.
+func (a *AdminServer) RevokeAPIKey(ctx context.Context, request *pb.RevokeAPIKeyRequest) (*pb.RevokeAPIKeyResponse, error) {
+	key, err := a.Svc.RevokeAPIKey(ctx, int(request.Id))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.RevokeAPIKeyResponse{ApiKey: toPBAPIKey(key)}, nil
+}
+
+// GetQuotaUsage implements pb.AdminServiceServer.
+func (a *AdminServer) GetQuotaUsage(ctx context.Context, request *pb.GetQuotaUsageRequest) (*pb.GetQuotaUsageResponse, error) {
+	usage, err := a.Svc.GetQuotaUsage(ctx, int(request.ApiKeyId))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.GetQuotaUsageResponse{
+		ApiKeyId:   request.ApiKeyId,
+		Used:       int64(usage.Used),
+		DailyQuota: int64(usage.Quota),
+		ResetsAt:   timestamppb.New(usage.ResetsAt),
+	}, nil
+}
+
+func toPBAPIKey(key model.APIKey) *pb.APIKey {
+	apiKey := &pb.APIKey{
+		Id:        int32(key.ID),
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		Role:      key.Role,
+		CreatedAt: timestamppb.New(key.CreatedAt),
+	}
+	if !key.RevokedAt.IsZero() {
+		apiKey.RevokedAt = timestamppb.New(key.RevokedAt)
+	}
+	return apiKey
+}
+
+// toStatusError converts service errors into gRPC status errors.
+// Rule violations become INVALID_ARGUMENT with one field violation per broken rule.
+func toStatusError(err error) error {
+	var validationErr *validation.Error
+	if errors.As(err, &validationErr) {
+		st := status.New(codes.InvalidArgument, validationErr.Error())
+		badRequest := &errdetails.BadRequest{}
+		for _, v := range validationErr.Violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       v.Field,
+				Description: v.Message,
+				Reason:      v.Rule,
+			})
+		}
+		if detailed, detailErr := st.WithDetails(badRequest); detailErr == nil {
+			st = detailed
+		}
+		return st.Err()
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	if errors.Is(err, context.Canceled) {
+		return status.Error(codes.Canceled, err.Error())
+	}
+	if errors.Is(err, ErrIdempotencyKeyReused) || errors.Is(err, postgres.ErrDuplicate) {
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+	if errors.Is(err, ErrInvalidAPIKeyRequest) || errors.Is(err, importer.ErrInvalidOptions) ||
+		errors.Is(err, exporter.ErrInvalidOptions) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	var playErr *plays.Error
+	if errors.As(err, &playErr) || errors.Is(err, lineups.ErrInvalidStint) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, ErrAPIKeyNotFound) || errors.Is(err, ErrPlayNotFound) || errors.Is(err, ErrGameNotFound) ||
+		errors.Is(err, ErrPlayerNotFound) || errors.Is(err, ErrTeamNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if errors.Is(err, ErrQuotasDisabled) || errors.Is(err, ErrStintsDerived) || errors.Is(err, ErrNotOnRoster) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if errors.Is(err, live.ErrFellBehind) {
+		return status.Error(codes.Aborted, err.Error())
+	}
+	if errors.Is(err, live.ErrClosed) {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	return err
+}
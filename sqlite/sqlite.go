@@ -0,0 +1,214 @@
+// Package sqlite runs the player repository on SQLite, for laptops and edge boxes
+// without a Postgres server. It uses a pure Go driver, so binaries stay CGO-free.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"nba/postgres"
+)
+
+// Migrations are the Postgres migrations translated to SQLite, version for version.
+var Migrations = []postgres.Migration{
+	{
+		Version:     1,
+		Description: "create team, game, player and player_game_stats",
+		SQL: `
+		CREATE TABLE team (
+			id INTEGER PRIMARY KEY,
+			name VARCHAR(100) UNIQUE NOT NULL
+		);
+
+		CREATE TABLE game (
+			id INTEGER PRIMARY KEY,
+			date DATE NOT NULL,
+			season INT NOT NULL,
+			team_a_id INT NOT NULL,
+			team_b_id INT NOT NULL
+		);
+
+		CREATE TABLE player (
+			id INTEGER PRIMARY KEY,
+			name VARCHAR(100) NOT NULL,
+			current_team_id INT NOT NULL,
+			CONSTRAINT fk_team FOREIGN KEY (current_team_id) REFERENCES team (id) ON DELETE CASCADE
+		);
+
+		CREATE TABLE player_game_stats (
+			player_id INT NOT NULL,
+			game_id INT NOT NULL,
+			points INT,
+			assists INT,
+			rebounds INT,
+			steals INT,
+			blocks INT,
+			turnovers INT,
+			fouls INT,
+			minutes_played FLOAT,
+			PRIMARY KEY (player_id, game_id),
+			CONSTRAINT fk_player FOREIGN KEY (player_id) REFERENCES player (id) ON DELETE CASCADE,
+			CONSTRAINT fk_game FOREIGN KEY (game_id) REFERENCES game (id) ON DELETE CASCADE
+		);`,
+	},
+	{
+		Version:     2,
+		Description: "track league, overtimes and final score of games",
+		SQL: `
+		ALTER TABLE game ADD COLUMN team_a_score INT;
+		ALTER TABLE game ADD COLUMN team_b_score INT;
+		ALTER TABLE game ADD COLUMN league VARCHAR(50) NOT NULL DEFAULT 'nba';
+		ALTER TABLE game ADD COLUMN overtime_periods INT NOT NULL DEFAULT 0;`,
+	},
+	{
+		Version:     3,
+		Description: "add rosters and the team of each stat line",
+		SQL: `
+		ALTER TABLE player_game_stats ADD COLUMN team_id INT;
+
+		CREATE TABLE roster (
+			player_id INT NOT NULL,
+			team_id INT NOT NULL,
+			start_date DATE NOT NULL,
+			end_date DATE,
+			PRIMARY KEY (player_id, team_id, start_date),
+			CONSTRAINT fk_player FOREIGN KEY (player_id) REFERENCES player (id) ON DELETE CASCADE,
+			CONSTRAINT fk_team FOREIGN KEY (team_id) REFERENCES team (id) ON DELETE CASCADE
+		);`,
+	},
+	{
+		Version:     4,
+		Description: "add idempotency keys",
+		SQL: `
+		CREATE TABLE idempotency_key (
+			key VARCHAR(255) PRIMARY KEY,
+			request_hash CHAR(64) NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);`,
+	},
+	{
+		Version:     5,
+		Description: "add api keys",
+		SQL: `
+		CREATE TABLE api_key (
+			id INTEGER PRIMARY KEY,
+			name VARCHAR(100) NOT NULL,
+			prefix VARCHAR(16) NOT NULL,
+			hash CHAR(64) UNIQUE NOT NULL,
+			role VARCHAR(20) NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			revoked_at TIMESTAMP
+		);`,
+	},
+	{
+		Version:     6,
+		Description: "add plays",
+		SQL: `
+		CREATE TABLE play (
+			game_id INT NOT NULL,
+			sequence INT NOT NULL,
+			period INT NOT NULL,
+			elapsed_ms BIGINT NOT NULL,
+			type VARCHAR(20) NOT NULL,
+			player_id INT,
+			shot_type VARCHAR(20),
+			replaced_player_id INT,
+			PRIMARY KEY (game_id, sequence),
+			CONSTRAINT fk_game FOREIGN KEY (game_id) REFERENCES game (id) ON DELETE CASCADE,
+			CONSTRAINT fk_player FOREIGN KEY (player_id) REFERENCES player (id) ON DELETE CASCADE,
+			CONSTRAINT fk_replaced_player FOREIGN KEY (replaced_player_id) REFERENCES player (id) ON DELETE CASCADE
+		);`,
+	},
+	{
+		Version:     7,
+		Description: "add stints",
+		SQL: `
+		CREATE TABLE stint (
+			game_id INT NOT NULL,
+			team_id INT NOT NULL,
+			number INT NOT NULL,
+			period INT NOT NULL,
+			start_ms BIGINT NOT NULL,
+			end_ms BIGINT NOT NULL,
+			points_for INT NOT NULL,
+			points_against INT NOT NULL,
+			PRIMARY KEY (game_id, team_id, number),
+			CONSTRAINT fk_game FOREIGN KEY (game_id) REFERENCES game (id) ON DELETE CASCADE,
+			CONSTRAINT fk_team FOREIGN KEY (team_id) REFERENCES team (id) ON DELETE CASCADE
+		);
+
+		CREATE TABLE stint_player (
+			game_id INT NOT NULL,
+			team_id INT NOT NULL,
+			number INT NOT NULL,
+			player_id INT NOT NULL,
+			PRIMARY KEY (game_id, team_id, number, player_id),
+			CONSTRAINT fk_stint FOREIGN KEY (game_id, team_id, number) REFERENCES stint (game_id, team_id, number) ON DELETE CASCADE,
+			CONSTRAINT fk_player FOREIGN KEY (player_id) REFERENCES player (id) ON DELETE CASCADE
+		);
+
+		CREATE INDEX stint_player_player_id ON stint_player (player_id, game_id);`,
+	},
+	{
+		Version:     8,
+		Description: "add external player ids for imports",
+		SQL: `
+		ALTER TABLE player ADD COLUMN external_id VARCHAR(100);
+
+		CREATE UNIQUE INDEX player_external_id ON player (external_id);
+		CREATE INDEX player_name ON player (name);
+		CREATE INDEX game_date ON game (date);`,
+	},
+}
+
+// Dialect adapts the player repository to SQLite. SQLite reuses the highest ID for
+// INTEGER PRIMARY KEY columns, so there is no sequence to keep in sync.
+var Dialect = &postgres.Dialect{
+	Name: "sqlite",
+	IsUniqueViolation: func(err error) bool {
+		code, ok := errorCode(err)
+		return ok && (code == sqlite3.SQLITE_CONSTRAINT_UNIQUE || code == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY)
+	},
+	IsRetryable: func(err error) bool {
+		code, ok := errorCode(err)
+		return ok && (code&0xff == sqlite3.SQLITE_BUSY || code&0xff == sqlite3.SQLITE_LOCKED)
+	},
+}
+
+func errorCode(err error) (int, bool) {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return 0, false
+	}
+	return sqliteErr.Code(), true
+}
+
+// Open opens, or creates, the database file at path with foreign keys enforced.
+// SQLite allows a single writer, so the pool is limited to one connection;
+// concurrent requests queue in database/sql instead of failing with SQLITE_BUSY.
+func Open(path string) (*sql.DB, error) {
+	dsn := "file:" + path + "?" + url.Values{"_pragma": {"foreign_keys(1)", "busy_timeout(5000)", "journal_mode(WAL)"}}.Encode()
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite database %s: %w", path, err)
+	}
+	db.SetMaxOpenConns(1)
+	return db, nil
+}
+
+// Migrate brings the SQLite schema up to date.
+func Migrate(ctx context.Context, db *sql.DB) error {
+	return postgres.ApplyMigrations(ctx, db, Migrations)
+}
+
+// NewPlayerRepository creates a player repository backed by SQLite.
+func NewPlayerRepository(db *sql.DB, opts postgres.Options) postgres.PlayerRepository {
+	opts.Dialect = Dialect
+	return postgres.NewPlayerRepository(db, opts)
+}
@@ -0,0 +1,178 @@
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"nba/model"
+	"nba/postgres"
+	"nba/postgres/repotest"
+	"nba/sqlite"
+)
+
+func TestPlayerRepository(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) postgres.PlayerRepository {
+		db, err := sqlite.Open(filepath.Join(t.TempDir(), "nba.db"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { db.Close() })
+
+		if err := sqlite.Migrate(context.Background(), db); err != nil {
+			t.Fatalf("failed to migrate: %v", err)
+		}
+		return sqlite.NewPlayerRepository(db, postgres.Options{})
+	})
+}
+
+func TestMigrateIsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	db, err := sqlite.Open(filepath.Join(t.TempDir(), "nba.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	for i := 0; i < 2; i++ {
+		if err := sqlite.Migrate(ctx, db); err != nil {
+			t.Fatalf("migration run %d: %v", i+1, err)
+		}
+	}
+	version, err := postgres.SchemaVersion(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := sqlite.Migrations[len(sqlite.Migrations)-1].Version; version != want {
+		t.Errorf("schema version = %d, want %d", version, want)
+	}
+}
+
+// recordSpans records the spans of the test. The global tracer provider only takes
+// effect once, so every test shares one recorder.
+func recordSpans(t *testing.T) func() []sdktrace.ReadOnlySpan {
+	t.Helper()
+	spansOnce.Do(func() {
+		spans = tracetest.NewSpanRecorder()
+		provider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+		otel.SetTracerProvider(provider)
+	})
+	before := len(spans.Ended())
+	return func() []sdktrace.ReadOnlySpan { return spans.Ended()[before:] }
+}
+
+var (
+	spansOnce sync.Once
+	spans     *tracetest.SpanRecorder
+	provider  *sdktrace.TracerProvider
+)
+
+func TestOperationsAreTraced(t *testing.T) {
+	ended := recordSpans(t)
+
+	db, err := sqlite.Open(filepath.Join(t.TempDir(), "nba.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := sqlite.Migrate(context.Background(), db); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	repo := sqlite.NewPlayerRepository(db, postgres.Options{})
+
+	ctx, request := provider.Tracer("test").Start(context.Background(), "request")
+	err = repo.WithTx(ctx, func(repos postgres.Repositories) error {
+		_, err := repos.Players.SaveTeam(ctx, model.Team{Name: "Lakers"})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("WithTx() error = %v", err)
+	}
+	request.End()
+
+	// The repository spans belong to the trace of the request
+	spans := ended()
+	if len(spans) != 3 {
+		t.Fatalf("recorded %d spans, want 3", len(spans))
+	}
+	saveTeam, withTx, root := spans[0], spans[1], spans[2]
+	if saveTeam.Name() != "PlayerRepository.SaveTeam" || withTx.Name() != "PlayerRepository.WithTx" {
+		t.Fatalf("spans = %q, %q", saveTeam.Name(), withTx.Name())
+	}
+	for _, span := range []sdktrace.ReadOnlySpan{saveTeam, withTx} {
+		if span.Parent().SpanID() != root.SpanContext().SpanID() {
+			t.Errorf("%s is not a child of the request span", span.Name())
+		}
+	}
+}
+
+func TestFailedOperationsAreTraced(t *testing.T) {
+	ended := recordSpans(t)
+
+	db, err := sqlite.Open(filepath.Join(t.TempDir(), "nba.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := sqlite.Migrate(context.Background(), db); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	repo := sqlite.NewPlayerRepository(db, postgres.Options{})
+
+	// The second team breaks the unique name constraint
+	ctx := context.Background()
+	if _, err := repo.SaveTeam(ctx, model.Team{Name: "Lakers"}); err != nil {
+		t.Fatalf("SaveTeam() error = %v", err)
+	}
+	if _, err := repo.SaveTeam(ctx, model.Team{Name: "Lakers"}); !errors.Is(err, postgres.ErrDuplicate) {
+		t.Fatalf("SaveTeam() error = %v, want ErrDuplicate", err)
+	}
+
+	spans := ended()
+	if len(spans) != 2 {
+		t.Fatalf("recorded %d spans, want 2", len(spans))
+	}
+	if saved := spans[0]; saved.Status().Code != codes.Unset {
+		t.Errorf("successful SaveTeam span status = %v, want unset", saved.Status())
+	}
+	failed := spans[1]
+	if failed.Status().Code != codes.Error || len(failed.Events()) != 1 || failed.Events()[0].Name != "exception" {
+		t.Errorf("failed SaveTeam span status = %v, events = %v, want the error recorded", failed.Status(), failed.Events())
+	}
+}
+
+func TestOperationTimeouts(t *testing.T) {
+	db, err := sqlite.Open(filepath.Join(t.TempDir(), "nba.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := sqlite.Migrate(context.Background(), db); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	repo := sqlite.NewPlayerRepository(db, postgres.Options{Timeouts: postgres.Timeouts{
+		Read:       time.Minute,
+		Operations: map[string]time.Duration{"GetTeam": time.Nanosecond},
+	}})
+
+	ctx := context.Background()
+	team, err := repo.SaveTeam(ctx, model.Team{Name: "Lakers"})
+	if err != nil {
+		t.Fatalf("SaveTeam() error = %v", err)
+	}
+	// GetTeam runs out of its own timeout while other reads keep the read timeout
+	if _, err := repo.GetTeam(ctx, team.Id); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetTeam() error = %v, want the deadline of its operation timeout", err)
+	}
+	if found, err := repo.GetTeamByName(ctx, "Lakers"); err != nil || found.Id != team.Id {
+		t.Errorf("GetTeamByName() = %+v, %v, want the team", found, err)
+	}
+}
@@ -0,0 +1,46 @@
+package tracing_test
+
+import (
+	"context"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"nba/config"
+	"nba/tracing"
+)
+
+func TestLogger(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	logger := zap.New(core).Sugar()
+
+	tracing.Logger(context.Background(), logger).Info("untraced")
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "request")
+	defer span.End()
+	tracing.Logger(ctx, logger).Info("traced")
+
+	entries := logs.AllUntimed()
+	if fields := entries[0].ContextMap(); len(fields) != 0 {
+		t.Errorf("untraced entry has fields %v", fields)
+	}
+	fields := entries[1].ContextMap()
+	if fields["trace_id"] != span.SpanContext().TraceID().String() || fields["span_id"] != span.SpanContext().SpanID().String() {
+		t.Errorf("traced entry has fields %v, want the ids of %v", fields, span.SpanContext())
+	}
+}
+
+func TestSetup(t *testing.T) {
+	shutdown, err := tracing.Setup(context.Background(), config.TracingConfig{Exporter: "none"})
+	if err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("shutdown() error = %v", err)
+	}
+
+	if _, err := tracing.Setup(context.Background(), config.TracingConfig{Exporter: "zipkin"}); err == nil {
+		t.Errorf("Setup() accepted an unknown exporter")
+	}
+}
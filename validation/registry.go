@@ -0,0 +1,121 @@
+package validation
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+)
+
+// DefaultLeague is used for games that don't name a league, or name one without rules.
+const DefaultLeague = "nba"
+
+//go:embed rules/*.json
+var builtinRules embed.FS
+
+// Registry holds the resolved rule set of every known league.
+type Registry struct {
+	sets map[string]*RuleSet
+}
+
+// NewRegistry loads the built-in rule sets and, if dir is not empty, every *.json
+// rule set in dir. A file in dir replaces the built-in rule set of the same league.
+func NewRegistry(dir string) (*Registry, error) {
+	raw := make(map[string]RuleSet)
+	if err := loadRuleSets(builtinRules, "rules", raw); err != nil {
+		return nil, err
+	}
+	if dir != "" {
+		if err := loadRuleSets(os.DirFS(dir), ".", raw); err != nil {
+			return nil, err
+		}
+	}
+
+	r := &Registry{sets: make(map[string]*RuleSet, len(raw))}
+	for league := range raw {
+		set, err := resolve(raw, league, nil)
+		if err != nil {
+			return nil, err
+		}
+		r.sets[league] = set
+	}
+	if _, ok := r.sets[DefaultLeague]; !ok {
+		return nil, fmt.Errorf("no rule set for default league %q", DefaultLeague)
+	}
+	return r, nil
+}
+
+// For returns the rule set of the given league, falling back to the default league.
+func (r *Registry) For(league string) *RuleSet {
+	if set, ok := r.sets[strings.ToLower(league)]; ok {
+		return set
+	}
+	return r.sets[DefaultLeague]
+}
+
+func loadRuleSets(fsys fs.FS, dir string, into map[string]RuleSet) error {
+	paths, err := fs.Glob(fsys, path.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, p := range paths {
+		data, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return fmt.Errorf("failed to read rule set %s: %w", p, err)
+		}
+		var set RuleSet
+		if err := json.Unmarshal(data, &set); err != nil {
+			return fmt.Errorf("failed to parse rule set %s: %w", p, err)
+		}
+		if set.League == "" {
+			return fmt.Errorf("rule set %s has no league", p)
+		}
+		for _, rule := range set.Rules {
+			if err := rule.check(); err != nil {
+				return fmt.Errorf("rule set %s: %w", p, err)
+			}
+		}
+		into[strings.ToLower(set.League)] = set
+	}
+	return nil
+}
+
+// resolve flattens the extends chain of a rule set. Rules of the extending set
+// replace inherited rules with the same name and are appended otherwise.
+func resolve(raw map[string]RuleSet, league string, seen []string) (*RuleSet, error) {
+	for _, s := range seen {
+		if s == league {
+			return nil, fmt.Errorf("rule set %q extends itself: %s", league, strings.Join(append(seen, league), " -> "))
+		}
+	}
+	set, ok := raw[league]
+	if !ok {
+		return nil, fmt.Errorf("unknown rule set %q", league)
+	}
+	if set.Extends == "" {
+		return &RuleSet{League: set.League, Rules: set.Rules}, nil
+	}
+
+	parent, err := resolve(raw, strings.ToLower(set.Extends), append(seen, league))
+	if err != nil {
+		return nil, err
+	}
+	rules := append([]Rule(nil), parent.Rules...)
+	for _, rule := range set.Rules {
+		replaced := false
+		for i := range rules {
+			if rules[i].Name == rule.Name {
+				rules[i] = rule
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			rules = append(rules, rule)
+		}
+	}
+	return &RuleSet{League: set.League, Extends: set.Extends, Rules: rules}, nil
+}
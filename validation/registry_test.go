@@ -0,0 +1,115 @@
+package validation_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"nba/model"
+	"nba/validation"
+)
+
+// writeRuleSets writes each rule set to a file of its own in a new directory.
+func writeRuleSets(t *testing.T, sets map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range sets {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+	return dir
+}
+
+func TestRegistryMergesExtendsAndOverrides(t *testing.T) {
+	dir := writeRuleSets(t, map[string]string{
+		"summer.json": `{
+			"league": "summer",
+			"extends": "fiba",
+			"rules": [
+				{"name": "personal_foul_limit", "field": "fouls", "min": 0, "max": 10},
+				{"name": "points_cap", "field": "points", "max": 80}
+			]
+		}`,
+	})
+	summer := newRegistry(t, dir).For("summer")
+	game := validation.GameContext{League: "summer"}
+
+	// The override replaces the inherited foul limit in place and the new rule comes last
+	if n := len(summer.Rules); n != 9 || summer.Rules[6].Name != "personal_foul_limit" || summer.Rules[8].Name != "points_cap" {
+		t.Fatalf("rules = %+v, want the 8 inherited rules and points_cap", summer.Rules)
+	}
+	if err := summer.Validate(model.PlayerGameStats{Fouls: 10, MinutesPlayed: 40}, game); err != nil {
+		t.Errorf("Validate() error = %v, want the overridden foul limit", err)
+	}
+	// FIBA's minutes and NBA's non-negative stats are inherited through the chain
+	got := violations(t, summer.Validate(model.PlayerGameStats{Rebounds: -1, MinutesPlayed: 41, Points: 81}, game))
+	if strings.Join(got, ",") != "rebounds_non_negative,minutes_in_game,points_cap" {
+		t.Errorf("violations = %v, want the inherited rules and points_cap", got)
+	}
+}
+
+func TestRegistryFileReplacesBuiltinRuleSet(t *testing.T) {
+	dir := writeRuleSets(t, map[string]string{
+		"nba.json": `{"league": "nba", "rules": [{"name": "personal_foul_limit", "field": "fouls", "max": 7}]}`,
+	})
+	registry := newRegistry(t, dir)
+
+	if err := registry.For("nba").Validate(model.PlayerGameStats{Fouls: 7, Points: -1}, validation.GameContext{}); err != nil {
+		t.Errorf("nba: Validate() error = %v, want only the replacing rules", err)
+	}
+	// WNBA extends the replaced rule set
+	if err := registry.For("wnba").Validate(model.PlayerGameStats{Fouls: 7}, validation.GameContext{}); err != nil {
+		t.Errorf("wnba: Validate() error = %v, want the replaced foul limit", err)
+	}
+	// Unknown leagues fall back to the default league
+	if got := registry.For("euroleague"); got != registry.For(validation.DefaultLeague) {
+		t.Errorf("For(euroleague) = %+v, want the default rule set", got)
+	}
+}
+
+func TestRegistryErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		sets    map[string]string
+		wantErr string
+	}{
+		{
+			name: "extends cycle",
+			sets: map[string]string{
+				"a.json": `{"league": "a", "extends": "b", "rules": []}`,
+				"b.json": `{"league": "b", "extends": "a", "rules": []}`,
+			},
+			wantErr: "extends itself",
+		},
+		{
+			name:    "unknown parent",
+			sets:    map[string]string{"a.json": `{"league": "a", "extends": "nbl", "rules": []}`},
+			wantErr: `unknown rule set "nbl"`,
+		},
+		{
+			name:    "unknown field",
+			sets:    map[string]string{"a.json": `{"league": "a", "rules": [{"name": "dunks", "field": "dunks", "max": 5}]}`},
+			wantErr: `unknown field "dunks"`,
+		},
+		{
+			name:    "min above max",
+			sets:    map[string]string{"a.json": `{"league": "a", "rules": [{"name": "fouls", "field": "fouls", "min": 6, "max": 5}]}`},
+			wantErr: "min 6 is greater than max 5",
+		},
+		{
+			name:    "no league",
+			sets:    map[string]string{"a.json": `{"rules": []}`},
+			wantErr: "has no league",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validation.NewRegistry(writeRuleSets(t, tt.sets))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("NewRegistry() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
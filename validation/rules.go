@@ -0,0 +1,117 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"nba/model"
+)
+
+// GameContext carries the facts about a game that rules may depend on.
+type GameContext struct {
+	League          string
+	OvertimePeriods int
+}
+
+// Rule bounds a single stat field. Min and Max are optional; MaxPerOvertime
+// raises Max by that amount for every overtime period played.
+type Rule struct {
+	Name           string   `json:"name"`
+	Field          string   `json:"field"`
+	Min            *float64 `json:"min,omitempty"`
+	Max            *float64 `json:"max,omitempty"`
+	MaxPerOvertime float64  `json:"max_per_overtime,omitempty"`
+}
+
+// RuleSet is the declarative set of rules that applies to one league or competition.
+// A rule set may extend another one, overriding rules that share a name.
+type RuleSet struct {
+	League  string `json:"league"`
+	Extends string `json:"extends,omitempty"`
+	Rules   []Rule `json:"rules"`
+}
+
+// Violation describes a single rule a stat line failed.
+type Violation struct {
+	Rule    string
+	Field   string
+	Message string
+}
+
+// Error is returned when a stat line fails one or more rules. It carries every violation.
+type Error struct {
+	Violations []Violation
+}
+
+func (e *Error) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		messages = append(messages, v.Message)
+	}
+	return "invalid stat line: " + strings.Join(messages, "; ")
+}
+
+var fields = map[string]func(model.PlayerGameStats) float64{
+	"points":         func(s model.PlayerGameStats) float64 { return float64(s.Points) },
+	"rebounds":       func(s model.PlayerGameStats) float64 { return float64(s.Rebounds) },
+	"assists":        func(s model.PlayerGameStats) float64 { return float64(s.Assists) },
+	"steals":         func(s model.PlayerGameStats) float64 { return float64(s.Steals) },
+	"blocks":         func(s model.PlayerGameStats) float64 { return float64(s.Blocks) },
+	"turnovers":      func(s model.PlayerGameStats) float64 { return float64(s.Turnovers) },
+	"fouls":          func(s model.PlayerGameStats) float64 { return float64(s.Fouls) },
+	"minutes_played": func(s model.PlayerGameStats) float64 { return float64(s.MinutesPlayed) },
+}
+
+// check makes sure the rule refers to a known field and has sane bounds.
+func (r Rule) check() error {
+	if r.Name == "" {
+		return fmt.Errorf("rule on field %q has no name", r.Field)
+	}
+	if _, ok := fields[r.Field]; !ok {
+		return fmt.Errorf("rule %s: unknown field %q", r.Name, r.Field)
+	}
+	if r.Min != nil && r.Max != nil && *r.Min > *r.Max {
+		return fmt.Errorf("rule %s: min %v is greater than max %v", r.Name, *r.Min, *r.Max)
+	}
+	if r.MaxPerOvertime < 0 {
+		return fmt.Errorf("rule %s: max_per_overtime must not be negative", r.Name)
+	}
+	return nil
+}
+
+func (r Rule) apply(stats model.PlayerGameStats, game GameContext) *Violation {
+	value := fields[r.Field](stats)
+	if r.Min != nil && value < *r.Min {
+		return &Violation{
+			Rule:    r.Name,
+			Field:   r.Field,
+			Message: fmt.Sprintf("%s must be at least %v, got %v", r.Field, *r.Min, value),
+		}
+	}
+	if r.Max != nil {
+		limit := *r.Max + r.MaxPerOvertime*float64(game.OvertimePeriods)
+		if value > limit {
+			return &Violation{
+				Rule:    r.Name,
+				Field:   r.Field,
+				Message: fmt.Sprintf("%s must be at most %v, got %v", r.Field, limit, value),
+			}
+		}
+	}
+	return nil
+}
+
+// Validate runs every rule against the stat line and returns an *Error listing all
+// violations, or nil if the line is valid.
+func (rs *RuleSet) Validate(stats model.PlayerGameStats, game GameContext) error {
+	var violations []Violation
+	for _, rule := range rs.Rules {
+		if v := rule.apply(stats, game); v != nil {
+			violations = append(violations, *v)
+		}
+	}
+	if len(violations) > 0 {
+		return &Error{Violations: violations}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package validation_test
+
+import (
+	"errors"
+	"testing"
+
+	"nba/model"
+	"nba/validation"
+)
+
+func newRegistry(t *testing.T, dir string) *validation.Registry {
+	t.Helper()
+	registry, err := validation.NewRegistry(dir)
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+	return registry
+}
+
+// violations returns the rules err reports broken, failing the test if err is not a
+// *validation.Error.
+func violations(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var validationErr *validation.Error
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Validate() error = %v, want a *validation.Error", err)
+	}
+	rules := make([]string, 0, len(validationErr.Violations))
+	for _, v := range validationErr.Violations {
+		rules = append(rules, v.Rule)
+	}
+	return rules
+}
+
+func TestValidateZeroStatLine(t *testing.T) {
+	registry := newRegistry(t, "")
+	for _, league := range []string{"nba", "fiba", "wnba"} {
+		if err := registry.For(league).Validate(model.PlayerGameStats{}, validation.GameContext{League: league}); err != nil {
+			t.Errorf("%s: Validate() error = %v, want a zero line to pass", league, err)
+		}
+	}
+}
+
+func TestValidateMinutesPerOvertime(t *testing.T) {
+	nba := newRegistry(t, "").For("nba")
+	overtime := validation.GameContext{League: "nba", OvertimePeriods: 1}
+
+	if err := nba.Validate(model.PlayerGameStats{MinutesPlayed: 53}, overtime); err != nil {
+		t.Errorf("53 minutes with one overtime: Validate() error = %v", err)
+	}
+	err := nba.Validate(model.PlayerGameStats{MinutesPlayed: 54}, overtime)
+	if got := violations(t, err); len(got) != 1 || got[0] != "minutes_in_game" {
+		t.Fatalf("54 minutes with one overtime: violations = %v, want minutes_in_game", got)
+	}
+	if want := "invalid stat line: minutes_played must be at most 53, got 54"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+	if got := violations(t, nba.Validate(model.PlayerGameStats{MinutesPlayed: 49}, validation.GameContext{League: "nba"})); len(got) != 1 {
+		t.Errorf("49 minutes in regulation: violations = %v, want minutes_in_game", got)
+	}
+}
+
+func TestValidateFIBAFoulLimit(t *testing.T) {
+	registry := newRegistry(t, "")
+	fiba := registry.For("FIBA")
+	game := validation.GameContext{League: "fiba"}
+
+	if err := fiba.Validate(model.PlayerGameStats{Fouls: 5}, game); err != nil {
+		t.Errorf("5 fouls: Validate() error = %v", err)
+	}
+	if got := violations(t, fiba.Validate(model.PlayerGameStats{Fouls: 6}, game)); len(got) != 1 || got[0] != "personal_foul_limit" {
+		t.Errorf("6 fouls: violations = %v, want personal_foul_limit", got)
+	}
+	if err := registry.For("nba").Validate(model.PlayerGameStats{Fouls: 6}, validation.GameContext{League: "nba"}); err != nil {
+		t.Errorf("6 fouls in the NBA: Validate() error = %v", err)
+	}
+}
+
+func TestValidateReportsEveryViolation(t *testing.T) {
+	nba := newRegistry(t, "").For("nba")
+	stats := model.PlayerGameStats{Points: -1, Assists: -2, Fouls: 7, MinutesPlayed: 50}
+
+	got := violations(t, nba.Validate(stats, validation.GameContext{League: "nba"}))
+	want := []string{"points_non_negative", "assists_non_negative", "personal_foul_limit", "minutes_in_game"}
+	if len(got) != len(want) {
+		t.Fatalf("violations = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("violation %d = %s, want %s", i, got[i], want[i])
+		}
+	}
+}